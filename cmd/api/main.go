@@ -1,30 +1,53 @@
 package main
 
 import (
+	"badbuddy/internal/delivery/http/middleware"
 	"badbuddy/internal/delivery/http/rest"
 	"badbuddy/internal/delivery/http/ws"
+	"badbuddy/internal/domain/models"
 	"badbuddy/internal/infrastructure/database"
+	"badbuddy/internal/infrastructure/mail"
+	"badbuddy/internal/infrastructure/media"
+	"badbuddy/internal/infrastructure/pdf"
+	"badbuddy/internal/infrastructure/scheduler"
 	"badbuddy/internal/infrastructure/server"
+	"badbuddy/internal/infrastructure/sms"
 	"badbuddy/internal/repositories/postgres"
 	"badbuddy/internal/usecase/booking"
 	"badbuddy/internal/usecase/chat"
+	"badbuddy/internal/usecase/court"
+	"badbuddy/internal/usecase/export"
 	"badbuddy/internal/usecase/facility"
+	"badbuddy/internal/usecase/notification"
+	"badbuddy/internal/usecase/report"
+	"badbuddy/internal/usecase/search"
 	"badbuddy/internal/usecase/session"
 	"badbuddy/internal/usecase/user"
 	"badbuddy/internal/usecase/venue"
+	"badbuddy/internal/usecase/webhook"
 	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/go-co-op/gocron"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// version identifies the build; set it with -ldflags "-X main.version=<version>"
+var version = "dev"
+
 func main() {
+	startedAt := time.Now()
+
 	err := godotenv.Load(".env")
 	if err != nil {
 		log.Println("Warning: No .env file found")
@@ -33,14 +56,19 @@ func main() {
 	// Now that env vars are loaded, we can use getEnv
 	fmt.Println("badbuddy API", getEnv("DB_HOST", "beer"))
 
+	// Session search vectors are built by a trigger on the connection's setting,
+	// so the repository's queries and the stored vectors use the same configuration
+	sessionSearchLanguage := getEnv("SESSION_SEARCH_LANGUAGE", "simple")
+
 	// Create a new configuration
 	dbConfig := database.Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnvAsInt("DB_PORT", 5432),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", ""),
-		DBName:   getEnv("DB_NAME", "general"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+		Host:         getEnv("DB_HOST", "localhost"),
+		Port:         getEnvAsInt("DB_PORT", 5432),
+		User:         getEnv("DB_USER", "postgres"),
+		Password:     getEnv("DB_PASSWORD", ""),
+		DBName:       getEnv("DB_NAME", "general"),
+		SSLMode:      getEnv("DB_SSLMODE", "disable"),
+		SearchConfig: sessionSearchLanguage,
 	}
 
 	db, err := database.NewSQLxDB(dbConfig)
@@ -50,13 +78,33 @@ func main() {
 	defer database.CloseSQLxDB(db)
 
 	app := server.NewFiberServer()
+	app.Use(middleware.Metrics())
+	app.Use(middleware.Timeout(getEnvAsDuration("REQUEST_TIMEOUT", 10*time.Second)))
+	app.Use(middleware.NoStore())
+	publicCacheMaxAge := getEnvAsDuration("PUBLIC_CACHE_MAX_AGE", 30*time.Second)
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	chatHub := ws.NewChatHub()
+	imagePolicy := newImagePolicy()
+	phoneRegion := getEnv("PHONE_DEFAULT_REGION", "TH")
 
 	userRepo := postgres.NewUserRepository(db)
-	userUseCase := user.NewUserUseCase(userRepo, "your-jwt-secret", 24*time.Hour)
-	userHandler := rest.NewUserHandler(userUseCase)
-	userHandler.SetupUserRoutes(app)
+	app.Use(middleware.LastActive(userRepo, getEnvAsDuration("LAST_ACTIVE_INTERVAL", 5*time.Minute)))
+	userUseCase := user.NewUserUseCase(
+		userRepo,
+		"your-jwt-secret",
+		24*time.Hour,
+		getEnvAsInt("BCRYPT_COST", bcrypt.DefaultCost),
+		newMailer(),
+		getEnv("EMAIL_VERIFY_URL", "http://localhost:3000/verify-email"),
+		imagePolicy,
+		phoneRegion,
+	)
+
+	notificationRepo := postgres.NewNotificationRepository(db)
+	notificationUseCase := notification.NewNotificationUseCase(notificationRepo, notification.NewBroker(), newSMSSender())
+	notificationHandler := rest.NewNotificationHandler(notificationUseCase)
+	notificationHandler.SetupNotificationRoutes(app)
 
 	facilityRepo := postgres.NewFacilityRepository(db)
 	facilityUseCase := facility.NewFacilityUseCase(facilityRepo)
@@ -64,30 +112,72 @@ func main() {
 	facilityHandler.SetupFacilityRoutes(app)
 
 	venueRepo := postgres.NewVenueRepository(db)
-	venueUseCase := venue.NewVenueUseCase(venueRepo, userRepo)
-	venueHandler := rest.NewVenueHandler(venueUseCase, facilityUseCase, userUseCase)
+	bookingRepo := postgres.NewBookingRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db, sessionSearchLanguage)
+	chatRepo := postgres.NewChatRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	webhookUseCase := webhook.NewWebhookUseCase(webhookRepo, venueRepo)
+	venueUseCase := venue.NewVenueUseCase(venueRepo, userRepo, bookingRepo, sessionRepo, chatRepo, notificationUseCase, webhookUseCase, imagePolicy, phoneRegion)
+	venueHandler := rest.NewVenueHandler(venueUseCase, facilityUseCase, userUseCase, publicCacheMaxAge)
 	venueHandler.SetupVenueRoutes(app)
 
-	chatRepo := postgres.NewChatRepository(db)
-	chatUseCase := chat.NewChatUseCase(chatRepo, userRepo)
+	chatUseCase := chat.NewChatUseCase(chatRepo, userRepo, notificationUseCase, getEnvAsInt("CHAT_MAX_MESSAGE_LENGTH", 2000), imagePolicy)
 	chatHandler := rest.NewChatHandler(chatUseCase, chatHub)
 	chatHandler.SetupChatRoutes(app)
 	
-	sessionRepo := postgres.NewSessionRepository(db)
-	sessionUseCase := session.NewSessionUseCase(sessionRepo, venueRepo, chatRepo)
-	sessionHandler := rest.NewSessionHandler(sessionUseCase)
+	sessionUseCase := session.NewSessionUseCase(
+		sessionRepo,
+		venueRepo,
+		chatRepo,
+		userRepo,
+		notificationUseCase,
+		getEnvAsDuration("SESSION_MIN_DURATION", 30*time.Minute),
+		getEnvAsDuration("SESSION_MAX_DURATION", 6*time.Hour),
+		getEnvAsInt("SESSION_CANCELLATION_DEADLINE_HOURS", 24),
+		newCourtsPolicy(),
+	)
+	sessionHandler := rest.NewSessionHandler(sessionUseCase, chatUseCase, publicCacheMaxAge)
 	sessionHandler.SetupSessionRoutes(app)
 
-	bookingRepo := postgres.NewBookingRepository(db)
 	courtRepo := postgres.NewCourtRepository(db)
-	bookingUseCase := booking.NewBookingUseCase(bookingRepo, courtRepo, venueRepo, userRepo)
+	webhookHandler := rest.NewWebhookHandler(webhookUseCase)
+	webhookHandler.SetupWebhookRoutes(app)
+
+	bookingUseCase := booking.NewBookingUseCase(bookingRepo, courtRepo, venueRepo, userRepo, pdf.NewTextRenderer(), webhookUseCase, getEnvAsDuration("BOOKING_HOLD_DURATION", 15*time.Minute))
 	bookingHandler := rest.NewBookingHandler(bookingUseCase)
 	bookingHandler.SetupBookingRoutes(app)
 
-	cronJob(bookingUseCase)
-	app.Get("/ws/:chat_id", ws.ChatWebSocketHandler(chatHub))
+	reportRepo := postgres.NewReportRepository(db)
+	reportUseCase := report.NewReportUseCase(reportRepo)
+	reportHandler := rest.NewReportHandler(reportUseCase)
+	reportHandler.SetupReportRoutes(app)
+
+	adminHandler := rest.NewAdminHandler(bookingUseCase, userUseCase, reportUseCase, venueUseCase)
+	adminHandler.SetupAdminRoutes(app)
+
+	exportUseCase := export.NewExportUseCase(userUseCase, sessionUseCase, bookingUseCase, venueRepo, chatRepo)
+	userHandler := rest.NewUserHandler(userUseCase, sessionUseCase, exportUseCase, publicCacheMaxAge)
+	userHandler.SetupUserRoutes(app)
+
+	searchUseCase := search.NewSearchUseCase(venueUseCase, sessionUseCase, userUseCase)
+	searchHandler := rest.NewSearchHandler(searchUseCase)
+	searchHandler.SetupSearchRoutes(app)
+
+	courtUseCase := court.NewCourtUseCase(courtRepo, venueRepo, bookingRepo, userRepo)
+	courtHandler := rest.NewCourtHandler(courtUseCase)
+	courtHandler.SetupCourtRoutes(app)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	jobs := newScheduler(bookingUseCase, notificationUseCase, getEnvAsDuration("SMS_REMINDER_LEAD", 2*time.Hour))
+	jobs.Start(ctx)
+
+	app.Get("/ws/:chat_id", middleware.OptionalAuth(), ws.ChatWebSocketHandler(chatHub, chatRepo))
 
 	//add heatlh check and ready check
+	statusHandler := rest.NewStatusHandler(db, version, startedAt)
+	statusHandler.SetupStatusRoutes(app)
 
 	app.Get("*", func(c *fiber.Ctx) error {
 		return c.SendString("Hello, World 👋!")
@@ -96,10 +186,71 @@ func main() {
 		return c.SendString("OK")
 	})
 
+	// Stop taking requests on SIGINT or SIGTERM and let running jobs finish
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Failed to shut down server: %v", err)
+		}
+	}()
+
 	port := getEnv("PORT", "8004")
 	if err := app.Listen(":" + port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+
+	stop()
+	jobs.Wait()
+}
+
+// newMailer sends through SMTP_HOST when it is set, and only logs emails otherwise
+func newMailer() user.Mailer {
+	host := getEnv("SMTP_HOST", "")
+	if host == "" {
+		return mail.NewLogMailer()
+	}
+	return mail.NewSMTPMailer(
+		host,
+		getEnvAsInt("SMTP_PORT", 587),
+		getEnv("SMTP_USERNAME", ""),
+		getEnv("SMTP_PASSWORD", ""),
+		getEnv("SMTP_FROM", "no-reply@badbuddy.app"),
+	)
+}
+
+// newSMSSender returns nil unless SMS_ENABLED is set. It sends through Twilio
+// when TWILIO_ACCOUNT_SID is set, and only logs messages otherwise.
+func newSMSSender() notification.SMSSender {
+	if !getEnvAsBool("SMS_ENABLED", false) {
+		return nil
+	}
+
+	accountSID := getEnv("TWILIO_ACCOUNT_SID", "")
+	if accountSID == "" {
+		return sms.NewLogSender()
+	}
+	return sms.NewTwilioSender(accountSID, getEnv("TWILIO_AUTH_TOKEN", ""), getEnv("SMS_FROM", ""))
+}
+
+// newCourtsPolicy reads how many players share a court per play format, keeping the
+// defaults for unset values
+func newCourtsPolicy() session.CourtsPolicy {
+	policy := session.DefaultCourtsPolicy()
+	policy.PlayersPerCourt[models.SessionFormatSingles] = getEnvAsInt("SESSION_SINGLES_PLAYERS_PER_COURT", policy.PlayersPerCourt[models.SessionFormatSingles])
+	policy.PlayersPerCourt[models.SessionFormatDoubles] = getEnvAsInt("SESSION_DOUBLES_PLAYERS_PER_COURT", policy.PlayersPerCourt[models.SessionFormatDoubles])
+	policy.Enforce = getEnvAsBool("SESSION_ENFORCE_COURTS_REQUIRED", false)
+	return policy
+}
+
+// newImagePolicy reads the image limits, keeping the defaults for unset values
+func newImagePolicy() media.Policy {
+	policy := media.DefaultPolicy()
+	policy.MaxUploadBytes = int64(getEnvAsInt("IMAGE_MAX_UPLOAD_BYTES", int(policy.MaxUploadBytes)))
+	if types := getEnvAsList("IMAGE_ALLOWED_TYPES"); len(types) > 0 {
+		policy.AllowedTypes = types
+	}
+	policy.AllowedHosts = getEnvAsList("IMAGE_ALLOWED_HOSTS")
+	return policy
 }
 
 // Helper function to read an environment variable or return a default value
@@ -119,6 +270,26 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// Helper function to read an environment variable as a boolean or return a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// Helper function to read a comma separated environment variable, skipping empty items
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // Helper function to read an environment variable as a duration or return a default value
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := getEnv(key, "")
@@ -128,23 +299,37 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
-func cronJob(bookingUseCase booking.UseCase) {
-	cron := gocron.NewScheduler(time.UTC)
+// newScheduler registers the background jobs; they start with Start
+func newScheduler(bookingUseCase booking.UseCase, notificationUseCase notification.UseCase, reminderLead time.Duration) *scheduler.Scheduler {
+	jobs := scheduler.New()
 
-	// job 1
-	cron.Every("1m").Do(func() {
-		// ทำงานทุกๆ 5 นาที
-		ctx := context.Background()
+	// Mark courts occupied or available by today's confirmed bookings
+	jobs.Register("court-status", time.Minute, bookingUseCase.ChangeCourtStatus)
 
-		fmt.Println("Running cron job every 1 minute")
-		// Perform the task with context
-		err := bookingUseCase.ChangeCourtStatus(ctx)
+	// Release courts held by bookings that were never paid for
+	jobs.Register("expire-booking-holds", time.Minute, func(ctx context.Context) error {
+		expired, err := bookingUseCase.ExpireHolds(ctx)
 		if err != nil {
-			log.Printf("Error fetching users: %v", err)
-			return
+			return err
+		}
+		if expired > 0 {
+			log.Printf("Cancelled %d expired booking holds", expired)
 		}
+		return nil
+	})
 
+	// Text players and bookers before their session or booking starts; this is
+	// a no-op unless SMS is enabled
+	jobs.Register("sms-reminders", 5*time.Minute, func(ctx context.Context) error {
+		sent, err := notificationUseCase.SendReminders(ctx, reminderLead)
+		if err != nil {
+			return err
+		}
+		if sent > 0 {
+			log.Printf("Sent %d SMS reminders", sent)
+		}
+		return nil
 	})
 
-	cron.StartAsync()
+	return jobs
 }
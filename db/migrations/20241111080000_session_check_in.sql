@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE play_sessions ADD COLUMN check_in_code VARCHAR(6);
+ALTER TABLE session_participants ADD COLUMN checked_in_at TIMESTAMPTZ;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE session_participants DROP COLUMN IF EXISTS checked_in_at;
+ALTER TABLE play_sessions DROP COLUMN IF EXISTS check_in_code;
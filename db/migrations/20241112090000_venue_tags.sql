@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+CREATE TABLE IF NOT EXISTS "venue_tags" (
+    "venue_id" uuid NOT NULL,
+    "tag" varchar(50) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "venue_tags_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    PRIMARY KEY ("venue_id", "tag")
+);
+
+CREATE INDEX IF NOT EXISTS idx_venue_tags_tag ON venue_tags USING btree (tag);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS "venue_tags";
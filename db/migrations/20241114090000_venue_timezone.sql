@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "venues" ADD COLUMN IF NOT EXISTS "timezone" varchar(64) NOT NULL DEFAULT 'Asia/Bangkok';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "venues" DROP COLUMN IF EXISTS "timezone";
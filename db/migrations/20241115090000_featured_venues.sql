@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "venues" ADD COLUMN IF NOT EXISTS "featured" bool NOT NULL DEFAULT false;
+ALTER TABLE "venues" ADD COLUMN IF NOT EXISTS "featured_until" timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_venues_featured ON venues USING btree (featured_until) WHERE featured;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_venues_featured;
+ALTER TABLE "venues" DROP COLUMN IF EXISTS "featured_until";
+ALTER TABLE "venues" DROP COLUMN IF EXISTS "featured";
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "play_sessions" ADD COLUMN IF NOT EXISTS "cost_mode" varchar(10) NOT NULL DEFAULT 'fixed'
+    CHECK ("cost_mode" IN ('fixed', 'split'));
+ALTER TABLE "play_sessions" ADD COLUMN IF NOT EXISTS "total_court_cost" numeric(10,2) NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "play_sessions" DROP COLUMN IF EXISTS "total_court_cost";
+ALTER TABLE "play_sessions" DROP COLUMN IF EXISTS "cost_mode";
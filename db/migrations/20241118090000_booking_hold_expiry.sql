@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "court_bookings" ADD COLUMN IF NOT EXISTS "expires_at" timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_court_bookings_pending_expires_at ON court_bookings USING btree (expires_at) WHERE status = 'pending';
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_court_bookings_pending_expires_at;
+
+ALTER TABLE "court_bookings" DROP COLUMN IF EXISTS "expires_at";
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "courts" ADD COLUMN IF NOT EXISTS "max_players" int4 NOT NULL DEFAULT 4;
+
+ALTER TABLE "court_bookings" ADD COLUMN IF NOT EXISTS "player_count" int4 NOT NULL DEFAULT 2;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "court_bookings" DROP COLUMN IF EXISTS "player_count";
+
+ALTER TABLE "courts" DROP COLUMN IF EXISTS "max_players";
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "chat_messages" ADD COLUMN IF NOT EXISTS "is_pinned" bool NOT NULL DEFAULT false;
+ALTER TABLE "chat_messages" ADD COLUMN IF NOT EXISTS "pinned_at" timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_pinned ON chat_messages USING btree (chat_id) WHERE is_pinned;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_chat_messages_pinned;
+
+ALTER TABLE "chat_messages" DROP COLUMN IF EXISTS "pinned_at";
+ALTER TABLE "chat_messages" DROP COLUMN IF EXISTS "is_pinned";
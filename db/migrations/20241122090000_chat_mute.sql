@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "chat_participants" ADD COLUMN IF NOT EXISTS "muted" bool NOT NULL DEFAULT false;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "chat_participants" DROP COLUMN IF EXISTS "muted";
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+-- A direct chat has exactly two members; ones that gained more become group chats
+UPDATE "chats" SET "type" = 'group'
+WHERE "type" = 'direct'
+  AND (SELECT COUNT(*) FROM "chat_participants" WHERE "chat_participants"."chat_id" = "chats"."id") > 2;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "email_verified" bool NOT NULL DEFAULT false;
+
+-- Accounts from before verification existed keep hosting
+UPDATE "users" SET "email_verified" = true;
+
+CREATE TABLE IF NOT EXISTS "email_verification_tokens" (
+    "token_hash" varchar(64) NOT NULL,
+    "user_id" uuid NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "email_verification_tokens_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    PRIMARY KEY ("token_hash")
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens USING btree (user_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS "email_verification_tokens";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "email_verified";
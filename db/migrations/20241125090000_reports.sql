@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+CREATE TABLE IF NOT EXISTS "reports" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "reporter_id" uuid NOT NULL,
+    "target_type" varchar(20) NOT NULL,
+    "target_id" uuid NOT NULL,
+    "reason" text NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'open',
+    "resolved_by" uuid,
+    "resolved_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "reports_reporter_id_fkey" FOREIGN KEY ("reporter_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "reports_resolved_by_fkey" FOREIGN KEY ("resolved_by") REFERENCES "users"("id") ON DELETE SET NULL,
+    CONSTRAINT "reports_target_type_check" CHECK ("target_type" IN ('session', 'venue', 'user', 'message')),
+    CONSTRAINT "reports_status_check" CHECK ("status" IN ('open', 'resolved', 'dismissed')),
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_status_created_at ON reports USING btree (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reports_reporter_id_created_at ON reports USING btree (reporter_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports USING btree (target_type, target_id);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS "reports";
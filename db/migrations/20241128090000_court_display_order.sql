@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE courts ADD COLUMN display_order INT NOT NULL DEFAULT 0;
+
+-- Keep the current order, oldest court first
+UPDATE courts c SET display_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY venue_id ORDER BY created_at) AS position
+    FROM courts
+) ordered
+WHERE ordered.id = c.id;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE courts DROP COLUMN IF EXISTS display_order;
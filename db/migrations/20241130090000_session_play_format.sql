@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "play_sessions" ADD COLUMN IF NOT EXISTS "play_format" varchar(10) NOT NULL DEFAULT 'doubles'
+    CHECK ("play_format" IN ('singles', 'doubles'));
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "play_sessions" DROP COLUMN IF EXISTS "play_format";
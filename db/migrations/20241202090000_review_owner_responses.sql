@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "venue_reviews" ADD COLUMN IF NOT EXISTS "owner_response" text;
+ALTER TABLE "venue_reviews" ADD COLUMN IF NOT EXISTS "owner_responded_at" timestamptz;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "venue_reviews" DROP COLUMN IF EXISTS "owner_responded_at";
+ALTER TABLE "venue_reviews" DROP COLUMN IF EXISTS "owner_response";
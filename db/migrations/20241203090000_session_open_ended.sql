@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+ALTER TABLE "play_sessions" ADD COLUMN IF NOT EXISTS "open_ended" boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+ALTER TABLE "play_sessions" DROP COLUMN IF EXISTS "open_ended";
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+CREATE TABLE IF NOT EXISTS "venue_claims" (
+    "id" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "venue_id" uuid NOT NULL,
+    "claimant_id" uuid NOT NULL,
+    "phone" varchar(20) NOT NULL,
+    "evidence" text NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'pending',
+    "reviewed_by" uuid,
+    "reviewed_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "venue_claims_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_claims_claimant_id_fkey" FOREIGN KEY ("claimant_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "venue_claims_reviewed_by_fkey" FOREIGN KEY ("reviewed_by") REFERENCES "users"("id") ON DELETE SET NULL,
+    CONSTRAINT "venue_claims_status_check" CHECK ("status" IN ('pending', 'approved', 'rejected')),
+    PRIMARY KEY ("id")
+);
+
+-- A claimant has at most one pending claim per venue
+CREATE UNIQUE INDEX IF NOT EXISTS unique_pending_venue_claim ON venue_claims USING btree (venue_id, claimant_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_venue_claims_status_created_at ON venue_claims USING btree (status, created_at);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TABLE IF EXISTS "venue_claims";
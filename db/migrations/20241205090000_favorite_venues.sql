@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+CREATE TABLE IF NOT EXISTS "user_favorite_venues" (
+    "user_id" uuid NOT NULL,
+    "venue_id" uuid NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "user_favorite_venues_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "user_favorite_venues_venue_id_fkey" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE,
+    PRIMARY KEY ("user_id", "venue_id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_favorite_venues_user ON user_favorite_venues USING btree (user_id, created_at DESC);
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP INDEX IF EXISTS idx_user_favorite_venues_user;
+DROP TABLE IF EXISTS "user_favorite_venues";
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- The text search configuration comes from the badbuddy.search_config setting,
+-- which the API sets on its connections from SESSION_SEARCH_LANGUAGE, so stored
+-- vectors match the tsquery sessionRepository.Search builds
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION play_sessions_search_vector_update() RETURNS trigger AS $$
+DECLARE
+    config regconfig := COALESCE(NULLIF(current_setting('badbuddy.search_config', true), ''), 'simple')::regconfig;
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector(config, COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector(config, COALESCE(NEW.description, '')), 'B');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER play_sessions_search_vector_update
+    BEFORE INSERT OR UPDATE OF title, description ON play_sessions
+    FOR EACH ROW EXECUTE FUNCTION play_sessions_search_vector_update();
+
+-- Fires the trigger for existing rows
+UPDATE play_sessions SET title = title;
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+DROP TRIGGER IF EXISTS play_sessions_search_vector_update ON play_sessions;
+DROP FUNCTION IF EXISTS play_sessions_search_vector_update();
+UPDATE play_sessions SET search_vector = NULL;
@@ -7,12 +7,16 @@ type CreateBookingRequest struct {
 	StartTime string  `json:"start_time" validate:"required,datetime"`
 	EndTime   string  `json:"end_time" validate:"required,datetime"`
 	Notes     *string `json:"notes" validate:"omitempty,min=1,max=500"`
+	// PlayerCount defaults to two players, and can't be more than the court holds
+	PlayerCount int `json:"player_count" validate:"omitempty,min=1"`
 }
 
-// UpdateBookingRequest represents the request to update an existing booking
+// UpdateBookingRequest represents the request to update an existing booking.
+// Only the venue owner may change the status, and only the booker the player count.
 type UpdateBookingRequest struct {
-	Status string  `json:"status" validate:"omitempty,oneof=confirmed cancelled"`
-	Notes  *string `json:"notes" validate:"omitempty,min=1,max=500"`
+	Status      string  `json:"status" validate:"omitempty,oneof=confirmed cancelled"`
+	Notes       *string `json:"notes" validate:"omitempty,min=1,max=500"`
+	PlayerCount *int    `json:"player_count" validate:"omitempty,min=1"`
 }
 
 // CreatePaymentRequest represents the request to create a payment for a booking
@@ -30,13 +34,28 @@ type UpdatePaymentRequest struct {
 
 // ListBookingsRequest represents the request to list bookings with filters
 type ListBookingsRequest struct {
-	CourtID  string `json:"court_id" validate:"omitempty,uuid"`
-	VenueID  string `json:"venue_id" validate:"omitempty,uuid"`
-	DateFrom string `json:"date_from" validate:"omitempty,datetime"`
-	DateTo   string `json:"date_to" validate:"omitempty,datetime"`
-	Status   string `json:"status" validate:"omitempty,oneof=pending confirmed cancelled completed"`
-	Limit    int    `json:"limit" validate:"omitempty,min=1,max=100"`
-	Offset   int    `json:"offset" validate:"omitempty,min=0"`
+	CourtID       string `json:"court_id" validate:"omitempty,uuid"`
+	VenueID       string `json:"venue_id" validate:"omitempty,uuid"`
+	DateFrom      string `json:"date_from" validate:"omitempty,datetime"`
+	DateTo        string `json:"date_to" validate:"omitempty,datetime"`
+	Status        string `json:"status" validate:"omitempty,oneof=pending confirmed cancelled completed"`
+	PaymentStatus string `json:"payment_status" validate:"omitempty,oneof=pending completed failed refunded"`
+	Limit         int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset        int    `json:"offset" validate:"omitempty,min=0"`
+}
+
+// UserBookingsRequest filters the caller's own bookings
+type UserBookingsRequest struct {
+	When    string `json:"when" validate:"omitempty,oneof=upcoming past all"` // Defaults to upcoming
+	Sort    string `json:"sort" validate:"omitempty,oneof=asc desc"`          // Defaults to desc for past, asc otherwise
+	Status  string `json:"status" validate:"omitempty,oneof=pending confirmed cancelled"`
+	VenueID string `json:"venue_id" validate:"omitempty,uuid"`
+}
+
+// AdminListBookingsRequest filters bookings across all venues and users
+type AdminListBookingsRequest struct {
+	ListBookingsRequest
+	UserID string `json:"user_id" validate:"omitempty,uuid"`
 }
 
 // CheckAvailabilityRequest represents the request to check court availability
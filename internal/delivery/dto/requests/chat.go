@@ -2,5 +2,6 @@ package requests
 
 type SendAndUpdateMessageRequest struct {
 	Message string `json:"message"`
+	Type    string `json:"type"` // text (default) or image, whose message is the image URL; ignored on update
 }
 
@@ -5,14 +5,17 @@ type CreateCourtRequest struct {
 	Name         string  `json:"name" validate:"required,min=2,max=100"`
 	Description  string  `json:"description" validate:"omitempty,max=500"`
 	PricePerHour float64 `json:"price_per_hour" validate:"required,gt=0"`
+	MaxPlayers   int     `json:"max_players" validate:"omitempty,min=1,max=20"` // Defaults to 4
 }
 
+// UpdateCourtRequest is a partial update: nil fields are left unchanged
 type UpdateCourtRequest struct {
-	CourtID      string  `json:"court_id"`
-	Name         string  `json:"name" validate:"omitempty,min=2,max=100"`
-	Description  string  `json:"description" validate:"omitempty,max=500"`
-	PricePerHour float64 `json:"price_per_hour" validate:"omitempty,gt=0"`
-	Status       string  `json:"status" validate:"omitempty,oneof=available occupied maintenance"`
+	CourtID      string   `json:"court_id"`
+	Name         *string  `json:"name" validate:"omitempty,min=2,max=100"`
+	Description  *string  `json:"description" validate:"omitempty,max=500"`
+	PricePerHour *float64 `json:"price_per_hour" validate:"omitempty,gt=0"`
+	MaxPlayers   *int     `json:"max_players" validate:"omitempty,min=1,max=20"`
+	Status       *string  `json:"status" validate:"omitempty,oneof=available occupied maintenance"`
 }
 
 type UpdateCourtStatusRequest struct {
@@ -25,8 +28,20 @@ type ListCourtsRequest struct {
 	Location string  `json:"location" validate:"omitempty,max=100"`
 	PriceMin float64 `json:"price_min" validate:"omitempty,min=0"`
 	PriceMax float64 `json:"price_max" validate:"omitempty,gtefield=PriceMin"`
-	Limit    int     `json:"limit" validate:"omitempty,min=1,max=100"`
-	Offset   int     `json:"offset" validate:"omitempty,min=0"`
+	// Date, StartTime and EndTime together keep only courts free for the whole window
+	Date      string `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	StartTime string `json:"start_time" validate:"omitempty,datetime=15:04"`
+	EndTime   string `json:"end_time" validate:"omitempty,datetime=15:04"`
+	Limit     int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset    int    `json:"offset" validate:"omitempty,min=0"`
+}
+
+// BatchCourtAvailabilityRequest checks several courts for the same window at once
+type BatchCourtAvailabilityRequest struct {
+	CourtIDs  []string `json:"court_ids" validate:"required,min=1,max=50,dive,uuid"`
+	Date      string   `json:"date" validate:"required,datetime=2006-01-02"`
+	StartTime string   `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime   string   `json:"end_time" validate:"required,datetime=15:04,gtfield=StartTime"`
 }
 
 type CheckCourtAvailabilityRequest struct {
@@ -0,0 +1,19 @@
+package requests
+
+type CreateReportRequest struct {
+	TargetType string `json:"target_type" validate:"required,oneof=session venue user message"`
+	TargetID   string `json:"target_id" validate:"required,uuid"`
+	Reason     string `json:"reason" validate:"required,max=1000"`
+}
+
+// ListReportsRequest filters the admin report queue
+type ListReportsRequest struct {
+	Status     string `json:"status" validate:"omitempty,oneof=open resolved dismissed"` // Defaults to open
+	TargetType string `json:"target_type" validate:"omitempty,oneof=session venue user message"`
+	Limit      int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset     int    `json:"offset" validate:"omitempty,min=0"`
+}
+
+type ResolveReportRequest struct {
+	Status string `json:"status" validate:"required,oneof=resolved dismissed"`
+}
@@ -6,37 +6,78 @@ type CreateSessionRequest struct {
 	Description               string   `json:"description"`
 	SessionDate               string   `json:"session_date" validate:"required,datetime"`
 	StartTime                 string   `json:"start_time" validate:"required,datetime"`
-	EndTime                   string   `json:"end_time" validate:"required,datetime"`
+	EndTime                   string   `json:"end_time" validate:"required_unless=OpenEnded true"`
+	OpenEnded                 bool     `json:"open_ended"` // Runs until the venue closes; end_time is then ignored
 	PlayerLevel               string   `json:"player_level" validate:"required,oneof=beginner intermediate advanced"`
-	MaxParticipants           int      `json:"max_participants" validate:"required,min=2"`
-	CostPerPerson             float64  `json:"cost_per_person" validate:"required,min=0"`
+	MaxParticipants           int      `json:"max_participants" validate:"required,min=2"` // Includes the host
+	CostPerPerson             float64  `json:"cost_per_person" validate:"required_unless=CostMode split,min=0"`
+	CostMode                  string   `json:"cost_mode" validate:"omitempty,oneof=fixed split"`       // split derives cost_per_person from the courts
+	PlayFormat                string   `json:"play_format" validate:"omitempty,oneof=singles doubles"` // Defaults to doubles
 	AllowCancellation         bool     `json:"allow_cancellation"`
-	CancellationDeadlineHours int      `json:"cancellation_deadline_hours" validate:"required_if=AllowCancellation true,min=0"`
+	CancellationDeadlineHours *int     `json:"cancellation_deadline_hours" validate:"omitempty,min=0"` // Defaults to the configured deadline when cancellation is allowed
 	IsPublic                  bool     `json:"is_public"`
 	Rules                     []string `json:"rules" validate:"omitempty,dive,min=1"`
+	CourtIDs                  []string `json:"court_ids" validate:"omitempty,dive,uuid"`
 }
 
+// UpdateSessionRequest is a partial update: nil fields are left unchanged
 type UpdateSessionRequest struct {
-	Title                     string   `json:"title"`
-	Description               string   `json:"description"`
-	PlayerLevel               string   `json:"player_level" validate:"omitempty,oneof=beginner intermediate advanced"`
-	MaxParticipants           int      `json:"max_participants" validate:"omitempty,min=2"`
-	CostPerPerson             float64  `json:"cost_per_person" validate:"omitempty,min=0"`
-	Status                    string   `json:"status" validate:"omitempty,oneof=open full cancelled completed"`
-	AllowCancellation         bool     `json:"allow_cancellation"`
-	CancellationDeadlineHours int      `json:"cancellation_deadline_hours" validate:"omitempty,min=0"`
-	IsPublic                  bool     `json:"is_public"`
+	Title                     *string  `json:"title"`
+	Description               *string  `json:"description"`
+	SessionDate               *string  `json:"session_date" validate:"omitempty,datetime=2006-01-02"`
+	StartTime                 *string  `json:"start_time" validate:"omitempty,datetime=15:04"`
+	EndTime                   *string  `json:"end_time" validate:"omitempty,datetime=15:04"`
+	OpenEnded                 *bool    `json:"open_ended"` // Turning it off needs an end_time
+	PlayerLevel               *string  `json:"player_level" validate:"omitempty,oneof=beginner intermediate advanced"`
+	MaxParticipants           *int     `json:"max_participants" validate:"omitempty,min=2"` // Includes the host
+	CostPerPerson             *float64 `json:"cost_per_person" validate:"omitempty,min=0"`
+	CostMode                  *string  `json:"cost_mode" validate:"omitempty,oneof=fixed split"`
+	PlayFormat                *string  `json:"play_format" validate:"omitempty,oneof=singles doubles"`
+	Status                    *string  `json:"status" validate:"omitempty,oneof=open full cancelled completed"`
+	AllowCancellation         *bool    `json:"allow_cancellation"`
+	CancellationDeadlineHours *int     `json:"cancellation_deadline_hours" validate:"omitempty,min=0"`
+	IsPublic                  *bool    `json:"is_public"`
 	Rules                     []string `json:"rules" validate:"omitempty,dive,min=1"`
 }
 
+type BulkCancelSessionsRequest struct {
+	SessionDate string `json:"session_date" validate:"required,datetime=2006-01-02"`
+	VenueID     string `json:"venue_id" validate:"omitempty,uuid"` // Only cancel sessions at this venue
+}
+
+// BulkAddParticipantsRequest names the players by user ID, email or both
+type BulkAddParticipantsRequest struct {
+	UserIDs []string `json:"user_ids" validate:"omitempty,dive,uuid"`
+	Emails  []string `json:"emails" validate:"omitempty,dive,email"`
+}
+
+type BatchGetSessionsRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=50,dive,uuid"`
+}
+
 type JoinSessionRequest struct {
-	Message string `json:"message"` // Optional message for the host
+	Message        string `json:"message"`          // Optional message for the host
+	OpenDirectChat bool   `json:"open_direct_chat"` // Also open a direct chat with the host
+	WaitlistOK     bool   `json:"waitlist_ok"`      // Join as pending when the session is full instead of failing
 }
 
 type AddSessionRuleRequest struct {
 	RuleText string `json:"rule_text" validate:"required,min=1"`
 }
 
+type CompleteSessionRequest struct {
+	Attended []string `json:"attended" validate:"omitempty,dive,uuid"` // User IDs of confirmed participants who showed up
+}
+
+type AnnounceRequest struct {
+	Message string `json:"message" validate:"required,max=1000"`
+}
+
+type CheckInRequest struct {
+	Code   string `json:"code" validate:"required,len=6"`
+	UserID string `json:"user_id" validate:"omitempty,uuid"` // Defaults to the caller; only the host may check in others
+}
+
 type ChangeParticipantStatusRequest struct {
 	UserID string `json:"user_id" validate:"required,uuid"`
 	Status string `json:"status" validate:"required,oneof=confirmed pending cancelled"`
@@ -36,7 +36,18 @@ type SearchFilters struct {
 	Offset    int    `query:"offset" validate:"min=0"`
 }
 
+type LeaderboardRequest struct {
+	Metric   string `query:"metric"` // sessions_played (default), hosted or rating
+	Location string `query:"location"`
+	Limit    int    `query:"limit"`
+	Offset   int    `query:"offset"`
+}
+
 type UpdateRolesRequest struct {
 	UserID string `json:"user_id" validate:"required"`
 	Role  string  `json:"role" validate:"required"`
 }
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -17,6 +17,7 @@ type CreateVenueRequest struct {
 	Facilities  []Facility  `json:"facilities" validate:"required"`
 	Latitude    float64     `json:"latitude"`
 	Longitude   float64     `json:"longitude"`
+	Timezone    string      `json:"timezone"` // IANA name, e.g. "Asia/Bangkok"
 }
 
 type Facility struct {
@@ -34,20 +35,22 @@ type Rule struct {
 	Rule string `json:"rule"`
 }
 
+// UpdateVenueRequest is a partial update: nil fields are left unchanged
 type UpdateVenueRequest struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Address     string      `json:"address"`
-	Location    string      `json:"location"`
-	Phone       string      `json:"phone"`
-	Email       string      `json:"email"`
-	OpenRange   []OpenRange `json:"open_range" validate:"required"`
-	ImageURLs   string      `json:"image_urls"`
-	Status      string      `json:"status"`
+	Name        *string     `json:"name"`
+	Description *string     `json:"description"`
+	Address     *string     `json:"address"`
+	Location    *string     `json:"location"`
+	Phone       *string     `json:"phone"`
+	Email       *string     `json:"email" validate:"omitempty,email"`
+	OpenRange   []OpenRange `json:"open_range"`
+	ImageURLs   *string     `json:"image_urls"`
+	Status      *string     `json:"status" validate:"omitempty,oneof=active inactive maintenance"`
 	Rules       []Rule      `json:"rules"`
 	Facilities  []Facility  `json:"facilities"`
-	Latitude    float64     `json:"latitude"`
-	Longitude   float64     `json:"longitude"`
+	Latitude    *float64    `json:"latitude"`
+	Longitude   *float64    `json:"longitude"`
+	Timezone    *string     `json:"timezone"`
 }
 
 // type CreateCourtRequest struct {
@@ -64,7 +67,37 @@ type UpdateVenueRequest struct {
 // 	Status       string  `json:"status"`
 // }
 
+// ReorderCourtsRequest lists every court of the venue in the order they should be shown
+type ReorderCourtsRequest struct {
+	CourtIDs []string `json:"court_ids" validate:"required,dive,uuid"`
+}
+
+type SetVenueTagsRequest struct {
+	Tags []string `json:"tags" validate:"dive,min=1,max=50"`
+}
+
+type SetFeaturedVenueRequest struct {
+	Featured      bool       `json:"featured"`
+	FeaturedUntil *time.Time `json:"featured_until"`
+}
+
 type AddReviewRequest struct {
 	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
 	Comment string `json:"comment"`
 }
+
+// ClaimVenueRequest asks to take over a venue without an owner. Admins call the
+// phone number and check the evidence, e.g. a link to a business registration.
+type ClaimVenueRequest struct {
+	Phone    string `json:"phone" validate:"required"`
+	Evidence string `json:"evidence" validate:"required,max=1000"`
+}
+
+type ReviewVenueClaimRequest struct {
+	Status string `json:"status" validate:"required,oneof=approved rejected"`
+}
+
+// RespondToReviewRequest is the venue owner's public reply to a review
+type RespondToReviewRequest struct {
+	Response string `json:"response" validate:"required,max=1000"`
+}
@@ -0,0 +1,6 @@
+package requests
+
+type RegisterWebhookRequest struct {
+	URL    string `json:"url" validate:"required,url"`
+	Secret string `json:"secret" validate:"omitempty,min=16,max=128"` // Generated when empty
+}
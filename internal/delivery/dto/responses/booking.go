@@ -19,11 +19,13 @@ type BookingResponse struct {
 	EndTime       string           `json:"end_time"`
 	Duration      string           `json:"duration"`
 	TotalAmount   float64          `json:"total_amount"`
+	PlayerCount   int              `json:"player_count"`
 	Status        string           `json:"status"`
 	Notes         string           `json:"notes,omitempty"`
 	CreatedAt     string           `json:"created_at"`
 	UpdatedAt     string           `json:"updated_at"`
 	CancelledAt   string           `json:"cancelled_at,omitempty"`
+	ExpiresAt     string           `json:"expires_at,omitempty"` // Unpaid bookings are cancelled after this time
 	Payment       *PaymentResponse `json:"payment,omitempty"`
 }
 
@@ -12,6 +12,7 @@ type ChatMassageResponse struct {
 	ChatID        string           `json:"chat_id"`
 	Autor         UserChatResponse `json:"autor"`
 	Message       string           `json:"message"`
+	IsPinned      bool             `json:"is_pinned"`
 	Timestamp     time.Time        `json:"timestamp"`
 	EditTimeStamp time.Time        `json:"edit_timestamp"`
 }
@@ -21,16 +22,42 @@ type BoardCastMessageResponse struct {
 	Data         interface{} `json:"data,omitempty"`
 }
 
+// Ephemeral chat socket events. They go to the room's other clients and are
+// never stored.
+const (
+	BoardCastTyping      = "typing"
+	BoardCastStopTyping  = "stop_typing"
+	BoardCastUserOnline  = "user_online"
+	BoardCastUserOffline = "user_offline"
+	BoardCastPresence    = "presence" // Sent only to a newly connected client
+)
+
 type ChatListResponse struct {
-	Chats []ChatResponse `json:"chats"`
+	Chats   []ChatResponse `json:"chats"`
+	Total   int            `json:"total"`
+	Limit   int            `json:"limit"`
+	Offset  int            `json:"offset"`
+	HasMore bool           `json:"has_more"`
 }
 
 type ChatResponse struct {
-	ID          string               `json:"id"`
-	Type        string               `json:"type"`
-	SessionID   string               `json:"session_id"`
-	LastMessage *ChatMassageResponse `json:"last_message"`
-	Users       []UserChatResponse   `json:"users"`
+	ID          string                  `json:"id"`
+	Type        string                  `json:"type"`
+	SessionID   string                  `json:"session_id"`
+	Muted       bool                    `json:"muted"`
+	LastMessage *MessagePreviewResponse `json:"last_message"`
+	Users       []UserChatResponse      `json:"users"`
+}
+
+// MessagePreviewResponse is the short form of a chat's last message shown in the inbox
+type MessagePreviewResponse struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Preview    string    `json:"preview"`
+	SenderID   string    `json:"sender_id"`
+	SenderName string    `json:"sender_name"`
+	Timestamp  time.Time `json:"timestamp"`
+	Unread     bool      `json:"unread"`
 }
 
 type UserListResponse struct {
@@ -7,3 +7,26 @@ type CourtListResponse struct {
 	Limit  int             `json:"limit"`
 	Offset int             `json:"offset"`
 }
+
+// CourtDetailResponse is a court with what a court page shows of its venue
+type CourtDetailResponse struct {
+	CourtResponse
+	Venue CourtVenueResponse `json:"venue"`
+}
+
+type CourtVenueResponse struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Location   string            `json:"location"`
+	Timezone   string            `json:"timezone"`
+	TodayHours OpenRangeResponse `json:"today_hours"`
+}
+
+// BatchCourtAvailabilityResponse says whether each court is free for the window,
+// in the order the courts were asked for
+type BatchCourtAvailabilityResponse struct {
+	Date      string                      `json:"date"`
+	StartTime string                      `json:"start_time"`
+	EndTime   string                      `json:"end_time"`
+	Courts    []CourtAvailabilityResponse `json:"courts"`
+}
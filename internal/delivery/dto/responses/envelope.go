@@ -0,0 +1,45 @@
+package responses
+
+// Envelope is the body of every API response. Successful responses carry Data
+// (plus Meta for paginated lists) and failed ones carry Error.
+type Envelope struct {
+	Data    interface{}    `json:"data,omitempty"`
+	Meta    *Meta          `json:"meta,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Error   *ErrorResponse `json:"error,omitempty"`
+}
+
+// Meta describes the page returned by a list endpoint
+type Meta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// OK wraps a successful payload
+func OK(data interface{}) Envelope {
+	return Envelope{Data: data}
+}
+
+// OKMessage wraps a successful payload with a message for the client; data may be nil
+func OKMessage(message string, data interface{}) Envelope {
+	return Envelope{Data: data, Message: message}
+}
+
+// Paginated wraps one page of a list together with its pagination details
+func Paginated(data interface{}, total, limit, offset int) Envelope {
+	return Envelope{
+		Data: data,
+		Meta: &Meta{Total: total, Limit: limit, Offset: offset},
+	}
+}
+
+// Fail wraps an error
+func Fail(err ErrorResponse) Envelope {
+	return Envelope{Error: &err}
+}
+
+// FailMessage wraps an error that only has a message
+func FailMessage(message string) Envelope {
+	return Fail(ErrorResponse{Error: message})
+}
@@ -0,0 +1,21 @@
+package responses
+
+// ExportedReviewResponse is a review as it appears in a user's data export
+type ExportedReviewResponse struct {
+	ID        string `json:"id"`
+	VenueID   string `json:"venue_id"`
+	Rating    int    `json:"rating"`
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ExportedMessageResponse is a chat message as it appears in a user's data export
+type ExportedMessageResponse struct {
+	ID        string `json:"id"`
+	ChatID    string `json:"chat_id"`
+	Type      string `json:"type"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
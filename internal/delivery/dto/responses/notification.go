@@ -0,0 +1,21 @@
+package responses
+
+import "encoding/json"
+
+type NotificationResponse struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	ReadAt    string          `json:"read_at,omitempty"`
+	CreatedAt string          `json:"created_at"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int `json:"marked"`
+}
+
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
@@ -0,0 +1,20 @@
+package responses
+
+type ReportResponse struct {
+	ID         string  `json:"id"`
+	ReporterID string  `json:"reporter_id"`
+	TargetType string  `json:"target_type"`
+	TargetID   string  `json:"target_id"`
+	Reason     string  `json:"reason"`
+	Status     string  `json:"status"`
+	ResolvedBy *string `json:"resolved_by,omitempty"`
+	ResolvedAt *string `json:"resolved_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type ReportListResponse struct {
+	Reports []ReportResponse `json:"reports"`
+	Total   int              `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
@@ -0,0 +1,9 @@
+package responses
+
+// SearchResponse groups the results of a search across venues, sessions and users.
+// Groups that were not searched or have no matches are left out.
+type SearchResponse struct {
+	Venues   []VenueResponse   `json:"venues,omitempty"`
+	Sessions []SessionResponse `json:"sessions,omitempty"`
+	Users    []UserResponse    `json:"users,omitempty"`
+}
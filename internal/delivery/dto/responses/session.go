@@ -7,6 +7,8 @@ type ParticipantResponse struct {
 	Status      string `json:"status"`
 	JoinedAt    string `json:"joined_at"`
 	CancelledAt string `json:"cancelled_at,omitempty"`
+	CheckedInAt string `json:"checked_in_at,omitempty"`
+	JoinMessage string `json:"join_message,omitempty"`
 }
 
 type SessionRuleResponse struct {
@@ -27,18 +29,27 @@ type SessionResponse struct {
 	HostGender                string                `json:"host_gender"`
 	SessionDate               string                `json:"session_date"`
 	StartTime                 string                `json:"start_time"`
-	EndTime                   string                `json:"end_time"`
+	EndTime                   string                `json:"end_time"`   // The venue's closing time for open-ended sessions
+	OpenEnded                 bool                  `json:"open_ended"` // No fixed end: the courts are held until the venue closes
 	PlayerLevel               string                `json:"player_level"`
-	MaxParticipants           int                   `json:"max_participants"`
+	MaxParticipants           int                   `json:"max_participants"` // Includes the host
 	CostPerPerson             float64               `json:"cost_per_person"`
+	CostMode                  string                `json:"cost_mode"`
+	PlayFormat                string                `json:"play_format"`
+	CourtsNeeded              int                   `json:"courts_needed,omitempty"` // Courts max_participants takes in this format
+	TotalCourtCost            float64               `json:"total_court_cost,omitempty"`
+	CostPerConfirmedPlayer    float64               `json:"cost_per_confirmed_player,omitempty"` // Split sessions only; changes as players join or leave
 	Status                    string                `json:"status"`
 	AllowCancellation         bool                  `json:"allow_cancellation"`
 	CancellationDeadlineHours *int                  `json:"cancellation_deadline_hours,omitempty"`
 	IsPublic                  bool                  `json:"is_public"`
-	ConfirmedPlayers          int                   `json:"confirmed_players"`
+	CheckInCode               string                `json:"check_in_code,omitempty"` // Only exposed to the host
+	ConfirmedPlayers          int                   `json:"confirmed_players"`       // Includes the host
 	PendingPlayers            int                   `json:"pending_players"`
+	OpenSpots                 int                   `json:"open_spots"` // Places left for players to join as confirmed
 	Participants              []ParticipantResponse `json:"participants,omitempty"`
 	Rules                     []SessionRuleResponse `json:"rules,omitempty"`
+	Courts                    []CourtResponse       `json:"courts,omitempty"`
 	CreatedAt                 string                `json:"created_at"`
 	UpdatedAt                 string                `json:"updated_at"`
 }
@@ -48,15 +59,24 @@ type SessionListResponse struct {
 	Total    int               `json:"total"`
 }
 
-// Error responses
+type JoinSessionResponse struct {
+	Status       string `json:"status,omitempty"`         // confirmed, or pending while the host approves or a place frees up
+	DirectChatID string `json:"direct_chat_id,omitempty"` // Set when a direct chat with the host was asked for
+}
+
+// ErrorResponse is the error object of an Envelope
+type BulkCancelSessionsResponse struct {
+	CancelledSessionIDs []string `json:"cancelled_session_ids"`
+}
+
+type BulkAddParticipantsResponse struct {
+	AddedUserIDs []string `json:"added_user_ids"`
+	// SkippedUserIDs already had a place in the session, or had left it
+	SkippedUserIDs []string `json:"skipped_user_ids"`
+}
+
 type ErrorResponse struct {
-	Error       string `json:"error"`
+	Error       string `json:"message"`
 	Code        string `json:"code,omitempty"`
 	Description string `json:"description,omitempty"`
 }
-
-// Success responses
-type SuccessResponse struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-}
@@ -0,0 +1,15 @@
+package responses
+
+// StatusResponse describes the running build and its dependencies
+type StatusResponse struct {
+	Version       string         `json:"version"`
+	StartedAt     string         `json:"started_at"`
+	UptimeSeconds int64          `json:"uptime_seconds"`
+	Database      DatabaseStatus `json:"database"`
+}
+
+type DatabaseStatus struct {
+	Status    string  `json:"status"` // ok or unavailable
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
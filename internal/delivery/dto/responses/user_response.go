@@ -3,20 +3,21 @@ package responses
 import "time"
 
 type UserResponse struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	FirstName    string    `json:"first_name"`
-	LastName     string    `json:"last_name"`
-	Phone        string    `json:"phone"`
-	PlayLevel    string    `json:"play_level"`
-	Location     string    `json:"location"`
-	Bio          string    `json:"bio"`
-	Gender       string    `json:"gender"`
-	PlayHand     string    `json:"play_hand"`
-	AvatarURL    string    `json:"avatar_url"`
-	LastActiveAt time.Time `json:"last_active_at"`
-	Role         string    `json:"role"`
-	Venues       []Venue   `json:"venues"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Phone         string    `json:"phone"`
+	PlayLevel     string    `json:"play_level"`
+	Location      string    `json:"location"`
+	Bio           string    `json:"bio"`
+	Gender        string    `json:"gender"`
+	PlayHand      string    `json:"play_hand"`
+	AvatarURL     string    `json:"avatar_url"`
+	LastActiveAt  time.Time `json:"last_active_at"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"email_verified"`
+	Venues        []Venue   `json:"venues"`
 }
 
 type UserProfileResponse struct {
@@ -26,9 +27,31 @@ type UserProfileResponse struct {
 	AverageRating   float64 `json:"average_rating"`
 	TotalReviews    int     `json:"total_reviews"`
 	RegularPartners int     `json:"regular_partners"`
+	NoShowSessions  int     `json:"no_show_sessions"`
 	Venues          []Venue `json:"venues"`
 }
 
+type LeaderboardEntryResponse struct {
+	Rank           int     `json:"rank"`
+	UserID         string  `json:"user_id"`
+	FirstName      string  `json:"first_name"`
+	LastName       string  `json:"last_name"`
+	AvatarURL      string  `json:"avatar_url"`
+	Location       string  `json:"location"`
+	PlayLevel      string  `json:"play_level"`
+	SessionsPlayed int     `json:"sessions_played"`
+	HostedSessions int     `json:"hosted_sessions"`
+	AverageRating  float64 `json:"average_rating"`
+	TotalReviews   int     `json:"total_reviews"`
+}
+
+type LeaderboardResponse struct {
+	Entries []LeaderboardEntryResponse `json:"entries"`
+	Total   int                        `json:"total"`
+	Limit   int                        `json:"limit"`
+	Offset  int                        `json:"offset"`
+}
+
 type Venue struct {
 	ID string `json:"id"`
 }
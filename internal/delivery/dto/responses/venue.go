@@ -7,27 +7,65 @@ type CourtResponse struct {
 	Name         string  `json:"name"`
 	Description  string  `json:"description"`
 	PricePerHour float64 `json:"price_per_hour"`
+	MaxPlayers   int     `json:"max_players"`
 	Status       string  `json:"status"`
 }
 
 type VenueResponse struct {
-	ID           string              `json:"id"`
-	Name         string              `json:"name"`
-	Description  string              `json:"description"`
-	Address      string              `json:"address"`
-	Location     string              `json:"location"`
-	Phone        string              `json:"phone"`
-	Email        string              `json:"email"`
-	OpenRange    []OpenRangeResponse `json:"open_range" validate:"required"`
-	ImageURLs    string              `json:"image_urls"`
-	Status       string              `json:"status"`
-	Rating       float64             `json:"rating"`
-	TotalReviews int                 `json:"total_reviews"`
-	Courts       []CourtResponse     `json:"courts"`
-	Facilities   []FacilityResponse  `json:"facilities"`
-	Rules        []RuleResponse      `json:"rules"`
-	Latitude     float64             `json:"latitude"`
-	Longitude    float64             `json:"longitude"`
+	ID            string              `json:"id"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	Address       string              `json:"address"`
+	Location      string              `json:"location"`
+	Phone         string              `json:"phone"`
+	Email         string              `json:"email"`
+	OpenRange     []OpenRangeResponse `json:"open_range" validate:"required"`
+	IsOpenNow     bool                `json:"is_open_now"`
+	NextOpenAt    *time.Time          `json:"next_open_at,omitempty"`
+	NextCloseAt   *time.Time          `json:"next_close_at,omitempty"`
+	ImageURLs     string              `json:"image_urls"`
+	Status        string              `json:"status"`
+	Rating        float64             `json:"rating"`
+	TotalReviews  int                 `json:"total_reviews"`
+	Courts        []CourtResponse     `json:"courts"`
+	Facilities    []FacilityResponse  `json:"facilities"`
+	Rules         []RuleResponse      `json:"rules"`
+	Tags          []string            `json:"tags"`
+	Latitude      float64             `json:"latitude"`
+	Longitude     float64             `json:"longitude"`
+	Timezone      string              `json:"timezone"`
+	Featured      bool                `json:"featured"`
+	FeaturedUntil *time.Time          `json:"featured_until,omitempty"`
+	Relevance     float64             `json:"relevance,omitempty"`
+	UpdatedAt     string              `json:"updated_at"` // Latest change to the venue or one of its courts
+	Owner         *VenueOwnerResponse `json:"owner,omitempty"`
+
+	RatingBreakdown *RatingBreakdownResponse `json:"rating_breakdown,omitempty"` // Only with include=rating_breakdown
+
+	// Only set for signed in callers
+	HasReviewed *bool           `json:"has_reviewed,omitempty"`
+	MyReview    *ReviewResponse `json:"my_review,omitempty"`
+	IsFavorited *bool           `json:"is_favorited,omitempty"`
+}
+
+// RatingBreakdownResponse counts a venue's reviews per star rating, from 5 stars down to 1
+type RatingBreakdownResponse struct {
+	VenueID      string                `json:"venue_id"`
+	Rating       float64               `json:"rating"`
+	TotalReviews int                   `json:"total_reviews"`
+	Counts       []RatingCountResponse `json:"counts"`
+}
+
+type RatingCountResponse struct {
+	Stars int `json:"stars"`
+	Count int `json:"count"`
+}
+
+// VenueOwnerResponse is the public part of the profile of the user who manages a venue
+type VenueOwnerResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
 }
 
 type OpenRangeResponse struct {
@@ -47,16 +85,91 @@ type VenueResponseDTO struct {
 }
 
 type ListVenueResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Featured    bool   `json:"featured"`
+	IsFavorited *bool  `json:"is_favorited,omitempty"` // Only set for signed in callers
+}
+
+// VenueScheduleResponse is the court × time slot grid of a venue for one day
+type VenueScheduleResponse struct {
+	VenueID   string                  `json:"venue_id"`
+	Date      string                  `json:"date"`
+	IsOpen    bool                    `json:"is_open"`
+	OpenTime  string                  `json:"open_time,omitempty"`
+	CloseTime string                  `json:"close_time,omitempty"`
+	Courts    []CourtScheduleResponse `json:"courts"`
+}
+
+type CourtScheduleResponse struct {
+	CourtID      string         `json:"court_id"`
+	CourtName    string         `json:"court_name"`
+	PricePerHour float64        `json:"price_per_hour"`
+	Slots        []ScheduleSlot `json:"slots"`
+}
+
+// ScheduleSlot is a 30 minute slot. Status is free, booked or session.
+type ScheduleSlot struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Status    string `json:"status"`
+	BookingID string `json:"booking_id,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// AffectedSessionResponse is an upcoming session that held a court when it went into
+// maintenance. ReassignedCourtID is empty when no other court was free and the host was
+// asked to pick one.
+type AffectedSessionResponse struct {
+	SessionID           string `json:"session_id"`
+	Title               string `json:"title"`
+	SessionDate         string `json:"session_date"`
+	StartTime           string `json:"start_time"`
+	EndTime             string `json:"end_time"`
+	ReassignedCourtID   string `json:"reassigned_court_id,omitempty"`
+	ReassignedCourtName string `json:"reassigned_court_name,omitempty"`
+}
+
+type UpdateCourtResponse struct {
+	AffectedSessions []AffectedSessionResponse `json:"affected_sessions"`
+}
+
+// VenueDashboardResponse summarises a venue for its owner. Dates are in the venue's timezone
+// and revenue counts completed payments for bookings in the current Monday to Sunday week.
+type VenueDashboardResponse struct {
+	VenueID           string          `json:"venue_id"`
+	Date              string          `json:"date"`
+	TodayBookings     int             `json:"today_bookings"`
+	UpcomingSessions  int             `json:"upcoming_sessions"`
+	Rating            float64         `json:"rating"`
+	TotalReviews      int             `json:"total_reviews"`
+	MaintenanceCourts []CourtResponse `json:"maintenance_courts"`
+	WeekStart         string          `json:"week_start"`
+	WeekEnd           string          `json:"week_end"`
+	WeekRevenue       float64         `json:"week_revenue"`
 }
 
 type ReviewResponse struct {
-	ID        string           `json:"id"`
-	Rating    int              `json:"rating"`
-	Comment   string           `json:"comment"`
-	CreatedAt string           `json:"created_at"`
-	Reviewer  ReviewerResponse `json:"reviewer"`
+	ID            string              `json:"id"`
+	Rating        int                 `json:"rating"`
+	Comment       string              `json:"comment"`
+	HelpfulCount  int                 `json:"helpful_count"`
+	CreatedAt     string              `json:"created_at"`
+	Reviewer      ReviewerResponse    `json:"reviewer"`
+	OwnerResponse *OwnerReplyResponse `json:"owner_response,omitempty"`
+}
+
+// OwnerReplyResponse is the venue owner's public reply to a review
+type OwnerReplyResponse struct {
+	Response    string `json:"response"`
+	RespondedAt string `json:"responded_at"`
+}
+
+// ReviewVoteResponse is the caller's helpful vote on a review after toggling it
+type ReviewVoteResponse struct {
+	ReviewID     string `json:"review_id"`
+	Helpful      bool   `json:"helpful"`
+	HelpfulCount int    `json:"helpful_count"`
 }
 
 type ReviewerResponse struct {
@@ -64,3 +177,22 @@ type ReviewerResponse struct {
 	LastName  string `json:"last_name"`
 	AvatarURL string `json:"avatar_url"`
 }
+
+type VenueClaimResponse struct {
+	ID         string  `json:"id"`
+	VenueID    string  `json:"venue_id"`
+	ClaimantID string  `json:"claimant_id"`
+	Phone      string  `json:"phone"`
+	Evidence   string  `json:"evidence"`
+	Status     string  `json:"status"`
+	ReviewedBy *string `json:"reviewed_by,omitempty"`
+	ReviewedAt *string `json:"reviewed_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type VenueClaimListResponse struct {
+	Claims []VenueClaimResponse `json:"claims"`
+	Total  int                  `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
@@ -0,0 +1,9 @@
+package responses
+
+type WebhookResponse struct {
+	ID        string `json:"id"`
+	VenueID   string `json:"venue_id"`
+	URL       string `json:"url"`
+	Secret    string `json:"secret,omitempty"` // Only returned when the webhook is registered
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+var ErrAdminRequired = errors.New("admin access required")
+
+// AdminChecker reports whether a user has the admin role
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// AdminRequired only lets admins through. It must run after AuthRequired.
+func AdminRequired(checker AdminChecker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := GetUserID(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage(err.Error()))
+		}
+
+		isAdmin, err := checker.IsAdmin(c.UserContext(), userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+		}
+		if !isAdmin {
+			return c.Status(fiber.StatusForbidden).JSON(responses.FailMessage(ErrAdminRequired.Error()))
+		}
+
+		return c.Next()
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"strings"
 
+	"badbuddy/internal/delivery/dto/responses"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
@@ -22,50 +24,72 @@ func AuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrNoAuthHeader.Error(),
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage(ErrNoAuthHeader.Error()))
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidFormat.Error(),
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage(ErrInvalidFormat.Error()))
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fiber.ErrUnauthorized
-			}
-			return []byte("your-jwt-secret"), nil
-		})
+		userID, err := parseUserID(tokenString)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage(err.Error()))
+		}
+
+		// Set user ID in context for later use
+		c.Locals("userID", userID)
 
-		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidToken.Error(),
-			})
+		return c.Next()
+	}
+}
+
+// OptionalAuth sets the user ID when the request carries a valid token, either
+// as a bearer Authorization header or a ?token= query parameter for clients
+// like browser WebSockets that can't set headers. Requests without one go on
+// anonymously.
+func OptionalAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenString := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		if tokenString == "" {
+			tokenString = c.Query("token")
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidClaims.Error(),
-			})
+		if tokenString != "" {
+			if userID, err := parseUserID(tokenString); err == nil {
+				c.Locals("userID", userID)
+			}
 		}
 
-		userID, err := uuid.Parse(claims["user_id"].(string))
-		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": ErrInvalidUserID.Error(),
-			})
+		return c.Next()
+	}
+}
+
+// parseUserID validates the token and returns the user ID in its claims
+func parseUserID(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fiber.ErrUnauthorized
 		}
+		return []byte("your-jwt-secret"), nil
+	})
 
-		// Set user ID in context for later use
-		c.Locals("userID", userID)
+	if err != nil || !token.Valid {
+		return uuid.Nil, ErrInvalidToken
+	}
 
-		return c.Next()
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, ErrInvalidClaims
+	}
+
+	rawUserID, _ := claims["user_id"].(string)
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return uuid.Nil, ErrInvalidUserID
 	}
+
+	return userID, nil
 }
 
 // GetUserID gets the user ID from the Fiber context
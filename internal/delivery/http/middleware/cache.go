@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PublicCache lets browsers and CDNs keep successful anonymous GET responses
+// for maxAge. Requests carrying an Authorization header may see user-specific
+// data, so they are left to NoStore. A zero maxAge disables it.
+func PublicCache(maxAge time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		c.Vary(fiber.HeaderAuthorization)
+		if maxAge <= 0 || c.Method() != fiber.MethodGet || c.Response().StatusCode() != fiber.StatusOK || c.Get(fiber.HeaderAuthorization) != "" {
+			return nil
+		}
+
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+		return nil
+	}
+}
+
+// NoStore keeps responses to authenticated requests out of shared and browser
+// caches, unless the handler chose its own Cache-Control
+func NoStore() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		if c.Get(fiber.HeaderAuthorization) != "" && len(c.Response().Header.Peek(fiber.HeaderCacheControl)) == 0 {
+			c.Set(fiber.HeaderCacheControl, "no-store")
+		}
+		return err
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ETag tags successful GET responses with a hash of their body and answers a
+// matching If-None-Match with 304. The tag covers the exact bytes sent, so it
+// changes with anything the handler puts in the body: the caller's own fields,
+// query options, fields computed from the current time and writes landing in
+// the same second. Handlers whose body depends on the caller still have to set
+// Vary for shared caches.
+func ETag() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if c.Method() != fiber.MethodGet || c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		sum := sha256.Sum256(c.Response().Body())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		c.Set(fiber.HeaderETag, etag)
+
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			c.Response().ResetBody()
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		return nil
+	}
+}
+
+// etagMatches checks an If-None-Match header, which may list several tags
+// (optionally weak) or be "*"
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// LastActiveUpdater records that a user was just seen
+type LastActiveUpdater interface {
+	UpdateLastActive(ctx context.Context, userID uuid.UUID) error
+}
+
+// LastActive refreshes last_active_at for users making authenticated requests.
+// It runs after the route so it sees the user ID set by AuthRequired, writes
+// at most once per interval per user, and never delays the response.
+func LastActive(updater LastActiveUpdater, interval time.Duration) fiber.Handler {
+	var (
+		mu         sync.Mutex
+		lastSeen   = make(map[uuid.UUID]time.Time)
+		lastPruned time.Time
+	)
+
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		userID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok || userID == uuid.Nil {
+			return err
+		}
+
+		now := time.Now()
+		mu.Lock()
+		due := now.Sub(lastSeen[userID]) >= interval
+		if due {
+			lastSeen[userID] = now
+		}
+		// Entries older than interval no longer throttle anything, so drop
+		// them once per interval to keep the map to recently active users
+		if now.Sub(lastPruned) >= interval {
+			for id, seen := range lastSeen {
+				if now.Sub(seen) >= interval {
+					delete(lastSeen, id)
+				}
+			}
+			lastPruned = now
+		}
+		mu.Unlock()
+
+		if due {
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				if err := updater.UpdateLastActive(ctx, userID); err != nil {
+					log.Printf("failed to update last active for user %s: %v", userID, err)
+				}
+			}()
+		}
+
+		return err
+	}
+}
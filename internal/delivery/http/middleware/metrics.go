@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"badbuddy/internal/infrastructure/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics records how long each request took, labelled with the matched route
+// pattern rather than the raw path so IDs don't explode the label set
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// The app's error handler writes the response after us
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		metrics.RequestDuration.
+			WithLabelValues(c.Method(), c.Route().Path, strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
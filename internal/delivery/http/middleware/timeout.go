@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrRequestTimeout = errors.New("request timed out")
+	ErrRequestAborted = errors.New("request was cancelled")
+)
+
+// Timeout attaches a deadline to the request's user context so repository calls
+// made with c.UserContext() are cancelled once it expires. A timed out request
+// is answered with 504 and a cancelled one with 503.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return c.Status(fiber.StatusGatewayTimeout).JSON(responses.FailMessage(ErrRequestTimeout.Error()))
+		case errors.Is(ctx.Err(), context.Canceled):
+			return c.Status(fiber.StatusServiceUnavailable).JSON(responses.FailMessage(ErrRequestAborted.Error()))
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/booking"
+	"badbuddy/internal/usecase/report"
+	"badbuddy/internal/usecase/user"
+	"badbuddy/internal/usecase/venue"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AdminHandler serves support staff endpoints that act on any user's data
+type AdminHandler struct {
+	bookingUseCase booking.UseCase
+	userUseCase    user.UseCase
+	reportUseCase  report.UseCase
+	venueUseCase   venue.UseCase
+	bookings       *BookingHandler
+	reports        *ReportHandler
+}
+
+func NewAdminHandler(bookingUseCase booking.UseCase, userUseCase user.UseCase, reportUseCase report.UseCase, venueUseCase venue.UseCase) *AdminHandler {
+	return &AdminHandler{
+		bookingUseCase: bookingUseCase,
+		userUseCase:    userUseCase,
+		reportUseCase:  reportUseCase,
+		venueUseCase:   venueUseCase,
+		bookings:       NewBookingHandler(bookingUseCase),
+		reports:        NewReportHandler(reportUseCase),
+	}
+}
+
+func (h *AdminHandler) SetupAdminRoutes(app *fiber.App) {
+	admin := app.Group("/api/admin", middleware.AuthRequired(), middleware.AdminRequired(h.userUseCase))
+
+	admin.Get("/bookings", h.ListBookings)
+	admin.Post("/bookings/:id/cancel", h.CancelBooking)
+	admin.Get("/reports", h.ListReports)
+	admin.Post("/reports/:id/resolve", h.ResolveReport)
+	admin.Get("/venue-claims", h.ListVenueClaims)
+	admin.Post("/venue-claims/:id/review", h.ReviewVenueClaim)
+}
+
+// ListBookings lists bookings of every user and venue
+func (h *AdminHandler) ListBookings(c *fiber.Ctx) error {
+	var req requests.AdminListBookingsRequest
+
+	req.CourtID = c.Query("court_id")
+	req.VenueID = c.Query("venue_id")
+	req.UserID = c.Query("user_id")
+	req.DateFrom = c.Query("date_from")
+	req.DateTo = c.Query("date_to")
+	req.Status = c.Query("status")
+	req.PaymentStatus = c.Query("payment_status")
+	req.Limit = c.QueryInt("limit", 10)
+	req.Offset = c.QueryInt("offset", 0)
+
+	bookings, err := h.bookingUseCase.AdminListBookings(c.UserContext(), req)
+	if err != nil {
+		return h.bookings.handleError(c, err)
+	}
+
+	return c.JSON(responses.Paginated(bookings.Bookings, bookings.Total, bookings.Limit, bookings.Offset))
+}
+
+// CancelBooking force-cancels a booking on behalf of its customer
+func (h *AdminHandler) CancelBooking(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid booking ID",
+			Code:        "INVALID_ID",
+			Description: "The provided booking ID is not in a valid format",
+		}))
+	}
+
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.bookingUseCase.AdminCancelBooking(c.UserContext(), id, adminID); err != nil {
+		return h.bookings.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Booking cancelled successfully", nil))
+}
+
+// ListReports lists reported content, open reports by default
+func (h *AdminHandler) ListReports(c *fiber.Ctx) error {
+	req := requests.ListReportsRequest{
+		Status:     c.Query("status"),
+		TargetType: c.Query("target_type"),
+		Limit:      c.QueryInt("limit", 20),
+		Offset:     c.QueryInt("offset", 0),
+	}
+
+	reports, err := h.reportUseCase.ListReports(c.UserContext(), req)
+	if err != nil {
+		return h.reports.handleError(c, err)
+	}
+
+	return c.JSON(responses.Paginated(reports.Reports, reports.Total, reports.Limit, reports.Offset))
+}
+
+// ResolveReport closes an open report as resolved or dismissed
+func (h *AdminHandler) ResolveReport(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid report ID",
+			Code:        "INVALID_ID",
+			Description: "The provided report ID is not in a valid format",
+		}))
+	}
+
+	var req requests.ResolveReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	resolved, err := h.reportUseCase.ResolveReport(c.UserContext(), id, adminID, req)
+	if err != nil {
+		return h.reports.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Report closed successfully", resolved))
+}
+
+// ListVenueClaims lists claims on unowned venues, pending ones by default
+func (h *AdminHandler) ListVenueClaims(c *fiber.Ctx) error {
+	claims, err := h.venueUseCase.ListClaims(c.UserContext(), c.Query("status"), c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return handleVenueClaimError(c, err)
+	}
+
+	return c.JSON(responses.Paginated(claims.Claims, claims.Total, claims.Limit, claims.Offset))
+}
+
+// ReviewVenueClaim approves or rejects a pending venue claim
+func (h *AdminHandler) ReviewVenueClaim(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid claim ID"))
+	}
+
+	var req requests.ReviewVenueClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
+	}
+
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	claim, err := h.venueUseCase.ReviewClaim(c.UserContext(), id, adminID, req)
+	if err != nil {
+		return handleVenueClaimError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Claim reviewed successfully", claim))
+}
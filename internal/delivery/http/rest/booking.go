@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
@@ -34,9 +36,11 @@ func (h *BookingHandler) SetupBookingRoutes(app *fiber.App) {
 	bookings.Get("/", h.ListBookings)
 	bookings.Get("/:id", h.GetBooking)
 	bookings.Put("/:id", h.UpdateBooking)
+	bookings.Patch("/:id", h.UpdateBooking)
 	bookings.Post("/:id/cancel", h.CancelBooking)
 	bookings.Get("/user/me", h.GetUserBookings)
 	bookings.Get("/:id/payment", h.GetPayment)
+	bookings.Get("/:id/receipt", h.GetReceipt)
 	bookings.Post("/:id/payment", h.CreatePayment)
 	bookings.Put("/:id/payment", h.UpdatePayment)
 
@@ -47,49 +51,43 @@ func (h *BookingHandler) SetupBookingRoutes(app *fiber.App) {
 func (h *BookingHandler) CreateBooking(c *fiber.Ctx) error {
 	var req requests.CreateBookingRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	booking, err := h.bookingUseCase.CreateBooking(c.Context(), userID, req)
+	created, err := h.bookingUseCase.CreateBooking(c.UserContext(), userID, req)
+	if errors.Is(err, booking.ErrValidation) {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Booking created successfully",
-		Data:    booking,
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Booking created successfully", created))
 }
 
 // GetBooking handles retrieving a single booking
 func (h *BookingHandler) GetBooking(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid booking ID",
 			Code:        "INVALID_ID",
 			Description: "The provided booking ID is not in a valid format",
-		})
+		}))
 	}
 
-	booking, err := h.bookingUseCase.GetBooking(c.Context(), id)
+	booking, err := h.bookingUseCase.GetBooking(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: booking,
-	})
+	return c.JSON(responses.OK(booking))
 }
 
 // ListBookings handles listing bookings with filters
@@ -102,93 +100,114 @@ func (h *BookingHandler) ListBookings(c *fiber.Ctx) error {
 	req.DateFrom = c.Query("date_from")
 	req.DateTo = c.Query("date_to")
 	req.Status = c.Query("status")
+	req.PaymentStatus = c.Query("payment_status")
 	req.Limit = c.QueryInt("limit", 10)
 	req.Offset = c.QueryInt("offset", 0)
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	bookings, err := h.bookingUseCase.ListBookings(c.Context(), userID, req)
+	bookings, err := h.bookingUseCase.ListBookings(c.UserContext(), userID, req)
+	if errors.Is(err, booking.ErrValidation) {
+		return h.handleError(c, booking.ErrValidation)
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(bookings)
+	return c.JSON(responses.Paginated(bookings.Bookings, bookings.Total, bookings.Limit, bookings.Offset))
 }
 
 // UpdateBooking handles updating a booking
 func (h *BookingHandler) UpdateBooking(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid booking ID",
 			Code:        "INVALID_ID",
 			Description: "The provided booking ID is not in a valid format",
-		})
+		}))
 	}
 
 	var req requests.UpdateBookingRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
-	booking, err := h.bookingUseCase.UpdateBooking(c.Context(), id, req)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	userID := c.Locals("userID").(uuid.UUID)
+
+	updated, err := h.bookingUseCase.UpdateBooking(c.UserContext(), id, userID, req)
+	switch {
+	case errors.Is(err, booking.ErrBookingNotFound):
+		return h.handleError(c, booking.ErrBookingNotFound)
+	case errors.Is(err, booking.ErrUnauthorized):
+		return c.Status(fiber.StatusForbidden).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Forbidden",
+			Code:        "FORBIDDEN",
+			Description: err.Error(),
+		}))
+	case errors.Is(err, booking.ErrValidation):
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+	case errors.Is(err, booking.ErrInvalidStatusTransition):
+		return c.Status(fiber.StatusConflict).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid status transition",
+			Code:        "INVALID_STATUS_TRANSITION",
+			Description: err.Error(),
+		}))
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Message: "Booking updated successfully",
-		Data:    booking,
-	})
+	return c.JSON(responses.OKMessage("Booking updated successfully", updated))
 }
 
 // CancelBooking handles cancelling a booking
 func (h *BookingHandler) CancelBooking(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid booking ID",
 			Code:        "INVALID_ID",
 			Description: "The provided booking ID is not in a valid format",
-		})
+		}))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.bookingUseCase.CancelBooking(c.Context(), id, userID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.bookingUseCase.CancelBooking(c.UserContext(), id, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Message: "Booking cancelled successfully",
-	})
+	return c.JSON(responses.OKMessage("Booking cancelled successfully", nil))
 }
 
 // GetUserBookings handles retrieving user's bookings
 func (h *BookingHandler) GetUserBookings(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
-	includeHistory := c.QueryBool("include_history", false)
 
-	bookings, err := h.bookingUseCase.GetUserBookings(c.Context(), userID, includeHistory)
+	req := requests.UserBookingsRequest{
+		When:    c.Query("when"),
+		Sort:    c.Query("sort"),
+		Status:  c.Query("status"),
+		VenueID: c.Query("venue_id"),
+	}
+	// include_history=true predates ?when= and means every booking
+	if req.When == "" && c.QueryBool("include_history", false) {
+		req.When = "all"
+	}
+
+	bookings, err := h.bookingUseCase.ListUserBookings(c.UserContext(), userID, req)
+	if errors.Is(err, booking.ErrValidation) {
+		return h.handleError(c, booking.ErrValidation)
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: bookings,
-	})
+	return c.JSON(responses.OK(bookings))
 }
 
 // CheckAvailability handles checking court availability
@@ -200,118 +219,122 @@ func (h *BookingHandler) CheckAvailability(c *fiber.Ctx) error {
 	req.StartTime = c.Query("start_time")
 	req.EndTime = c.Query("end_time")
 
-	availability, err := h.bookingUseCase.CheckAvailability(c.Context(), req)
+	availability, err := h.bookingUseCase.CheckAvailability(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: availability,
-	})
+	return c.JSON(responses.OK(availability))
 }
 
 // get payment for booking
 func (h *BookingHandler) GetPayment(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid Court Booking ID",
 			Code:        "INVALID_ID",
 			Description: "The provided court booking ID is not in a valid format",
-		})
+		}))
 	}
 
-	booking, err := h.bookingUseCase.GetPayment(c.Context(), id)
+	booking, err := h.bookingUseCase.GetPayment(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: booking,
-	})
+	return c.JSON(responses.OK(booking))
+}
+
+// GetReceipt returns the booking's payment receipt as a PDF
+func (h *BookingHandler) GetReceipt(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid booking ID",
+			Code:        "INVALID_ID",
+			Description: "The provided booking ID is not in a valid format",
+		}))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	receipt, err := h.bookingUseCase.GetReceipt(c.UserContext(), id, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="receipt-%s.pdf"`, id))
+	return c.Send(receipt)
 }
 
 // CreatePayment handles creating a payment for a booking
 func (h *BookingHandler) CreatePayment(c *fiber.Ctx) error {
 	bookingID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid booking ID",
 			Code:        "INVALID_ID",
 			Description: "The provided booking ID is not in a valid format",
-		})
+		}))
 	}
 
 	var req requests.CreatePaymentRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 	userID := c.Locals("userID").(uuid.UUID)
-	payment, err := h.bookingUseCase.CreatePayment(c.Context(), bookingID, userID, req)
+	payment, err := h.bookingUseCase.CreatePayment(c.UserContext(), bookingID, userID, req)
+	if err == booking.ErrBookingExpired {
+		return h.handleError(c, err)
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Payment created successfully",
-		Data:    payment,
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Payment created successfully", payment))
 }
 
 func (h *BookingHandler) UpdatePayment(c *fiber.Ctx) error {
 	bookingID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid booking ID",
 			Code:        "INVALID_ID",
 			Description: "The provided booking ID is not in a valid format",
-		})
+		}))
 	}
 
 	var req requests.UpdatePaymentRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 	userID := c.Locals("userID").(uuid.UUID)
-	payment, err := h.bookingUseCase.UpdatePayment(c.Context(), bookingID, userID, req)
+	payment, err := h.bookingUseCase.UpdatePayment(c.UserContext(), bookingID, userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Payment created successfully",
-		Data:    payment,
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Payment created successfully", payment))
 }
 
 func (h *BookingHandler) ChangeCourtStatus(c *fiber.Ctx) error {
 
-	err := h.bookingUseCase.ChangeCourtStatus(c.Context())
+	err := h.bookingUseCase.ChangeCourtStatus(c.UserContext())
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Court status changed successfully",
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Court status changed successfully", nil))
 }
 
 // handleError centralizes error handling
@@ -319,36 +342,42 @@ func (h *BookingHandler) handleError(c *fiber.Ctx, err error) error {
 	// Add specific error types
 	switch {
 	case err == booking.ErrBookingNotFound:
-		return c.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusNotFound).JSON(responses.Fail(responses.ErrorResponse{
 			Error: "Booking not found",
 			Code:  "BOOKING_NOT_FOUND",
-		})
+		}))
 	case err == booking.ErrUnauthorized:
-		return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.Fail(responses.ErrorResponse{
 			Error: "Unauthorized",
 			Code:  "UNAUTHORIZED",
-		})
+		}))
 	case err == booking.ErrValidation:
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error: "Validation error",
 			Code:  "VALIDATION_ERROR",
-		})
+		}))
 	case err == booking.ErrBookingConflict:
-		return c.Status(fiber.StatusConflict).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusConflict).JSON(responses.Fail(responses.ErrorResponse{
 			Error: "Booking conflict",
 			Code:  "BOOKING_CONFLICT",
-		})
+		}))
 	case err == booking.ErrPaymentRequired:
-		return c.Status(fiber.StatusPaymentRequired).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusPaymentRequired).JSON(responses.Fail(responses.ErrorResponse{
 			Error: "Payment required",
 			Code:  "PAYMENT_REQUIRED",
-		})
+		}))
+	case err == booking.ErrBookingExpired:
+		return c.Status(fiber.StatusGone).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Booking expired",
+			Code:        "BOOKING_EXPIRED",
+			Description: "The hold on this booking ran out before payment, please book again",
+		}))
 	default:
 		// Log the error here
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.Fail(responses.ErrorResponse{
 			Error: "Internal server error",
 			Code:  "INTERNAL_ERROR",
-		})
+		}))
 	}
 }
 
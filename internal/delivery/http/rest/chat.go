@@ -39,6 +39,12 @@ func (h *ChatHandler) SetupChatRoutes(app *fiber.App) {
 	chat.Post("/:chatID/messages", h.SendMessage)
 	chat.Delete("/:chatID/messages/:messageID", h.DeleteMessage)
 	chat.Put("/:chatID/messages/:messageID", h.UpdateMessage)
+	chat.Post("/:chatID/messages/:messageID/pin", h.PinMessage)
+	chat.Delete("/:chatID/messages/:messageID/pin", h.UnpinMessage)
+	chat.Get("/:chatID/pinned", h.GetPinnedMessages)
+	chat.Delete("/:chatID", h.DeleteChat)
+	chat.Post("/:chatID/mute", h.MuteChat)
+	chat.Delete("/:chatID/mute", h.UnmuteChat)
 
 	chat.Get("/:chatID/users", h.GetUsersInChat)
 
@@ -68,7 +74,7 @@ func (h *ChatHandler) GetChatMessage(c *fiber.Ctx) error {
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	chat, err := h.chatUseCase.GetChatMessageByID(c.Context(), chatUUID, limit, offset, userID)
+	chat, err := h.chatUseCase.GetChatMessageByID(c.UserContext(), chatUUID, limit, offset, userID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -79,10 +85,7 @@ func (h *ChatHandler) GetChatMessage(c *fiber.Ctx) error {
 	})
 	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- message_bytes
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Chat messages retrieved successfully",
-		Data:    chat,
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Chat messages retrieved successfully", chat))
 }
 
 func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
@@ -91,10 +94,6 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid request body"))
 	}
 
-	if req.Message == "" {
-		return h.handleError(c, errors.New("message cannot be empty"))
-	}
-
 	userID := c.Locals("userID").(uuid.UUID)
 
 	chatID := c.Params("chatID")
@@ -103,7 +102,7 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid chat ID format"))
 	}
 
-	chatMessage, err := h.chatUseCase.SendMessage(c.Context(), userID, chatUUID, req)
+	chatMessage, err := h.chatUseCase.SendMessage(c.UserContext(), userID, chatUUID, req)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -114,10 +113,7 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 	})
 	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Message sent successfully",
-		Data:    chatMessage,
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Message sent successfully", chatMessage))
 }
 
 func (h *ChatHandler) handleError(c *fiber.Ctx, err error) error {
@@ -132,6 +128,12 @@ func (h *ChatHandler) handleError(c *fiber.Ctx, err error) error {
 			Error: "Chat not found",
 			Code:  "CHAT_NOT_FOUND",
 		}
+	case errors.Is(err, chat.ErrMessageNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Message not found",
+			Code:  "MESSAGE_NOT_FOUND",
+		}
 	case errors.Is(err, chat.ErrUnauthorized):
 		status = fiber.StatusUnauthorized
 		errorResponse = responses.ErrorResponse{
@@ -153,7 +155,7 @@ func (h *ChatHandler) handleError(c *fiber.Ctx, err error) error {
 	}
 
 	errorResponse.Description = err.Error()
-	return c.Status(status).JSON(errorResponse)
+	return c.Status(status).JSON(responses.Fail(errorResponse))
 }
 
 func (h *ChatHandler) DeleteMessage(c *fiber.Ctx) error {
@@ -172,7 +174,7 @@ func (h *ChatHandler) DeleteMessage(c *fiber.Ctx) error {
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	err = h.chatUseCase.DeleteMessage(c.Context(), chatUUID, messageUUID, userID)
+	err = h.chatUseCase.DeleteMessage(c.UserContext(), chatUUID, messageUUID, userID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -183,9 +185,7 @@ func (h *ChatHandler) DeleteMessage(c *fiber.Ctx) error {
 	})
 	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Message deleted successfully",
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Message deleted successfully", nil))
 }
 
 func (h *ChatHandler) UpdateMessage(c *fiber.Ctx) error {
@@ -194,10 +194,6 @@ func (h *ChatHandler) UpdateMessage(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid request body"))
 	}
 
-	if req.Message == "" {
-		return h.handleError(c, errors.New("message cannot be empty"))
-	}
-
 	chatID := c.Params("chatID")
 	messageID := c.Params("messageID")
 
@@ -213,7 +209,7 @@ func (h *ChatHandler) UpdateMessage(c *fiber.Ctx) error {
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	err = h.chatUseCase.UpdateMessage(c.Context(), chatUUID, messageUUID, userID, req)
+	content, err := h.chatUseCase.UpdateMessage(c.UserContext(), chatUUID, messageUUID, userID, req)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -222,28 +218,35 @@ func (h *ChatHandler) UpdateMessage(c *fiber.Ctx) error {
 		MessageaType: "update_message",
 		Data: map[string]interface{}{
 			"message_id": messageID,
-			"message":    req.Message,
+			"message":    content,
 		},
 	})
 	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Message updated successfully",
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Message updated successfully", nil))
 }
 
 func (h *ChatHandler) GetChats(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
+	limitStr := c.Query("limit", "20")
+	offsetStr := c.Query("offset", "0")
 
-	chats, err := h.chatUseCase.GetChats(c.Context(), userID)
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid limit format"))
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid offset format"))
+	}
+
+	chats, err := h.chatUseCase.GetChats(c.UserContext(), userID, limit, offset)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Chats retrieved successfully",
-		Data:    chats,
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Chats retrieved successfully", chats))
 }
 
 func (h *ChatHandler) GetUsersInChat(c *fiber.Ctx) error {
@@ -255,15 +258,12 @@ func (h *ChatHandler) GetUsersInChat(c *fiber.Ctx) error {
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	users, err := h.chatUseCase.GetUsersInChat(c.Context(), chatUUID, userID)
+	users, err := h.chatUseCase.GetUsersInChat(c.UserContext(), chatUUID, userID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Chat users retrieved successfully",
-		Data:    users,
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Chat users retrieved successfully", users))
 }
 
 func (h *ChatHandler) GetDirectChat(c *fiber.Ctx) error {
@@ -287,15 +287,12 @@ func (h *ChatHandler) GetDirectChat(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid user ID format"))
 	}
 
-	chat, err := h.chatUseCase.GetDirectChat(c.Context(), userID, otherUserUUID, limit, offset)
+	chat, err := h.chatUseCase.GetDirectChat(c.UserContext(), userID, otherUserUUID, limit, offset)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Direct chat retrieved successfully",
-		Data:    chat,
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Direct chat retrieved successfully", chat))
 }
 
 func (h *ChatHandler) GetChatMessageOfSession(c *fiber.Ctx) error {
@@ -320,13 +317,121 @@ func (h *ChatHandler) GetChatMessageOfSession(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid session ID format"))
 	}
 
-	chat, err := h.chatUseCase.GetChatMessageOfSession(c.Context(), sessionUUID, limit, offset, userID)
+	chat, err := h.chatUseCase.GetChatMessageOfSession(c.UserContext(), sessionUUID, limit, offset, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Chat messages retrieved successfully", chat))
+}
+
+func (h *ChatHandler) PinMessage(c *fiber.Ctx) error {
+	return h.setPinned(c, true)
+}
+
+func (h *ChatHandler) UnpinMessage(c *fiber.Ctx) error {
+	return h.setPinned(c, false)
+}
+
+func (h *ChatHandler) setPinned(c *fiber.Ctx, pinned bool) error {
+	chatID := c.Params("chatID")
+	messageID := c.Params("messageID")
+
+	chatUUID, err := uuid.Parse(chatID)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid chat ID format"))
+	}
+
+	messageUUID, err := uuid.Parse(messageID)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid message ID format"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	err = h.chatUseCase.PinMessage(c.UserContext(), chatUUID, messageUUID, userID, pinned)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Chat messages retrieved successfully",
-		Data:    chat,
+	messageType, message := "pin_message", "Message pinned successfully"
+	if !pinned {
+		messageType, message = "unpin_message", "Message unpinned successfully"
+	}
+
+	messageBytes, _ := json.Marshal(responses.BoardCastMessageResponse{
+		MessageaType: messageType,
+		Data:         map[string]interface{}{"message_id": messageID},
 	})
-}
\ No newline at end of file
+	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
+
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage(message, nil))
+}
+
+func (h *ChatHandler) GetPinnedMessages(c *fiber.Ctx) error {
+	chatID := c.Params("chatID")
+	chatUUID, err := uuid.Parse(chatID)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid chat ID format"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	pinned, err := h.chatUseCase.GetPinnedMessages(c.UserContext(), chatUUID, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Pinned messages retrieved successfully", pinned))
+}
+
+func (h *ChatHandler) DeleteChat(c *fiber.Ctx) error {
+	chatID := c.Params("chatID")
+	chatUUID, err := uuid.Parse(chatID)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid chat ID format"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.chatUseCase.DeleteChat(c.UserContext(), chatUUID, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	messageBytes, _ := json.Marshal(responses.BoardCastMessageResponse{
+		MessageaType: "delete_chat",
+		Data:         map[string]interface{}{"chat_id": chatID},
+	})
+	h.chatHub.GetRoom(chatUUID.String()).Broadcast <- messageBytes
+
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Chat deleted successfully", nil))
+}
+
+func (h *ChatHandler) MuteChat(c *fiber.Ctx) error {
+	return h.setMuted(c, true)
+}
+
+func (h *ChatHandler) UnmuteChat(c *fiber.Ctx) error {
+	return h.setMuted(c, false)
+}
+
+func (h *ChatHandler) setMuted(c *fiber.Ctx, muted bool) error {
+	chatID := c.Params("chatID")
+	chatUUID, err := uuid.Parse(chatID)
+	if err != nil {
+		return h.handleError(c, errors.New("invalid chat ID format"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.chatUseCase.MuteChat(c.UserContext(), chatUUID, userID, muted); err != nil {
+		return h.handleError(c, err)
+	}
+
+	message := "Chat muted successfully"
+	if !muted {
+		message = "Chat unmuted successfully"
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage(message, nil))
+}
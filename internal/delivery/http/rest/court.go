@@ -0,0 +1,198 @@
+package rest
+
+import (
+	"errors"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/court"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CourtHandler struct {
+	courtUseCase court.UseCase
+}
+
+func NewCourtHandler(courtUseCase court.UseCase) *CourtHandler {
+	return &CourtHandler{
+		courtUseCase: courtUseCase,
+	}
+}
+
+func (h *CourtHandler) SetupCourtRoutes(app *fiber.App) {
+	courts := app.Group("/api/courts")
+
+	// Public routes
+	courts.Get("/", h.ListCourts)
+	courts.Get("/:id/detail", h.GetCourtDetail)
+	courts.Post("/availability/batch", h.CheckAvailabilityBatch)
+
+	// Protected routes
+	courts.Use(middleware.AuthRequired())
+	courts.Get("/:id/bookings", h.GetCourtBookings)
+	courts.Post("/:id/restore", h.RestoreCourt)
+}
+
+// ListCourts lists courts, optionally only those free on ?date= between ?start_time= and ?end_time=
+func (h *CourtHandler) ListCourts(c *fiber.Ctx) error {
+	req := requests.ListCourtsRequest{
+		VenueID:   c.Query("venue_id"),
+		Status:    c.Query("status"),
+		Location:  c.Query("location"),
+		PriceMin:  c.QueryFloat("price_min", 0),
+		PriceMax:  c.QueryFloat("price_max", 0),
+		Date:      c.Query("date"),
+		StartTime: c.Query("start_time"),
+		EndTime:   c.Query("end_time"),
+		Limit:     c.QueryInt("limit", 10),
+		Offset:    c.QueryInt("offset", 0),
+	}
+
+	if req.VenueID != "" {
+		if _, err := uuid.Parse(req.VenueID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+				Error:       "Invalid venue ID",
+				Code:        "INVALID_ID",
+				Description: err.Error(),
+			}))
+		}
+	}
+
+	result, err := h.courtUseCase.ListCourts(c.UserContext(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.Paginated(result.Courts, result.Total, result.Limit, result.Offset))
+}
+
+// GetCourtDetail returns a court with its venue's name, location and today's hours
+func (h *CourtHandler) GetCourtDetail(c *fiber.Ctx) error {
+	courtID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid court ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	detail, err := h.courtUseCase.GetCourtDetail(c.UserContext(), courtID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(detail))
+}
+
+// CheckAvailabilityBatch checks many courts for the same date and time window in one call
+func (h *CourtHandler) CheckAvailabilityBatch(c *fiber.Ctx) error {
+	var req requests.BatchCourtAvailabilityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	availability, err := h.courtUseCase.CheckAvailabilityBatch(c.UserContext(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(availability))
+}
+
+// GetCourtBookings lists a court's bookings for ?date=YYYY-MM-DD (today by default)
+func (h *CourtHandler) GetCourtBookings(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	courtID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid court ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	date := time.Now().Truncate(24 * time.Hour)
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+				Error:       "Invalid date",
+				Code:        "INVALID_DATE",
+				Description: "date must be in YYYY-MM-DD format",
+			}))
+		}
+	}
+
+	bookings, err := h.courtUseCase.GetCourtBookings(c.UserContext(), courtID, userID, date)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(bookings))
+}
+
+// RestoreCourt undoes the soft delete of a court
+func (h *CourtHandler) RestoreCourt(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	courtID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid court ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	restored, err := h.courtUseCase.RestoreCourt(c.UserContext(), courtID, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Court restored successfully", restored))
+}
+
+func (h *CourtHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, court.ErrCourtNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Court not found",
+			Code:  "COURT_NOT_FOUND",
+		}
+	case errors.Is(err, court.ErrForbidden):
+		status = fiber.StatusForbidden
+		errorResponse = responses.ErrorResponse{
+			Error: "Forbidden",
+			Code:  "FORBIDDEN",
+		}
+	case errors.Is(err, court.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(responses.Fail(errorResponse))
+}
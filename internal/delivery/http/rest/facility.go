@@ -39,17 +39,17 @@ func (h *FacilityHandler) SetupFacilityRoutes(app *fiber.App) {
 }
 
 func (h *FacilityHandler) ListFacilities(c *fiber.Ctx) error {
-	facilities, err := h.facilityUseCase.ListFacilities(c.Context())
+	facilities, err := h.facilityUseCase.ListFacilities(c.UserContext())
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(facilities)
+	return c.Status(fiber.StatusOK).JSON(responses.OK(facilities))
 }
 
 func (h *FacilityHandler) GetFacility(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
-	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	isAdmin, err := h.userUseCase.IsAdmin(c.UserContext(), userID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -63,35 +63,35 @@ func (h *FacilityHandler) GetFacility(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid facility ID format"))
 	}
 
-	facility, err := h.facilityUseCase.GetFacilityByID(c.Context(), id)
+	facility, err := h.facilityUseCase.GetFacilityByID(c.UserContext(), id)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(facility)
+	return c.Status(fiber.StatusOK).JSON(responses.OK(facility))
 }
 
 func (h *FacilityHandler) CreateFacility(c *fiber.Ctx) error {
 	var req requests.CreateAndUpdateFacilityRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	if req.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Facility name cannot be empty",
 			Code:        "INVALID_REQUEST",
 			Description: "Facility name cannot be empty",
-		})
+		}))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	isAdmin, err := h.userUseCase.IsAdmin(c.UserContext(), userID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -100,35 +100,35 @@ func (h *FacilityHandler) CreateFacility(c *fiber.Ctx) error {
 		return h.handleError(c, facility.ErrUnauthorized)
 	}
 
-	facility, err := h.facilityUseCase.CreateFacility(c.Context(), req)
+	facility, err := h.facilityUseCase.CreateFacility(c.UserContext(), req)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(facility)
+	return c.Status(fiber.StatusCreated).JSON(responses.OK(facility))
 }
 
 func (h *FacilityHandler) UpdateFacility(c *fiber.Ctx) error {
 	var req requests.CreateAndUpdateFacilityRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	if req.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Facility name cannot be empty",
 			Code:        "INVALID_REQUEST",
 			Description: "Facility name cannot be empty",
-		})
+		}))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	isAdmin, err := h.userUseCase.IsAdmin(c.UserContext(), userID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
@@ -143,19 +143,19 @@ func (h *FacilityHandler) UpdateFacility(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid facility ID format"))
 	}
 
-	facility, err := h.facilityUseCase.UpdateFacility(c.Context(), id, req)
+	facility, err := h.facilityUseCase.UpdateFacility(c.UserContext(), id, req)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(facility)
+	return c.Status(fiber.StatusOK).JSON(responses.OK(facility))
 
 }
 
 func (h *FacilityHandler) DeleteFacility(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 
-	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), userID)
+	isAdmin, err := h.userUseCase.IsAdmin(c.UserContext(), userID)
 
 	if err != nil {
 		return h.handleError(c, err)
@@ -170,15 +170,13 @@ func (h *FacilityHandler) DeleteFacility(c *fiber.Ctx) error {
 		return h.handleError(c, errors.New("invalid facility ID format"))
 	}
 
-	err = h.facilityUseCase.DeleteFacility(c.Context(), id)
+	err = h.facilityUseCase.DeleteFacility(c.UserContext(), id)
 
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.SuccessResponse{
-		Message: "Facility deleted successfully",
-	})
+	return c.Status(fiber.StatusOK).JSON(responses.OKMessage("Facility deleted successfully", nil))
 
 }
 
@@ -215,5 +213,5 @@ func (h *FacilityHandler) handleError(c *fiber.Ctx, err error) error {
 	}
 
 	errorResponse.Description = err.Error()
-	return c.Status(status).JSON(errorResponse)
+	return c.Status(status).JSON(responses.Fail(errorResponse))
 }
@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/notification"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// streamHeartbeatInterval keeps idle SSE connections alive through proxies and
+// lets us notice disconnected clients
+const streamHeartbeatInterval = 25 * time.Second
+
+type NotificationHandler struct {
+	notificationUseCase notification.UseCase
+}
+
+func NewNotificationHandler(notificationUseCase notification.UseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+func (h *NotificationHandler) SetupNotificationRoutes(app *fiber.App) {
+	notifications := app.Group("/api/notifications")
+
+	notifications.Use(middleware.AuthRequired())
+	notifications.Get("/", h.ListNotifications)
+	notifications.Get("/unread-count", h.GetUnreadCount)
+	notifications.Get("/stream", h.StreamNotifications)
+	notifications.Post("/read-all", h.MarkAllAsRead)
+	notifications.Post("/:id/read", h.MarkAsRead)
+}
+
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+	unreadOnly := c.QueryBool("unread_only", false)
+
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, err := h.notificationUseCase.ListNotifications(c.UserContext(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(notifications))
+}
+
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	count, err := h.notificationUseCase.GetUnreadCount(c.UserContext(), userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(count))
+}
+
+// StreamNotifications pushes new notifications to the client as Server-Sent Events.
+// Clients that can't keep a stream open should fall back to polling the list endpoint.
+func (h *NotificationHandler) StreamNotifications(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	events, unsubscribe := h.notificationUseCase.Subscribe(userID)
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		ticker := time.NewTicker(streamHeartbeatInterval)
+		defer ticker.Stop()
+
+		// Tell the client how long to wait before reconnecting
+		fmt.Fprintf(w, "retry: %d\n\n", (5 * time.Second).Milliseconds())
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				payload, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "id: %s\nevent: notification\ndata: %s\n\n", event.ID, payload)
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// A failed flush means the client has gone away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+
+	return nil
+}
+
+func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid notification ID",
+			Code:        "INVALID_ID",
+			Description: "The provided notification ID is not in a valid format",
+		}))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.notificationUseCase.MarkAsRead(c.UserContext(), id, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Notification marked as read", nil))
+}
+
+func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.notificationUseCase.MarkAllAsRead(c.UserContext(), userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("All notifications marked as read", result))
+}
+
+func (h *NotificationHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, notification.ErrNotificationNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Notification not found",
+			Code:  "NOTIFICATION_NOT_FOUND",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(responses.Fail(errorResponse))
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/report"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReportHandler struct {
+	reportUseCase report.UseCase
+}
+
+func NewReportHandler(reportUseCase report.UseCase) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase: reportUseCase,
+	}
+}
+
+func (h *ReportHandler) SetupReportRoutes(app *fiber.App) {
+	reports := app.Group("/api/reports", middleware.AuthRequired())
+
+	reports.Post("/", h.CreateReport)
+}
+
+// CreateReport flags a session, venue, user or chat message for the admins
+func (h *ReportHandler) CreateReport(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req requests.CreateReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	created, err := h.reportUseCase.CreateReport(c.UserContext(), userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Report submitted successfully", created))
+}
+
+func (h *ReportHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, report.ErrReportNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Report not found",
+			Code:  "REPORT_NOT_FOUND",
+		}
+	case errors.Is(err, report.ErrTargetNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Reported content not found",
+			Code:  "TARGET_NOT_FOUND",
+		}
+	case errors.Is(err, report.ErrAlreadyReported):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Already reported",
+			Code:  "ALREADY_REPORTED",
+		}
+	case errors.Is(err, report.ErrTooManyReports):
+		status = fiber.StatusTooManyRequests
+		errorResponse = responses.ErrorResponse{
+			Error: "Too many reports",
+			Code:  "TOO_MANY_REPORTS",
+		}
+	case errors.Is(err, report.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(responses.Fail(errorResponse))
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/search"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchHandler struct {
+	searchUseCase search.UseCase
+}
+
+func NewSearchHandler(searchUseCase search.UseCase) *SearchHandler {
+	return &SearchHandler{
+		searchUseCase: searchUseCase,
+	}
+}
+
+func (h *SearchHandler) SetupSearchRoutes(app *fiber.App) {
+	// User search is only available to signed in users, so the whole endpoint is protected
+	app.Get("/api/search", middleware.AuthRequired(), h.Search)
+}
+
+// Search looks up ?q= across venues, sessions and users. ?type= narrows it to one
+// group and ?limit= sets the size of each group.
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	result, err := h.searchUseCase.Search(
+		c.UserContext(),
+		c.Query("q"),
+		search.Type(c.Query("type", string(search.TypeAll))),
+		c.QueryInt("limit", search.MaxGroupSize),
+	)
+	if err != nil {
+		if errors.Is(err, search.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(result))
+}
@@ -2,10 +2,12 @@ package rest
 
 import (
 	"errors"
+	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/chat"
 	"badbuddy/internal/usecase/session"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,11 +16,16 @@ import (
 
 type SessionHandler struct {
 	sessionUseCase session.UseCase
+	chatUseCase    chat.UseCase
+
+	cacheMaxAge time.Duration // How long anonymous listings may be cached
 }
 
-func NewSessionHandler(sessionUseCase session.UseCase) *SessionHandler {
+func NewSessionHandler(sessionUseCase session.UseCase, chatUseCase chat.UseCase, cacheMaxAge time.Duration) *SessionHandler {
 	return &SessionHandler{
 		sessionUseCase: sessionUseCase,
+		chatUseCase:    chatUseCase,
+		cacheMaxAge:    cacheMaxAge,
 	}
 }
 
@@ -26,65 +33,68 @@ func (h *SessionHandler) SetupSessionRoutes(app *fiber.App) {
 	sessions := app.Group("/api/sessions")
 
 	// Public routes
-	sessions.Get("/", h.ListSessions)
+	sessions.Get("/", middleware.PublicCache(h.cacheMaxAge), h.ListSessions)
 	sessions.Get("/search", h.SearchSessions)
-	sessions.Get("/:id", h.GetSession)
+	sessions.Get("/recommended", middleware.AuthRequired(), h.GetRecommendedSessions)
+	sessions.Post("/batch", h.GetSessionsBatch)
+	sessions.Get("/:id", middleware.ETag(), h.GetSession)
 
 	// Protected routes
 	sessions.Use(middleware.AuthRequired())
 	sessions.Get("/join/me", h.GetMyJoinedSessions)
 	sessions.Get("/host/me", h.GetMyHostedSessions)
 	sessions.Post("/", h.CreateSession)
+	sessions.Post("/bulk-cancel", h.BulkCancelSessions)
 	sessions.Put("/:id", h.UpdateSession)
 	sessions.Post("/:id/join", h.JoinSession)
+	sessions.Post("/:id/participants/bulk", h.BulkAddParticipants)
 	sessions.Post("/:id/leave", h.LeaveSession)
 	sessions.Post("/:id/cancel", h.CancelSession)
+	sessions.Post("/:id/complete", h.CompleteSession)
+	sessions.Post("/:id/checkin", h.CheckIn)
+	sessions.Post("/:id/announce", h.Announce)
 	sessions.Get("/user/me", h.GetUserSessions)
 	sessions.Put("/:id/status", h.ChangeParticipantStatus)
 	sessions.Get("/:id/participants", h.GetSessionParticipants)
+	sessions.Get("/:id/messages", h.GetSessionMessages)
 }
 
 func (h *SessionHandler) CreateSession(c *fiber.Ctx) error {
 	var req requests.CreateSessionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	hostID := c.Locals("userID").(uuid.UUID)
 
-	session, err := h.sessionUseCase.CreateSession(c.Context(), hostID, req)
+	session, err := h.sessionUseCase.CreateSession(c.UserContext(), hostID, req)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Session created successfully",
-		Data:    session,
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Session created successfully", session))
 }
 
 func (h *SessionHandler) GetSession(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
-	session, err := h.sessionUseCase.GetSession(c.Context(), id)
+	session, err := h.sessionUseCase.GetSession(c.UserContext(), id)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: session,
-	})
+	return c.JSON(responses.OK(session))
 }
 
 func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
@@ -103,6 +113,28 @@ func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
 	if status := c.Query("status"); status != "" {
 		filters["status"] = status
 	}
+	if hostID := c.Query("host_id"); hostID != "" {
+		id, err := uuid.Parse(hostID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+				Error:       "Invalid host ID",
+				Code:        "INVALID_ID",
+				Description: err.Error(),
+			}))
+		}
+		filters["host_id"] = id
+	}
+	if participantID := c.Query("participant_id"); participantID != "" {
+		id, err := uuid.Parse(participantID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+				Error:       "Invalid participant ID",
+				Code:        "INVALID_ID",
+				Description: err.Error(),
+			}))
+		}
+		filters["participant_id"] = id
+	}
 
 	// Parse pagination params with defaults
 	limit := c.QueryInt("limit", 10)
@@ -115,14 +147,33 @@ func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
 		offset = 0
 	}
 
-	sessions, err := h.sessionUseCase.ListSessions(c.Context(), filters, limit, offset)
+	sessions, err := h.sessionUseCase.ListSessions(c.UserContext(), filters, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.Paginated(sessions.Sessions, sessions.Total, limit, offset))
+}
+
+func (h *SessionHandler) GetRecommendedSessions(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	sessions, err := h.sessionUseCase.GetRecommendedSessions(c.UserContext(), userID, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return h.handleError(c, err)
 	}
 
-	return c.JSON(sessions)
+	return c.JSON(responses.Paginated(sessions.Sessions, sessions.Total, limit, offset))
 }
 
 func (h *SessionHandler) SearchSessions(c *fiber.Ctx) error {
@@ -151,206 +202,376 @@ func (h *SessionHandler) SearchSessions(c *fiber.Ctx) error {
 		offset = 0
 	}
 
-	sessions, err := h.sessionUseCase.SearchSessions(c.Context(), query, filters, limit, offset)
+	sessions, err := h.sessionUseCase.SearchSessions(c.UserContext(), query, filters, limit, offset)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(sessions)
+	return c.JSON(responses.Paginated(sessions.Sessions, sessions.Total, limit, offset))
 }
 
 func (h *SessionHandler) UpdateSession(c *fiber.Ctx) error {
 	sessionID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
 	var req requests.UpdateSessionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	hostID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.UpdateSession(c.Context(), sessionID, hostID, req); err != nil {
+	if err := h.sessionUseCase.UpdateSession(c.UserContext(), sessionID, hostID, req); err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Message: "Session updated successfully",
-	})
+	return c.JSON(responses.OKMessage("Session updated successfully", nil))
 }
 
 func (h *SessionHandler) JoinSession(c *fiber.Ctx) error {
 	sessionID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
 	var req requests.JoinSessionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.JoinSession(c.Context(), sessionID, userID, req); err != nil {
+	result, err := h.sessionUseCase.JoinSession(c.UserContext(), sessionID, userID, req)
+	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Message: "Successfully joined session",
-	})
+	return c.JSON(responses.OKMessage("Successfully joined session", result))
 }
 
 func (h *SessionHandler) LeaveSession(c *fiber.Ctx) error {
 	sessionID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.LeaveSession(c.Context(), sessionID, userID); err != nil {
+	if err := h.sessionUseCase.LeaveSession(c.UserContext(), sessionID, userID); err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Message: "Successfully left session",
-	})
+	return c.JSON(responses.OKMessage("Successfully left session", nil))
 }
 
 func (h *SessionHandler) CancelSession(c *fiber.Ctx) error {
 	sessionID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
 	hostID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.CancelSession(c.Context(), sessionID, hostID); err != nil {
+	if err := h.sessionUseCase.CancelSession(c.UserContext(), sessionID, hostID); err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Message: "Session cancelled successfully",
-	})
+	return c.JSON(responses.OKMessage("Session cancelled successfully", nil))
+}
+
+// Announce sends the host's announcement to the session chat and its confirmed participants
+func (h *SessionHandler) Announce(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid session ID",
+			Code:        "INVALID_ID",
+			Description: "The provided session ID is not in a valid format",
+		}))
+	}
+
+	var req requests.AnnounceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	hostID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.sessionUseCase.Announce(c.UserContext(), sessionID, hostID, req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Announcement sent successfully", nil))
+}
+
+// GetSessionsBatch returns several sessions by ID in one request
+func (h *SessionHandler) GetSessionsBatch(c *fiber.Ctx) error {
+	var req requests.BatchGetSessionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	sessions, err := h.sessionUseCase.GetSessionsBatch(c.UserContext(), req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(sessions))
+}
+
+// BulkAddParticipants adds a list of players to the host's session as confirmed
+func (h *SessionHandler) BulkAddParticipants(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid session ID",
+			Code:        "INVALID_ID",
+			Description: "The provided session ID is not in a valid format",
+		}))
+	}
+
+	var req requests.BulkAddParticipantsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	hostID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.sessionUseCase.BulkAddParticipants(c.UserContext(), sessionID, hostID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Participants added successfully", result))
+}
+
+func (h *SessionHandler) BulkCancelSessions(c *fiber.Ctx) error {
+	var req requests.BulkCancelSessionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	hostID := c.Locals("userID").(uuid.UUID)
+
+	result, err := h.sessionUseCase.BulkCancelSessions(c.UserContext(), hostID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Sessions cancelled successfully", result))
+}
+
+func (h *SessionHandler) CheckIn(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid session ID",
+			Code:        "INVALID_ID",
+			Description: "The provided session ID is not in a valid format",
+		}))
+	}
+
+	var req requests.CheckInRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	callerID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.sessionUseCase.CheckIn(c.UserContext(), sessionID, callerID, req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Checked in successfully", nil))
+}
+
+func (h *SessionHandler) CompleteSession(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid session ID",
+			Code:        "INVALID_ID",
+			Description: "The provided session ID is not in a valid format",
+		}))
+	}
+
+	var req requests.CompleteSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	hostID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.sessionUseCase.CompleteSession(c.UserContext(), sessionID, hostID, req); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Session completed successfully", nil))
 }
 
 func (h *SessionHandler) GetUserSessions(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	includeHistory := c.QueryBool("include_history", false)
 
-	sessions, err := h.sessionUseCase.GetUserSessions(c.Context(), userID, includeHistory)
+	sessions, err := h.sessionUseCase.GetUserSessions(c.UserContext(), userID, includeHistory)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: sessions,
-	})
+	return c.JSON(responses.OK(sessions))
 }
 
 func (h *SessionHandler) ChangeParticipantStatus(c *fiber.Ctx) error {
 	sessionID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
 	var req requests.ChangeParticipantStatusRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid request body",
 			Code:        "INVALID_REQUEST",
 			Description: err.Error(),
-		})
+		}))
 	}
 
 	hostID := c.Locals("userID").(uuid.UUID)
 
-	if err := h.sessionUseCase.ChangeParticipantStatus(c.Context(), sessionID, hostID, req); err != nil {
+	if err := h.sessionUseCase.ChangeParticipantStatus(c.UserContext(), sessionID, hostID, req); err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(responses.SuccessResponse{
-		Message: "Participant status updated successfully",
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Participant status updated successfully", nil))
 }
 
 func (h *SessionHandler) GetSessionParticipants(c *fiber.Ctx) error {
 	sessionID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
 			Error:       "Invalid session ID",
 			Code:        "INVALID_ID",
 			Description: "The provided session ID is not in a valid format",
-		})
+		}))
 	}
 
-	participants, err := h.sessionUseCase.GetSessionParticipants(c.Context(), sessionID)
+	participants, err := h.sessionUseCase.GetSessionParticipants(c.UserContext(), sessionID)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: participants,
-	})
+	return c.JSON(responses.OK(participants))
 }
 
 func (h *SessionHandler) GetMyJoinedSessions(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	includeHistory := c.QueryBool("include_history", false)
 
-	sessions, err := h.sessionUseCase.GetMyJoinedSessions(c.Context(), userID, includeHistory)
+	sessions, err := h.sessionUseCase.GetMyJoinedSessions(c.UserContext(), userID, includeHistory)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: sessions,
-	})
+	return c.JSON(responses.OK(sessions))
 }
 
 func (h *SessionHandler) GetMyHostedSessions(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	includeHistory := c.QueryBool("include_history", false)
 
-	sessions, err := h.sessionUseCase.GetMyHostedSessions(c.Context(), userID, includeHistory)
+	sessions, err := h.sessionUseCase.GetMyHostedSessions(c.UserContext(), userID, includeHistory)
 	if err != nil {
 		return h.handleError(c, err)
 	}
 
-	return c.JSON(responses.SuccessResponse{
-		Data: sessions,
-	})
+	return c.JSON(responses.OK(sessions))
+}
+
+// GetSessionMessages returns the latest ?limit= messages of the session's chat
+// to its host and confirmed players
+func (h *SessionHandler) GetSessionMessages(c *fiber.Ctx) error {
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid session ID",
+			Code:        "INVALID_ID",
+			Description: "The provided session ID is not in a valid format",
+		}))
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	offset := max(c.QueryInt("offset", 0), 0)
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	chatID, err := h.sessionUseCase.GetSessionChatID(c.UserContext(), sessionID, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	messages, err := h.chatUseCase.GetChatMessageByID(c.UserContext(), chatID, limit, offset, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Chat messages retrieved successfully", messages))
 }
 
 func (h *SessionHandler) handleError(c *fiber.Ctx, err error) error {
@@ -364,6 +585,12 @@ func (h *SessionHandler) handleError(c *fiber.Ctx, err error) error {
 			Error: "Session not found",
 			Code:  "SESSION_NOT_FOUND",
 		}
+	case errors.Is(err, chat.ErrChatNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Chat not found",
+			Code:  "CHAT_NOT_FOUND",
+		}
 	case errors.Is(err, session.ErrUnauthorized):
 		status = fiber.StatusUnauthorized
 		errorResponse = responses.ErrorResponse{
@@ -376,6 +603,36 @@ func (h *SessionHandler) handleError(c *fiber.Ctx, err error) error {
 			Error: "Validation error",
 			Code:  "VALIDATION_ERROR",
 		}
+	case errors.Is(err, session.ErrScheduleConflict):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Schedule conflict",
+			Code:  "SCHEDULE_CONFLICT",
+		}
+	case errors.Is(err, session.ErrSessionFull):
+		status = fiber.StatusConflict
+		errorResponse = responses.ErrorResponse{
+			Error: "Session is full",
+			Code:  "SESSION_FULL",
+		}
+	case errors.Is(err, session.ErrUserNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "User not found",
+			Code:  "USER_NOT_FOUND",
+		}
+	case errors.Is(err, session.ErrEmailNotVerified):
+		status = fiber.StatusForbidden
+		errorResponse = responses.ErrorResponse{
+			Error: "Email not verified",
+			Code:  "EMAIL_NOT_VERIFIED",
+		}
+	case errors.Is(err, session.ErrTooManyCheckInAttempts):
+		status = fiber.StatusTooManyRequests
+		errorResponse = responses.ErrorResponse{
+			Error: "Too many check-in attempts",
+			Code:  "TOO_MANY_CHECK_IN_ATTEMPTS",
+		}
 	default:
 		status = fiber.StatusInternalServerError
 		errorResponse = responses.ErrorResponse{
@@ -385,5 +642,5 @@ func (h *SessionHandler) handleError(c *fiber.Ctx, err error) error {
 	}
 
 	errorResponse.Description = err.Error()
-	return c.Status(status).JSON(errorResponse)
+	return c.Status(status).JSON(responses.Fail(errorResponse))
 }
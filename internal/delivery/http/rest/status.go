@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statusPingTimeout bounds the database ping so a hung database can't hang the status check
+const statusPingTimeout = 2 * time.Second
+
+// Pinger is the database as the status check sees it
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type StatusHandler struct {
+	db        Pinger
+	version   string
+	startedAt time.Time
+}
+
+func NewStatusHandler(db Pinger, version string, startedAt time.Time) *StatusHandler {
+	return &StatusHandler{
+		db:        db,
+		version:   version,
+		startedAt: startedAt,
+	}
+}
+
+func (h *StatusHandler) SetupStatusRoutes(app *fiber.App) {
+	app.Get("/api/status", h.GetStatus)
+}
+
+// GetStatus reports the build version, uptime and database ping latency. It
+// answers 503 when the database can't be reached.
+func (h *StatusHandler) GetStatus(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), statusPingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.db.PingContext(ctx)
+	latency := time.Since(start)
+
+	status := responses.StatusResponse{
+		Version:       h.version,
+		StartedAt:     h.startedAt.UTC().Format(time.RFC3339),
+		UptimeSeconds: int64(time.Since(h.startedAt).Seconds()),
+		Database: responses.DatabaseStatus{
+			Status:    "ok",
+			LatencyMs: float64(latency.Microseconds()) / 1000,
+		},
+	}
+
+	code := fiber.StatusOK
+	if err != nil {
+		code = fiber.StatusServiceUnavailable
+		status.Database.Status = "unavailable"
+		status.Database.Error = err.Error()
+	}
+
+	return c.Status(code).JSON(responses.OK(status))
+}
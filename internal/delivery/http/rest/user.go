@@ -1,21 +1,41 @@
 package rest
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"time"
+
 	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/export"
+	"badbuddy/internal/usecase/session"
 	"badbuddy/internal/usecase/user"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// exportTimeout bounds a data export, which runs after the handler returns and
+// so outlives the request timeout
+const exportTimeout = 5 * time.Minute
+
 type UserHandler struct {
-	userUseCase user.UseCase
+	userUseCase    user.UseCase
+	sessionUseCase session.UseCase
+	exportUseCase  export.UseCase
+	cacheMaxAge    time.Duration
 }
 
-func NewUserHandler(userUseCase user.UseCase) *UserHandler {
+// NewUserHandler lets CDNs cache the anonymous leaderboard for cacheMaxAge
+func NewUserHandler(userUseCase user.UseCase, sessionUseCase session.UseCase, exportUseCase export.UseCase, cacheMaxAge time.Duration) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUseCase,
+		userUseCase:    userUseCase,
+		sessionUseCase: sessionUseCase,
+		exportUseCase:  exportUseCase,
+		cacheMaxAge:    cacheMaxAge,
 	}
 }
 func (h *UserHandler) SetupUserRoutes(app *fiber.App) {
@@ -23,11 +43,16 @@ func (h *UserHandler) SetupUserRoutes(app *fiber.App) {
 
 	userGroup.Post("/register", h.Register)
 	userGroup.Post("/login", h.Login)
+	userGroup.Post("/verify-email", h.VerifyEmail)
+	userGroup.Get("/:id/hosted-sessions", h.GetHostedSessions)
+	userGroup.Get("/leaderboard", middleware.PublicCache(h.cacheMaxAge), h.GetLeaderboard)
 
 	// Protected routes
 	userGroup.Use(middleware.AuthRequired())
 	userGroup.Get("/profile", h.GetProfile)
 	userGroup.Put("/profile", h.UpdateProfile)
+	userGroup.Get("/me/export", h.ExportData)
+	userGroup.Post("/verify-email/resend", h.ResendVerificationEmail)
 	userGroup.Get("/search", h.SearchUsers)
 	userGroup.Put("/update/role", h.UpdateRoles)
 }
@@ -35,108 +60,116 @@ func (h *UserHandler) SetupUserRoutes(app *fiber.App) {
 func (h *UserHandler) Register(c *fiber.Ctx) error {
 	var req requests.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	if err := h.userUseCase.Register(c.Context(), req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.userUseCase.Register(c.UserContext(), req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "User registered successfully",
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("User registered successfully", nil))
 }
 
 func (h *UserHandler) Login(c *fiber.Ctx) error {
 	var req requests.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	response, err := h.userUseCase.Login(c.Context(), req)
+	response, err := h.userUseCase.Login(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage(err.Error()))
 	}
 
 	userID, err := uuid.Parse(response.User.ID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid user ID format",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid user ID format"))
 	}
-	venues, err := h.userUseCase.GetVenueUserOwn(c.Context(), userID)
+	venues, err := h.userUseCase.GetVenueUserOwn(c.UserContext(), userID)
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
 	response.User.Venues = venues
 
-	return c.JSON(response)
+	return c.JSON(responses.OK(response))
+}
+
+// VerifyEmail confirms the address with the token from the verification email
+func (h *UserHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req requests.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
+	}
+
+	if err := h.userUseCase.VerifyEmail(c.UserContext(), req); err != nil {
+		if errors.Is(err, user.ErrInvalidToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OKMessage("Email verified successfully", nil))
+}
+
+func (h *UserHandler) ResendVerificationEmail(c *fiber.Ctx) error {
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("unauthorized"))
+	}
+
+	if err := h.userUseCase.ResendVerificationEmail(c.UserContext(), userID); err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, user.ErrAlreadyVerified):
+			return c.Status(fiber.StatusConflict).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OKMessage("Verification email sent", nil))
 }
 
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 	userID, err := middleware.GetUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("unauthorized"))
 	}
 
-	profile, err := h.userUseCase.GetProfile(c.Context(), userID)
+	profile, err := h.userUseCase.GetProfile(c.UserContext(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
 	}
 
-	venues, err := h.userUseCase.GetVenueUserOwn(c.Context(), userID)
+	venues, err := h.userUseCase.GetVenueUserOwn(c.UserContext(), userID)
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
 	profile.Venues = venues
 
-	return c.JSON(profile)
+	return c.JSON(responses.OK(profile))
 }
 
 func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	if userID == uuid.Nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("unauthorized"))
 	}
 
 	var req requests.UpdateProfileRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	if err := h.userUseCase.UpdateProfile(c.Context(), userID, req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.userUseCase.UpdateProfile(c.UserContext(), userID, req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Profile updated successfully",
-	})
+	return c.JSON(responses.OKMessage("Profile updated successfully", nil))
 }
 
 func (h *UserHandler) SearchUsers(c *fiber.Ctx) error {
@@ -154,42 +187,98 @@ func (h *UserHandler) SearchUsers(c *fiber.Ctx) error {
 		filters.Offset = 0
 	}
 
-	users, err := h.userUseCase.SearchUsers(c.Context(), query, filters)
+	users, err := h.userUseCase.SearchUsers(c.UserContext(), query, filters)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(fiber.Map{
-		"users": users,
+	return c.JSON(responses.OK(users))
+}
+
+// ExportData streams the caller's profile, sessions, bookings, reviews and
+// chat messages as a JSON file
+func (h *UserHandler) ExportData(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="badbuddy-export.json"`)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+
+		if err := h.exportUseCase.ExportUserData(ctx, userID, w); err != nil {
+			log.Printf("failed to export data for user %s: %v", userID, err)
+		}
+		w.Flush()
 	})
+
+	return nil
+}
+
+// GetHostedSessions lists the upcoming public sessions hosted by a user
+func (h *UserHandler) GetHostedSessions(c *fiber.Ctx) error {
+	hostID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid user ID"))
+	}
+
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	sessions, err := h.sessionUseCase.GetHostedSessions(c.UserContext(), hostID, limit, offset)
+	if err != nil {
+		if errors.Is(err, session.ErrUserNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.Paginated(sessions.Sessions, sessions.Total, limit, offset))
+}
+
+// GetLeaderboard ranks players by ?metric=sessions_played|hosted|rating, optionally within ?location=
+func (h *UserHandler) GetLeaderboard(c *fiber.Ctx) error {
+	req := requests.LeaderboardRequest{
+		Metric:   c.Query("metric"),
+		Location: c.Query("location"),
+		Limit:    c.QueryInt("limit", 10),
+		Offset:   c.QueryInt("offset", 0),
+	}
+
+	leaderboard, err := h.userUseCase.GetLeaderboard(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidMetric) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.Paginated(leaderboard.Entries, leaderboard.Total, leaderboard.Limit, leaderboard.Offset))
 }
 
 func (h *UserHandler) UpdateRoles(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	if userID == uuid.Nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("unauthorized"))
 	}
 
 	var req requests.UpdateRolesRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	if err := h.userUseCase.UpdateRoles(c.Context(), userID, req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.userUseCase.UpdateRoles(c.UserContext(), userID, req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Roles updated successfully",
-	})
+	return c.JSON(responses.OKMessage("Roles updated successfully", nil))
 }
 
 
@@ -2,11 +2,14 @@ package rest
 
 import (
 	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/delivery/http/middleware"
 	"badbuddy/internal/usecase/facility"
 	"badbuddy/internal/usecase/user"
 	"badbuddy/internal/usecase/venue"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -16,13 +19,16 @@ type VenueHandler struct {
 	venueUseCase    venue.UseCase
 	facilityUseCase facility.UseCase
 	userUseCase     user.UseCase
+
+	cacheMaxAge time.Duration // How long anonymous listings may be cached
 }
 
-func NewVenueHandler(venueUseCase venue.UseCase, facilityUseCase facility.UseCase, userUseCase user.UseCase) *VenueHandler {
+func NewVenueHandler(venueUseCase venue.UseCase, facilityUseCase facility.UseCase, userUseCase user.UseCase, cacheMaxAge time.Duration) *VenueHandler {
 	return &VenueHandler{
 		venueUseCase:    venueUseCase,
 		facilityUseCase: facilityUseCase,
 		userUseCase:     userUseCase,
+		cacheMaxAge:     cacheMaxAge,
 	}
 }
 
@@ -30,20 +36,38 @@ func (h *VenueHandler) SetupVenueRoutes(app *fiber.App) {
 	venueGroup := app.Group("/api/venues")
 
 	// Public routes
-	venueGroup.Get("/", h.ListVenues)
-	venueGroup.Get("/search", h.SearchVenues)
-	venueGroup.Get("/:id", h.GetVenue)
+	venueGroup.Get("/", middleware.OptionalAuth(), middleware.PublicCache(h.cacheMaxAge), h.ListVenues)
+	venueGroup.Get("/search", middleware.OptionalAuth(), middleware.PublicCache(h.cacheMaxAge), h.SearchVenues)
+	venueGroup.Get("/featured", middleware.OptionalAuth(), h.ListFeaturedVenues)
+	// Registered ahead of /:id, which would otherwise match it
+	venueGroup.Get("/favorites", middleware.AuthRequired(), h.ListFavoriteVenues)
+	venueGroup.Get("/:id", middleware.OptionalAuth(), middleware.ETag(), h.GetVenue)
 	venueGroup.Get("/:id/reviews", h.GetReviews)
+	venueGroup.Get("/:id/rating-breakdown", h.GetRatingBreakdown)
 	venueGroup.Get("/:id/facilities", h.GetFacilitiesOfVenue)
+	venueGroup.Get("/:id/schedule", h.GetSchedule)
+	venueGroup.Get("/:id/tags", h.GetTags)
 
 	// Protected routes
 	venueGroup.Use(middleware.AuthRequired())
 	venueGroup.Post("/", h.CreateVenue)
+	// Registered before /:id/courts/:courtId, which would match "order" as a court ID
+	venueGroup.Put("/:id/courts/order", h.ReorderCourts)
 	//update court
 	venueGroup.Put("/:id/courts/:courtId", h.UpdateCourt)
 	venueGroup.Put("/:id", h.UpdateVenue)
+	venueGroup.Post("/:id/restore", h.RestoreVenue)
+	venueGroup.Post("/:id/claim", h.ClaimVenue)
+	venueGroup.Post("/:id/favorite", h.FavoriteVenue)
+	venueGroup.Delete("/:id/favorite", h.UnfavoriteVenue)
+	venueGroup.Put("/:id/featured", h.SetFeatured)
 	venueGroup.Post("/:id/courts", h.AddCourt)
 	venueGroup.Post("/:id/reviews", h.AddReview)
+	venueGroup.Post("/:id/reviews/:reviewId/helpful", h.ToggleReviewHelpful)
+	venueGroup.Post("/:id/reviews/:reviewId/response", h.RespondToReview)
+	venueGroup.Put("/:id/tags", h.SetTags)
+	venueGroup.Get("/:id/dashboard", h.GetDashboard)
+	venueGroup.Delete("/:id/tags/:tag", h.RemoveTag)
 
 	// delete court
 	venueGroup.Delete("/:id/courts/:courtId", h.DeleteCourt)
@@ -52,123 +76,257 @@ func (h *VenueHandler) SetupVenueRoutes(app *fiber.App) {
 func (h *VenueHandler) CreateVenue(c *fiber.Ctx) error {
 	var req requests.CreateVenueRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 	ownerID := c.Locals("userID").(uuid.UUID)
 
 	facility := req.Facilities
 
 	if !h.validateFacilities(facility, c) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid facility ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid facility ID"))
 	}
 
-	venue, err := h.venueUseCase.CreateVenue(c.Context(), ownerID, req)
+	created, err := h.venueUseCase.CreateVenue(c.UserContext(), ownerID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		if errors.Is(err, venue.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		if errors.Is(err, venue.ErrEmailNotVerified) {
+			return c.Status(fiber.StatusForbidden).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(venue)
+	return c.Status(fiber.StatusCreated).JSON(responses.OK(created))
 }
 
 func (h *VenueHandler) GetVenue(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
-	venue, err := h.venueUseCase.GetVenue(c.Context(), id)
+	venue, err := h.venueUseCase.GetVenue(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(venue)
+	if c.Query("include") == "rating_breakdown" {
+		venue.RatingBreakdown, err = h.venueUseCase.GetRatingBreakdown(c.UserContext(), id)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+		}
+	}
+
+	// Signed in callers learn whether they can still review the venue and whether
+	// they favorited it, so the body (and with it the ETag) differs between callers
+	c.Vary(fiber.HeaderAuthorization)
+	if callerID, ok := c.Locals("userID").(uuid.UUID); ok {
+		venue.MyReview, err = h.venueUseCase.GetUserReview(c.UserContext(), id, callerID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+		}
+		hasReviewed := venue.MyReview != nil
+		venue.HasReviewed = &hasReviewed
+
+		isFavorited, err := h.venueUseCase.IsFavorited(c.UserContext(), id, callerID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+		}
+		venue.IsFavorited = &isFavorited
+	}
+
+	return c.JSON(responses.OK(venue))
+}
+
+func (h *VenueHandler) GetRatingBreakdown(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	breakdown, err := h.venueUseCase.GetRatingBreakdown(c.UserContext(), venueID)
+	if err != nil {
+		if errors.Is(err, venue.ErrVenueNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(breakdown))
 }
 
 // เพิ่ม method UpdateVenue
 func (h *VenueHandler) UpdateVenue(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
 	ownerID := c.Locals("userID").(uuid.UUID)
 
-	isAdmin, err := h.userUseCase.IsAdmin(c.Context(), ownerID)
+	isAdmin, err := h.userUseCase.IsAdmin(c.UserContext(), ownerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	isOwner, err := h.venueUseCase.IsOwner(c.Context(), id, ownerID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), id, ownerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
 	// check isAdmin and pass owner check
 	if !isAdmin {
 		if !isOwner {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Unauthorized",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
 		}
 	}
 
 	var req requests.UpdateVenueRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
 	facility := req.Facilities
 
 	if !h.validateFacilities(facility, c) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid facility ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid facility ID"))
 	}
 
-	if err := h.venueUseCase.UpdateVenue(c.Context(), id, req); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.venueUseCase.UpdateVenue(c.UserContext(), id, req); err != nil {
+		if errors.Is(err, venue.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Venue updated successfully",
-	})
+	return c.JSON(responses.OKMessage("Venue updated successfully", nil))
 }
 
 func (h *VenueHandler) ListVenues(c *fiber.Ctx) error {
 	location := c.Query("location", "")
 	limit := c.QueryInt("limit", 10)
 	offset := c.QueryInt("offset", 0)
+	status := c.Query("status", "")
+	tags, matchAllTags := parseTagFilter(c)
+
+	// Signed in callers may list their venues that aren't active
+	callerID, _ := c.Locals("userID").(uuid.UUID)
+
+	venues, err := h.venueUseCase.ListVenues(c.UserContext(), callerID, location, status, tags, matchAllTags, limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, venue.ErrUnauthorized):
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrValidation):
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(venues))
+}
+
+func (h *VenueHandler) ListFeaturedVenues(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	offset := c.QueryInt("offset", 0)
+
+	venues, err := h.venueUseCase.ListFeaturedVenues(c.UserContext(), limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	// is_favorited is the caller's own
+	c.Vary(fiber.HeaderAuthorization)
+	if callerID, ok := c.Locals("userID").(uuid.UUID); ok {
+		if err := h.venueUseCase.MarkFavorites(c.UserContext(), callerID, venues); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+		}
+	}
+
+	return c.JSON(responses.OK(venues))
+}
+
+func (h *VenueHandler) ListFavoriteVenues(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 10)
+	offset := c.QueryInt("offset", 0)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	venues, err := h.venueUseCase.ListFavoriteVenues(c.UserContext(), userID, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.Paginated(venues.Venues, venues.Total, limit, offset))
+}
+
+func (h *VenueHandler) FavoriteVenue(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.venueUseCase.FavoriteVenue(c.UserContext(), venueID, userID); err != nil {
+		if errors.Is(err, venue.ErrVenueNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OKMessage("Venue added to favorites", nil))
+}
+
+func (h *VenueHandler) UnfavoriteVenue(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
 
-	venues, err := h.venueUseCase.ListVenues(c.Context(), location, limit, offset)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.venueUseCase.UnfavoriteVenue(c.UserContext(), venueID, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OKMessage("Venue removed from favorites", nil))
+}
+
+func (h *VenueHandler) SetFeatured(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	isAdmin, err := h.userUseCase.IsAdmin(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	if !isAdmin {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
 	}
 
-	return c.JSON(fiber.Map{
-		"venues": venues,
-	})
+	var req requests.SetFeaturedVenueRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
+	}
+
+	updated, err := h.venueUseCase.SetFeatured(c.UserContext(), venueID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, venue.ErrVenueNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrValidation):
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(updated))
 }
 
 func (h *VenueHandler) SearchVenues(c *fiber.Ctx) error {
@@ -185,215 +343,389 @@ func (h *VenueHandler) SearchVenues(c *fiber.Ctx) error {
 		facilityList = []string{}
 	}
 
-	venues, err := h.venueUseCase.SearchVenues(c.Context(), query, limit, offset, minPrice, maxPrice, location, facilityList)
+	tags, matchAllTags := parseTagFilter(c)
+
+	venues, err := h.venueUseCase.SearchVenues(c.UserContext(), query, limit, offset, minPrice, maxPrice, location, facilityList, tags, matchAllTags)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(venues)
+	if callerID, ok := c.Locals("userID").(uuid.UUID); ok {
+		if err := h.venueUseCase.MarkFavorites(c.UserContext(), callerID, venues.Venues); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+		}
+	}
+
+	return c.JSON(responses.Paginated(venues.Venues, venues.Total, limit, offset))
 }
 
 func (h *VenueHandler) AddCourt(c *fiber.Ctx) error {
 	venueID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
 	// check ownerID is owner or not
 	ownerID := c.Locals("userID").(uuid.UUID)
-	isOwner, err := h.venueUseCase.IsOwner(c.Context(), venueID, ownerID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
 	if !isOwner {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
 	}
 
 	var req requests.CreateCourtRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	court, err := h.venueUseCase.AddCourt(c.Context(), venueID, req)
+	court, err := h.venueUseCase.AddCourt(c.UserContext(), venueID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(court)
+	return c.Status(fiber.StatusCreated).JSON(responses.OK(court))
 }
 
 func (h *VenueHandler) UpdateCourt(c *fiber.Ctx) error {
 	vendorID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
 	// check ownerID is owner or not
 	ownerID := c.Locals("userID").(uuid.UUID)
-	isOwner, err := h.venueUseCase.IsOwner(c.Context(), vendorID, ownerID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), vendorID, ownerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
 	if !isOwner {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
 	}
 
 	courtID, err := uuid.Parse(c.Params("courtId"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid court ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid court ID"))
 	}
 
 	var req requests.UpdateCourtRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
 	req.CourtID = courtID.String()
 
-	if err := h.venueUseCase.UpdateCourt(c.Context(), vendorID, req); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	result, err := h.venueUseCase.UpdateCourt(c.UserContext(), vendorID, req)
+	if err != nil {
+		if errors.Is(err, venue.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Court updated successfully",
-	})
+	return c.JSON(responses.OKMessage("Court updated successfully", result))
+}
+
+// ClaimVenue asks to take over a venue that has no owner; an admin reviews the claim
+func (h *VenueHandler) ClaimVenue(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	var req requests.ClaimVenueRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
+	}
+
+	claimantID := c.Locals("userID").(uuid.UUID)
+
+	claim, err := h.venueUseCase.ClaimVenue(c.UserContext(), venueID, claimantID, req)
+	if err != nil {
+		return handleVenueClaimError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Claim submitted for review", claim))
+}
+
+func handleVenueClaimError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, venue.ErrValidation):
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+	case errors.Is(err, venue.ErrVenueNotFound), errors.Is(err, venue.ErrClaimNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+	case errors.Is(err, venue.ErrVenueAlreadyOwned), errors.Is(err, venue.ErrAlreadyClaimed):
+		return c.Status(fiber.StatusConflict).JSON(responses.FailMessage(err.Error()))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+}
+
+func (h *VenueHandler) RestoreVenue(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	restored, err := h.venueUseCase.RestoreVenue(c.UserContext(), venueID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, venue.ErrVenueNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrUnauthorized):
+			return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
+		case errors.Is(err, venue.ErrValidation):
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(restored))
 }
 
 func (h *VenueHandler) DeleteCourt(c *fiber.Ctx) error {
 	venueID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
 	// check ownerID is owner or not
 	ownerID := c.Locals("userID").(uuid.UUID)
-	isOwner, err := h.venueUseCase.IsOwner(c.Context(), venueID, ownerID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
 	if !isOwner {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
 	}
 
 	courtID, err := uuid.Parse(c.Params("courtId"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid court ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid court ID"))
+	}
+
+	if err := h.venueUseCase.DeleteCourt(c.UserContext(), venueID, courtID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OKMessage("Court deleted successfully", nil))
+}
+
+// ReorderCourts sets the order the venue's courts are listed in
+func (h *VenueHandler) ReorderCourts(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	ownerID := c.Locals("userID").(uuid.UUID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	if err := h.venueUseCase.DeleteCourt(c.Context(), venueID, courtID); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if !isOwner {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
+	}
+
+	var req requests.ReorderCourtsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Court deleted successfully",
-	})
+	courts, err := h.venueUseCase.ReorderCourts(c.UserContext(), venueID, req)
+	if err != nil {
+		if errors.Is(err, venue.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(courts))
 }
 
 // เพิ่ม method GetReviews
 func (h *VenueHandler) GetReviews(c *fiber.Ctx) error {
 	venueID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
 	limit := c.QueryInt("limit", 10)
 	offset := c.QueryInt("offset", 0)
 
-	reviews, err := h.venueUseCase.GetReviews(c.Context(), venueID, limit, offset)
+	minRating := c.QueryInt("min_rating", 0)
+	maxRating := c.QueryInt("max_rating", 0)
+
+	reviews, err := h.venueUseCase.GetReviews(c.UserContext(), venueID, c.Query("sort"), minRating, maxRating, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		if errors.Is(err, venue.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(fiber.Map{
-		"reviews": reviews,
-	})
+	return c.JSON(responses.OK(reviews))
+}
+
+func (h *VenueHandler) RespondToReview(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	ownerID := c.Locals("userID").(uuid.UUID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	if !isOwner {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
+	}
+
+	reviewID, err := uuid.Parse(c.Params("reviewId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid review ID"))
+	}
+
+	var req requests.RespondToReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
+	}
+
+	reply, err := h.venueUseCase.RespondToReview(c.UserContext(), venueID, reviewID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, venue.ErrReviewNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrValidation):
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(reply))
+}
+
+func (h *VenueHandler) ToggleReviewHelpful(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	reviewID, err := uuid.Parse(c.Params("reviewId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid review ID"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	vote, err := h.venueUseCase.ToggleReviewHelpful(c.UserContext(), venueID, reviewID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, venue.ErrReviewNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrValidation):
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(vote))
 }
 
 func (h *VenueHandler) AddReview(c *fiber.Ctx) error {
 	venueID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
 	userID := c.Locals("userID").(uuid.UUID)
 
 	var req requests.AddReviewRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
 	}
 
-	if err := h.venueUseCase.AddReview(c.Context(), venueID, userID, req); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	if err := h.venueUseCase.AddReview(c.UserContext(), venueID, userID, req); err != nil {
+		switch {
+		case errors.Is(err, venue.ErrAlreadyReviewed):
+			return c.Status(fiber.StatusConflict).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrReviewCooldown):
+			return c.Status(fiber.StatusTooManyRequests).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"message": "Review added successfully",
-	})
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Review added successfully", nil))
 }
 
 func (h *VenueHandler) GetFacilitiesOfVenue(c *fiber.Ctx) error {
 	venueID, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid venue ID",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	facilities, err := h.venueUseCase.GetFacilities(c.UserContext(), venueID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(facilities))
+}
+
+func (h *VenueHandler) GetSchedule(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	schedule, err := h.venueUseCase.GetSchedule(c.UserContext(), venueID, c.Query("date"))
+	if err != nil {
+		switch {
+		case errors.Is(err, venue.ErrVenueNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		case errors.Is(err, venue.ErrValidation):
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(schedule))
+}
+
+// GetDashboard returns the owner's summary of a venue
+func (h *VenueHandler) GetDashboard(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
 	}
 
-	facilities, err := h.venueUseCase.GetFacilities(c.Context(), venueID)
+	ownerID := c.Locals("userID").(uuid.UUID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
 	}
 
-	return c.JSON(facilities)
+	if !isOwner {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
+	}
+
+	dashboard, err := h.venueUseCase.GetDashboard(c.UserContext(), venueID)
+	if err != nil {
+		if errors.Is(err, venue.ErrVenueNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(dashboard))
 }
 
 func (h *VenueHandler) validateFacilities(facility []requests.Facility, c *fiber.Ctx) bool {
@@ -402,10 +734,92 @@ func (h *VenueHandler) validateFacilities(facility []requests.Facility, c *fiber
 		if err != nil {
 			return false
 		}
-		_, err = h.facilityUseCase.GetFacilityByID(c.Context(), facilityID)
+		_, err = h.facilityUseCase.GetFacilityByID(c.UserContext(), facilityID)
 		if err != nil {
 			return false
 		}
 	}
 	return true
 }
+
+func (h *VenueHandler) GetTags(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	tags, err := h.venueUseCase.GetTags(c.UserContext(), venueID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(tags))
+}
+
+func (h *VenueHandler) SetTags(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	ownerID := c.Locals("userID").(uuid.UUID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	if !isOwner {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
+	}
+
+	var req requests.SetVenueTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid request body"))
+	}
+
+	tags, err := h.venueUseCase.SetTags(c.UserContext(), venueID, req)
+	if err != nil {
+		if errors.Is(err, venue.ErrValidation) {
+			return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OK(tags))
+}
+
+func (h *VenueHandler) RemoveTag(c *fiber.Ctx) error {
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.FailMessage("Invalid venue ID"))
+	}
+
+	ownerID := c.Locals("userID").(uuid.UUID)
+	isOwner, err := h.venueUseCase.IsOwner(c.UserContext(), venueID, ownerID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	if !isOwner {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.FailMessage("Unauthorized"))
+	}
+
+	if err := h.venueUseCase.RemoveTag(c.UserContext(), venueID, c.Params("tag")); err != nil {
+		if errors.Is(err, venue.ErrTagNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(responses.FailMessage(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(responses.FailMessage(err.Error()))
+	}
+
+	return c.JSON(responses.OKMessage("Tag removed successfully", nil))
+}
+
+// parseTagFilter reads the comma-separated tags query and whether all of them must match
+func parseTagFilter(c *fiber.Ctx) ([]string, bool) {
+	tags := c.Query("tags")
+	if tags == "" {
+		return nil, false
+	}
+
+	return strings.Split(tags, ","), c.Query("tags_match", "any") == "all"
+}
@@ -0,0 +1,150 @@
+package rest
+
+import (
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/delivery/http/middleware"
+	"badbuddy/internal/usecase/webhook"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookUseCase webhook.UseCase
+}
+
+func NewWebhookHandler(webhookUseCase webhook.UseCase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+func (h *WebhookHandler) SetupWebhookRoutes(app *fiber.App) {
+	webhooks := app.Group("/api/venues/:id/webhooks", middleware.AuthRequired())
+
+	webhooks.Get("/", h.ListWebhooks)
+	webhooks.Post("/", h.RegisterWebhook)
+	webhooks.Delete("/:webhookID", h.DeleteWebhook)
+}
+
+// RegisterWebhook adds a URL that receives signed booking and payment events
+func (h *WebhookHandler) RegisterWebhook(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid venue ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	var req requests.RegisterWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid request body",
+			Code:        "INVALID_REQUEST",
+			Description: err.Error(),
+		}))
+	}
+
+	registered, err := h.webhookUseCase.RegisterWebhook(c.UserContext(), venueID, userID, req)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.OKMessage("Webhook registered successfully", registered))
+}
+
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid venue ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	webhooks, err := h.webhookUseCase.ListWebhooks(c.UserContext(), venueID, userID)
+	if err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OK(webhooks))
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	venueID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid venue ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	webhookID, err := uuid.Parse(c.Params("webhookID"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(responses.Fail(responses.ErrorResponse{
+			Error:       "Invalid webhook ID",
+			Code:        "INVALID_ID",
+			Description: err.Error(),
+		}))
+	}
+
+	if err := h.webhookUseCase.DeleteWebhook(c.UserContext(), venueID, webhookID, userID); err != nil {
+		return h.handleError(c, err)
+	}
+
+	return c.JSON(responses.OKMessage("Webhook deleted successfully", nil))
+}
+
+func (h *WebhookHandler) handleError(c *fiber.Ctx, err error) error {
+	var status int
+	var errorResponse responses.ErrorResponse
+
+	switch {
+	case errors.Is(err, webhook.ErrVenueNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Venue not found",
+			Code:  "VENUE_NOT_FOUND",
+		}
+	case errors.Is(err, webhook.ErrWebhookNotFound):
+		status = fiber.StatusNotFound
+		errorResponse = responses.ErrorResponse{
+			Error: "Webhook not found",
+			Code:  "WEBHOOK_NOT_FOUND",
+		}
+	case errors.Is(err, webhook.ErrForbidden):
+		status = fiber.StatusForbidden
+		errorResponse = responses.ErrorResponse{
+			Error: "Forbidden",
+			Code:  "FORBIDDEN",
+		}
+	case errors.Is(err, webhook.ErrValidation):
+		status = fiber.StatusBadRequest
+		errorResponse = responses.ErrorResponse{
+			Error: "Validation error",
+			Code:  "VALIDATION_ERROR",
+		}
+	default:
+		status = fiber.StatusInternalServerError
+		errorResponse = responses.ErrorResponse{
+			Error: "Internal server error",
+			Code:  "INTERNAL_ERROR",
+		}
+	}
+
+	errorResponse.Description = err.Error()
+	return c.Status(status).JSON(responses.Fail(errorResponse))
+}
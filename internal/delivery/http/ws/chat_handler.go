@@ -2,26 +2,109 @@
 package ws
 
 import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 )
 
-func ChatWebSocketHandler(hub *ChatHub) fiber.Handler {
+// ChatMemberChecker tells whether a user belongs to a chat
+type ChatMemberChecker interface {
+	IsUserPartOfChat(ctx context.Context, userID, chatID uuid.UUID) (bool, error)
+}
+
+// clientEvent is an event sent by a client over the socket
+type clientEvent struct {
+	Type string `json:"type"`
+}
+
+// ChatWebSocketHandler streams the chat's events to the client. Clients that
+// connect as a member of the chat (see middleware.OptionalAuth) also share
+// presence and can send typing and stop_typing events.
+func ChatWebSocketHandler(hub *ChatHub, members ChatMemberChecker) fiber.Handler {
 	return websocket.New(func(c *websocket.Conn) {
 		chatID := c.Params("chat_id")
 		room := hub.GetRoom(chatID)
 
-		room.Clients[c] = true
+		userID := memberID(c, members, chatID)
+		online, first := room.join(c, userID)
 		defer func() {
-			delete(room.Clients, c)
+			if room.leave(c) {
+				room.relay <- roomMessage{data: event(responses.BoardCastUserOffline, userID), skip: c}
+			}
 			c.Close()
 		}()
 
+		if userID != "" {
+			presence, _ := json.Marshal(responses.BoardCastMessageResponse{
+				MessageaType: responses.BoardCastPresence,
+				Data:         map[string]interface{}{"user_ids": online},
+			})
+			room.relay <- roomMessage{data: presence, only: c}
+			if first {
+				room.relay <- roomMessage{data: event(responses.BoardCastUserOnline, userID), skip: c}
+			}
+		}
+
 		for {
-			_, _, err := c.ReadMessage()
+			_, data, err := c.ReadMessage()
 			if err != nil {
 				break
 			}
+			if userID == "" {
+				continue
+			}
+
+			var e clientEvent
+			if err := json.Unmarshal(data, &e); err != nil {
+				continue
+			}
+
+			switch e.Type {
+			case responses.BoardCastTyping:
+				if room.allowTyping(userID, time.Now()) {
+					room.relay <- roomMessage{data: event(responses.BoardCastTyping, userID), skip: c}
+				}
+			case responses.BoardCastStopTyping:
+				// Members were only told about typing that was relayed
+				if room.stopTyping(userID) {
+					room.relay <- roomMessage{data: event(responses.BoardCastStopTyping, userID), skip: c}
+				}
+			}
 		}
 	})
 }
+
+// memberID returns the connected user's ID when they belong to the chat, or
+// an empty string for anyone else
+func memberID(c *websocket.Conn, members ChatMemberChecker, chatID string) string {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return ""
+	}
+
+	chatUUID, err := uuid.Parse(chatID)
+	if err != nil {
+		return ""
+	}
+
+	isMember, err := members.IsUserPartOfChat(context.Background(), userID, chatUUID)
+	if err != nil || !isMember {
+		return ""
+	}
+
+	return userID.String()
+}
+
+func event(messageType, userID string) []byte {
+	data, _ := json.Marshal(responses.BoardCastMessageResponse{
+		MessageaType: messageType,
+		Data:         map[string]interface{}{"user_id": userID},
+	})
+	return data
+}
@@ -2,13 +2,32 @@
 package ws
 
 import (
-	"github.com/gofiber/websocket/v2"
 	"sync"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
 )
 
+// TypingThrottle is the shortest gap between two typing events relayed for
+// the same user in a room
+const TypingThrottle = 3 * time.Second
+
 type ChatRoom struct {
 	Clients   map[*websocket.Conn]bool
 	Broadcast chan []byte
+
+	mu         sync.Mutex
+	users      map[*websocket.Conn]string // Connection to the ID of the user who opened it
+	lastTyping map[string]time.Time
+	relay      chan roomMessage
+}
+
+// roomMessage is an event from one client, sent to everyone else in the room
+// or, when only is set, to that client alone
+type roomMessage struct {
+	data []byte
+	skip *websocket.Conn
+	only *websocket.Conn
 }
 
 type ChatHub struct {
@@ -27,8 +46,11 @@ func (h *ChatHub) GetRoom(chatID string) *ChatRoom {
 	defer h.mu.Unlock()
 	if _, ok := h.Rooms[chatID]; !ok {
 		h.Rooms[chatID] = &ChatRoom{
-			Clients:   make(map[*websocket.Conn]bool),
-			Broadcast: make(chan []byte),
+			Clients:    make(map[*websocket.Conn]bool),
+			Broadcast:  make(chan []byte),
+			users:      make(map[*websocket.Conn]string),
+			lastTyping: make(map[string]time.Time),
+			relay:      make(chan roomMessage),
 		}
 		go h.runRoom(h.Rooms[chatID])
 	}
@@ -37,12 +59,114 @@ func (h *ChatHub) GetRoom(chatID string) *ChatRoom {
 
 func (h *ChatHub) runRoom(room *ChatRoom) {
 	for {
-		msg := <-room.Broadcast
-		for client := range room.Clients {
-			if err := client.WriteMessage(websocket.TextMessage, msg); err != nil {
-				client.Close()
-				delete(room.Clients, client)
-			}
+		select {
+		case msg := <-room.Broadcast:
+			room.send(roomMessage{data: msg})
+		case msg := <-room.relay:
+			room.send(msg)
+		}
+	}
+}
+
+// send writes from the room goroutine only, as a connection allows a single writer
+func (room *ChatRoom) send(msg roomMessage) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	for client := range room.Clients {
+		if client == msg.skip || (msg.only != nil && client != msg.only) {
+			continue
+		}
+		if err := client.WriteMessage(websocket.TextMessage, msg.data); err != nil {
+			client.Close()
+			delete(room.Clients, client)
+		}
+	}
+}
+
+// join adds the client and returns the users online in the room, and whether
+// this is the user's first connection to it. Anonymous clients pass an empty
+// user ID and take no part in presence.
+func (room *ChatRoom) join(client *websocket.Conn, userID string) ([]string, bool) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	room.Clients[client] = true
+	if userID == "" {
+		return nil, false
+	}
+
+	first := !room.isOnline(userID)
+	room.users[client] = userID
+	return room.onlineUsers(), first
+}
+
+// leave removes the client and reports whether its user has no other
+// connection to the room left
+func (room *ChatRoom) leave(client *websocket.Conn) bool {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	delete(room.Clients, client)
+	userID, ok := room.users[client]
+	if !ok {
+		return false
+	}
+
+	delete(room.users, client)
+	if room.isOnline(userID) {
+		return false
+	}
+
+	delete(room.lastTyping, userID)
+	return true
+}
+
+// allowTyping reports whether a typing event from the user may be relayed,
+// at most once per TypingThrottle
+func (room *ChatRoom) allowTyping(userID string, now time.Time) bool {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if last, ok := room.lastTyping[userID]; ok && now.Sub(last) < TypingThrottle {
+		return false
+	}
+
+	room.lastTyping[userID] = now
+	return true
+}
+
+// stopTyping reports whether the user has a relayed typing event to end. If so
+// it resets the throttle so the user's next typing event goes out at once.
+func (room *ChatRoom) stopTyping(userID string) bool {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if _, ok := room.lastTyping[userID]; !ok {
+		return false
+	}
+
+	delete(room.lastTyping, userID)
+	return true
+}
+
+func (room *ChatRoom) isOnline(userID string) bool {
+	for _, id := range room.users {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (room *ChatRoom) onlineUsers() []string {
+	seen := make(map[string]bool)
+	users := []string{}
+	for _, id := range room.users {
+		if !seen[id] {
+			seen[id] = true
+			users = append(users, id)
 		}
 	}
+	return users
 }
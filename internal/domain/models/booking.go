@@ -29,6 +29,9 @@ const (
 	PaymentMethodQR       PaymentMethod = "qr"
 )
 
+// DefaultBookingPlayerCount is used when a booking doesn't say how many people will play
+const DefaultBookingPlayerCount = 2
+
 // CourtBooking represents a court booking
 type CourtBooking struct {
 	ID          uuid.UUID     `db:"id"`
@@ -43,12 +46,15 @@ type CourtBooking struct {
 	CreatedAt   time.Time     `db:"created_at"`
 	UpdatedAt   time.Time     `db:"updated_at"`
 	CancelledAt *time.Time    `db:"cancelled_at"`
+	ExpiresAt   *time.Time    `db:"expires_at"` // End of the hold on an unpaid pending booking
+	PlayerCount int           `db:"player_count"`
 
 	// Joined fields
 	CourtName     string  `db:"court_name"`
 	PricePerHour  float64 `db:"price_per_hour"`
 	VenueName     string  `db:"venue_name"`
 	VenueLocation string  `db:"venue_location"`
+	VenueTimezone string  `db:"venue_timezone"`
 	UserName      string  `db:"user_name"`
 
 	// Related data
@@ -116,6 +122,10 @@ func (b *CourtBooking) Validate() error {
 		return fmt.Errorf("booking date must be in the future")
 	}
 
+	if b.PlayerCount < 1 {
+		return fmt.Errorf("player count must be at least 1")
+	}
+
 	if b.StartTime.After(b.EndTime) {
 		return fmt.Errorf("start time must be before end time")
 	}
@@ -162,6 +172,24 @@ func (b *CourtBooking) CanBeCancelled() bool {
 	// return now.Add(24 * time.Hour).Before(bookingStart)
 }
 
+// CanTransitionTo reports whether the booking may move to the given status.
+// Cancelled bookings are final, and a confirmed booking can't go back to pending.
+func (b *CourtBooking) CanTransitionTo(status BookingStatus) bool {
+	switch b.Status {
+	case BookingStatusPending:
+		return status == BookingStatusPending || status == BookingStatusConfirmed || status == BookingStatusCancelled
+	case BookingStatusConfirmed:
+		return status == BookingStatusConfirmed || status == BookingStatusCancelled
+	default:
+		return false
+	}
+}
+
+// IsHoldExpired reports whether the booking is an unpaid pending booking whose hold has run out
+func (b *CourtBooking) IsHoldExpired(now time.Time) bool {
+	return b.Status == BookingStatusPending && b.Payment == nil && b.ExpiresAt != nil && now.After(*b.ExpiresAt)
+}
+
 // IsOverlapping checks if this booking overlaps with another booking
 func (b *CourtBooking) IsOverlapping(other *CourtBooking) bool {
 	if b.CourtID != other.CourtID || !b.Date.Equal(other.Date) {
@@ -183,6 +211,7 @@ func (b *CourtBooking) ToResponse() *responses.BookingResponse {
 		StartTime:     b.StartTime.Format("15:04"),
 		EndTime:       b.EndTime.Format("15:04"),
 		TotalAmount:   b.TotalAmount,
+		PlayerCount:   b.PlayerCount,
 		Status:        string(b.Status),
 		CreatedAt:     b.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:     b.UpdatedAt.Format(time.RFC3339),
@@ -196,6 +225,10 @@ func (b *CourtBooking) ToResponse() *responses.BookingResponse {
 		resp.CancelledAt = b.CancelledAt.Format(time.RFC3339)
 	}
 
+	if b.ExpiresAt != nil && b.Status == BookingStatusPending && b.Payment == nil {
+		resp.ExpiresAt = b.ExpiresAt.Format(time.RFC3339)
+	}
+
 	if b.Payment != nil {
 		resp.Payment = &responses.PaymentResponse{
 			ID:            b.Payment.ID.String(),
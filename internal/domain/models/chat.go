@@ -31,6 +31,8 @@ type Chat struct {
 	SessionID *uuid.UUID `db:"session_id"`
 	LastMessage *Message `db:"last_message,omitempty"`
 	Users []User `db:"users,omitempty"`
+	DeletedAt *time.Time `db:"deleted_at"`
+	Muted bool `db:"muted"` // Whether the listing user muted the chat
 }
 
 // ChatParticipant represents a user in a chat
@@ -39,6 +41,7 @@ type ChatParticipant struct {
 	ChatID     uuid.UUID `db:"chat_id"`
 	UserID     uuid.UUID `db:"user_id"`
 	IsAdmin    bool      `db:"is_admin"`
+	Muted      bool      `db:"muted"`
 	LastReadAt time.Time `db:"last_read_at"`
 	JoinedAt   time.Time `db:"joined_at"`
 	LeftAt     time.Time `db:"left_at"`
@@ -58,6 +61,8 @@ type Message struct {
 	CreatedAt    time.Time     `db:"created_at"`
 	UpdatedAt    time.Time     `db:"updated_at"`
 	DeletedAt    *time.Time    `db:"delete_at"`
+	IsPinned     bool          `db:"is_pinned"`
+	PinnedAt     *time.Time    `db:"pinned_at"`
 	UserID       uuid.UUID     `db:"u_id"`
 	Email        string        `db:"email"`
 	FirstName    string        `db:"first_name"`
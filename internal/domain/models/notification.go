@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeSessionCancelled    NotificationType = "session_cancelled"
+	NotificationTypeSessionUpdated      NotificationType = "session_updated"
+	NotificationTypeSessionAnnouncement NotificationType = "session_announcement"
+	NotificationTypeParticipant         NotificationType = "participant_status"
+	NotificationTypeBooking             NotificationType = "booking"
+	NotificationTypeChatMessage         NotificationType = "chat_message"
+	NotificationTypeSystem              NotificationType = "system"
+)
+
+// Notification is an in-app message addressed to a single user
+type Notification struct {
+	ID        uuid.UUID        `db:"id"`
+	UserID    uuid.UUID        `db:"user_id"`
+	Type      NotificationType `db:"type"`
+	Title     string           `db:"title"`
+	Body      *string          `db:"body"`
+	Data      NullRawMessage   `db:"data"`
+	ReadAt    *time.Time       `db:"read_at"`
+	CreatedAt time.Time        `db:"created_at"`
+}
+
+type ReminderKind string
+
+const (
+	ReminderKindSession ReminderKind = "session"
+	ReminderKindBooking ReminderKind = "booking"
+)
+
+// Reminder is an upcoming session or booking a user is told about by SMS.
+// Title is the session title or the booked court's name.
+type Reminder struct {
+	Kind      ReminderKind `db:"kind"`
+	TargetID  uuid.UUID    `db:"target_id"`
+	UserID    uuid.UUID    `db:"user_id"`
+	Phone     string       `db:"phone"`
+	Title     string       `db:"title"`
+	VenueName string       `db:"venue_name"`
+	Timezone  string       `db:"timezone"`
+	StartsAt  time.Time    `db:"starts_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportTargetType string
+type ReportStatus string
+
+const (
+	ReportTargetSession ReportTargetType = "session"
+	ReportTargetVenue   ReportTargetType = "venue"
+	ReportTargetUser    ReportTargetType = "user"
+	ReportTargetMessage ReportTargetType = "message"
+
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusResolved  ReportStatus = "resolved"  // Action was taken
+	ReportStatusDismissed ReportStatus = "dismissed" // Nothing wrong was found
+)
+
+// Report is a user's flag on content they find abusive, waiting for an admin
+type Report struct {
+	ID         uuid.UUID        `db:"id"`
+	ReporterID uuid.UUID        `db:"reporter_id"`
+	TargetType ReportTargetType `db:"target_type"`
+	TargetID   uuid.UUID        `db:"target_id"`
+	Reason     string           `db:"reason"`
+	Status     ReportStatus     `db:"status"`
+	ResolvedBy *uuid.UUID       `db:"resolved_by"`
+	ResolvedAt *time.Time       `db:"resolved_at"`
+	CreatedAt  time.Time        `db:"created_at"`
+}
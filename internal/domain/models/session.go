@@ -18,27 +18,59 @@ const (
 	ParticipantStatusConfirmed ParticipantStatus = "confirmed"
 	ParticipantStatusPending   ParticipantStatus = "pending"
 	ParticipantStatusCancelled ParticipantStatus = "cancelled"
+	ParticipantStatusNoShow    ParticipantStatus = "no_show"
 )
 
+// SessionCostMode decides how cost_per_person is set: fixed by the host, or
+// split from the booked courts' price
+type SessionCostMode string
+
+const (
+	SessionCostModeFixed SessionCostMode = "fixed"
+	SessionCostModeSplit SessionCostMode = "split"
+)
+
+// SessionFormat is how the session is played, which decides how many players share a court
+type SessionFormat string
+
+const (
+	SessionFormatSingles SessionFormat = "singles"
+	SessionFormatDoubles SessionFormat = "doubles"
+)
+
+// SessionCourt is a court reserved by a session, with the session's time window
+type SessionCourt struct {
+	SessionID uuid.UUID `db:"session_id"`
+	CourtID   uuid.UUID `db:"court_id"`
+	Title     string    `db:"title"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+}
+
 // Session represents a play session
 type Session struct {
-	ID                        uuid.UUID     `db:"id"`
-	HostID                    uuid.UUID     `db:"host_id"`
-	VenueID                   uuid.UUID     `db:"venue_id"`
-	Title                     string        `db:"title"`
-	Description               *string       `db:"description"`
-	SessionDate               time.Time     `db:"session_date"`
-	StartTime                 time.Time     `db:"start_time"`
-	EndTime                   time.Time     `db:"end_time"`
-	PlayerLevel               PlayerLevel   `db:"player_level"`
-	MaxParticipants           int           `db:"max_participants"`
-	CostPerPerson             float64       `db:"cost_per_person"`
-	AllowCancellation         bool          `db:"allow_cancellation"`
-	CancellationDeadlineHours *int          `db:"cancellation_deadline_hours"`
-	IsPublic                  bool          `db:"is_public"`
-	Status                    SessionStatus `db:"status"`
-	CreatedAt                 time.Time     `db:"created_at"`
-	UpdatedAt                 time.Time     `db:"updated_at"`
+	ID                        uuid.UUID       `db:"id"`
+	HostID                    uuid.UUID       `db:"host_id"`
+	VenueID                   uuid.UUID       `db:"venue_id"`
+	Title                     string          `db:"title"`
+	Description               *string         `db:"description"`
+	SessionDate               time.Time       `db:"session_date"`
+	StartTime                 time.Time       `db:"start_time"`
+	EndTime                   time.Time       `db:"end_time"`
+	OpenEnded                 bool            `db:"open_ended"` // EndTime is then the venue's closing time
+	PlayerLevel               PlayerLevel     `db:"player_level"`
+	MaxParticipants           int             `db:"max_participants"`
+	CostPerPerson             float64         `db:"cost_per_person"`
+	CostMode                  SessionCostMode `db:"cost_mode"`
+	PlayFormat                SessionFormat   `db:"play_format"`
+	TotalCourtCost            float64         `db:"total_court_cost"`
+	AllowCancellation         bool            `db:"allow_cancellation"`
+	CancellationDeadlineHours *int            `db:"cancellation_deadline_hours"`
+	IsPublic                  bool            `db:"is_public"`
+	CheckInCode               *string         `db:"check_in_code"`
+	Status                    SessionStatus   `db:"status"`
+	CreatedAt                 time.Time       `db:"created_at"`
+	UpdatedAt                 time.Time       `db:"updated_at"`
 }
 
 // SessionRule represents a rule for a session
@@ -57,6 +89,8 @@ type SessionParticipant struct {
 	Status      ParticipantStatus `db:"status"`
 	JoinedAt    time.Time         `db:"joined_at"`
 	CancelledAt *time.Time        `db:"cancelled_at"`
+	CheckedInAt *time.Time        `db:"checked_in_at"`
+	JoinMessage *string           `db:"join_message"`        // Note for the host sent with the join request
 	UserName    string            `db:"user_name,omitempty"` // From JOIN with users table
 }
 
@@ -67,6 +101,7 @@ type SessionDetail struct {
 	Session
 	VenueName        string               `db:"venue_name"`
 	VenueLocation    string               `db:"venue_location"`
+	VenueTimezone    string               `db:"venue_timezone"`
 	HostName         string               `db:"host_name"`
 	HostGender       string               `db:"host_gender"`
 	HostLevel        PlayerLevel          `db:"host_level"`
@@ -74,6 +109,7 @@ type SessionDetail struct {
 	PendingPlayers   int                  `db:"pending_players"`
 	Participants     []SessionParticipant `db:"participants,omitempty"`
 	Rules            []SessionRule        `db:"rules,omitempty"`
+	Courts           []Court              `db:"courts,omitempty"` // The courts reserved for the session, only filled by GetByID
 	Search_vector    string               `db:"search_vector"`
 	IsPublic         bool                 `db:"is_public"`
 }
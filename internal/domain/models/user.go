@@ -9,6 +9,7 @@ import (
 type UserStatus string
 type PlayerLevel string
 type UserRole string
+type LeaderboardMetric string
 
 const (
 	UserStatusActive   UserStatus = "active"
@@ -21,6 +22,10 @@ const (
 	UserRoleAdmin UserRole = "admin"
 	UserRoleUser  UserRole = "user"
 	UserRoleVenue UserRole = "venue"
+
+	LeaderboardMetricSessionsPlayed LeaderboardMetric = "sessions_played"
+	LeaderboardMetricHosted         LeaderboardMetric = "hosted"
+	LeaderboardMetricRating         LeaderboardMetric = "rating"
 )
 
 type User struct {
@@ -41,6 +46,21 @@ type User struct {
 	LastActiveAt  time.Time   `db:"last_active_at"`
 	Search_vector string      `db:"search_vector"`
 	Role          string      `db:"role"`
+	EmailVerified bool        `db:"email_verified"`
+}
+
+// LeaderboardEntry holds the public part of a user's profile stats
+type LeaderboardEntry struct {
+	ID             uuid.UUID   `db:"id"`
+	FirstName      string      `db:"first_name"`
+	LastName       string      `db:"last_name"`
+	AvatarURL      string      `db:"avatar_url"`
+	Location       string      `db:"location"`
+	PlayLevel      PlayerLevel `db:"play_level"`
+	HostedSessions int         `db:"hosted_sessions"`
+	JoinedSessions int         `db:"joined_sessions"`
+	AverageRating  float64     `db:"avg_rating"`
+	TotalReviews   int         `db:"total_reviews"`
 }
 
 type VenueUserOwn struct {
@@ -54,4 +74,5 @@ type UserProfile struct {
 	AverageRating   float64 `db:"avg_rating"`
 	TotalReviews    int     `db:"total_reviews"`
 	RegularPartners int     `db:"regular_partners"`
+	NoShowSessions  int     `db:"no_show_sessions"`
 }
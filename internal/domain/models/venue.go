@@ -23,6 +23,26 @@ const (
 	CourtStatusMaintenance CourtStatus = "maintenance"
 )
 
+// DefaultCourtMaxPlayers is the capacity of a court that doesn't set its own, enough for doubles
+const DefaultCourtMaxPlayers = 4
+
+// DefaultVenueTimezone is used for venues that don't set their own timezone
+const DefaultVenueTimezone = "Asia/Bangkok"
+
+// LoadTimezone resolves an IANA timezone name, falling back to
+// DefaultVenueTimezone and then the server's zone
+func LoadTimezone(name string) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(DefaultVenueTimezone); err == nil {
+		return loc
+	}
+	return time.Local
+}
+
 // NullRawMessage is a custom type that properly handles NULL JSON values
 type NullRawMessage struct {
 	json.RawMessage
@@ -107,9 +127,26 @@ type Venue struct {
 	Rules         NullRawMessage `db:"rules"`
 	Facilities    []Facility     `db:"facilities"`
 	Courts        []Court        `db:"courts"`
+	Tags          []string       `db:"tags"`
 	Latitude      float64        `db:"latitude"`
 	Longitude     float64        `db:"longitude"`
+	Timezone      string         `db:"timezone"`
+	Featured      bool           `db:"featured"`
+	FeaturedUntil *time.Time     `db:"featured_until"`
+	Relevance     float64        `db:"relevance"` // Text search rank, only set by Search
 }
+
+// IsFeatured reports whether the venue is currently promoted
+func (v *Venue) IsFeatured(now time.Time) bool {
+	return v.Featured && (v.FeaturedUntil == nil || v.FeaturedUntil.After(now))
+}
+
+// TimeLocation returns the venue's timezone, falling back to the default zone
+// when it is unset or unknown
+func (v *Venue) TimeLocation() *time.Location {
+	return LoadTimezone(v.Timezone)
+}
+
 type VenueInsert struct {
 	ID            uuid.UUID   `db:"id"`
 	Name          string      `db:"name"`
@@ -132,6 +169,7 @@ type VenueInsert struct {
 	Facilities    []Facility  `db:"facilities"`
 	Latitude      float64     `db:"latitude"`
 	Longitude     float64     `db:"longitude"`
+	Timezone      string      `db:"timezone"`
 }
 
 type Court struct {
@@ -143,7 +181,9 @@ type Court struct {
 	Name          string      `db:"name"`
 	Description   string      `db:"description"`
 	PricePerHour  float64     `db:"price_per_hour"`
+	MaxPlayers    int         `db:"max_players"`
 	Status        CourtStatus `db:"status"`
+	DisplayOrder  int         `db:"display_order"` // Position in the venue's court list, set by the owner
 	CreatedAt     time.Time   `db:"created_at"`
 	UpdatedAt     time.Time   `db:"updated_at"`
 	DeletedAt     *time.Time  `db:"deleted_at"`
@@ -162,4 +202,8 @@ type VenueReview struct {
 	Comment   string    `db:"comment"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdateAt  time.Time `db:"updated_at"`
+
+	HelpfulCount     int        `db:"helpful_count"` // Users who voted the review helpful
+	OwnerResponse    *string    `db:"owner_response"`
+	OwnerRespondedAt *time.Time `db:"owner_responded_at"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VenueClaimStatus string
+
+const (
+	VenueClaimStatusPending  VenueClaimStatus = "pending"
+	VenueClaimStatusApproved VenueClaimStatus = "approved" // The claimant now owns the venue
+	VenueClaimStatusRejected VenueClaimStatus = "rejected"
+)
+
+// VenueClaim is a user's request to take over a venue that has no owner, such as
+// one imported before its operator signed up. An admin checks the evidence and
+// calls the phone number before approving it.
+type VenueClaim struct {
+	ID         uuid.UUID        `db:"id"`
+	VenueID    uuid.UUID        `db:"venue_id"`
+	ClaimantID uuid.UUID        `db:"claimant_id"`
+	Phone      string           `db:"phone"`
+	Evidence   string           `db:"evidence"`
+	Status     VenueClaimStatus `db:"status"`
+	ReviewedBy *uuid.UUID       `db:"reviewed_by"`
+	ReviewedAt *time.Time       `db:"reviewed_at"`
+	CreatedAt  time.Time        `db:"created_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventBookingCreated   WebhookEvent = "booking.created"
+	WebhookEventBookingConfirmed WebhookEvent = "booking.confirmed"
+	WebhookEventBookingCancelled WebhookEvent = "booking.cancelled"
+	WebhookEventPaymentUpdated   WebhookEvent = "payment.updated"
+)
+
+// VenueWebhook is a URL a venue owner registered to receive booking events.
+// Deliveries are signed with Secret.
+type VenueWebhook struct {
+	ID        uuid.UUID `db:"id"`
+	VenueID   uuid.UUID `db:"venue_id"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	CreatedAt time.Time `db:"created_at"`
+}
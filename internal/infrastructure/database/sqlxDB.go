@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -16,11 +17,22 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// SearchConfig is the text search configuration the play_sessions trigger
+	// builds search vectors with; empty leaves the trigger's default (simple)
+	SearchConfig string
 }
 
+var searchConfigPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
 func NewSQLxDB(config Config) (*sqlx.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode)
+	if config.SearchConfig != "" {
+		if !searchConfigPattern.MatchString(config.SearchConfig) {
+			return nil, fmt.Errorf("invalid text search configuration %q", config.SearchConfig)
+		}
+		dsn += fmt.Sprintf(" options='-c badbuddy.search_config=%s'", config.SearchConfig)
+	}
 	db, err := sqlx.Connect("postgres", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to the database: %w", err)
@@ -0,0 +1,99 @@
+// Package media holds the limits for images users upload or link to
+package media
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+)
+
+var (
+	ErrTooLarge = errors.New("image is too large")
+
+	ErrNotAllowed = errors.New("image is not allowed")
+)
+
+// Policy limits image uploads by size and type, and image URLs by type and host
+type Policy struct {
+	MaxUploadBytes int64
+	// AllowedTypes are MIME types such as "image/png"
+	AllowedTypes []string
+	// AllowedHosts match the host and its subdomains. When empty any public
+	// host is accepted, but never localhost or a private address.
+	AllowedHosts []string
+}
+
+// DefaultPolicy accepts JPEG, PNG, GIF and WebP images up to 5 MB from any public host
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxUploadBytes: 5 << 20,
+		AllowedTypes:   []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
+	}
+}
+
+// CheckUpload validates an uploaded image by its size and Content-Type
+func (p Policy) CheckUpload(size int64, contentType string) error {
+	if p.MaxUploadBytes > 0 && size > p.MaxUploadBytes {
+		return fmt.Errorf("%w: images must be at most %d bytes", ErrTooLarge, p.MaxUploadBytes)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !p.allowsType(mediaType) {
+		return fmt.Errorf("%w: images must be one of %s", ErrNotAllowed, strings.Join(p.AllowedTypes, ", "))
+	}
+
+	return nil
+}
+
+// CheckURL validates a linked image by its host and file extension. The URL
+// isn't fetched.
+func (p Policy) CheckURL(raw string) error {
+	target, err := url.Parse(raw)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" || target.User != nil {
+		return fmt.Errorf("%w: image must be an absolute http(s) URL", ErrNotAllowed)
+	}
+
+	if !p.allowsHost(strings.ToLower(target.Hostname())) {
+		return fmt.Errorf("%w: images can't be linked from %s", ErrNotAllowed, target.Hostname())
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(mime.TypeByExtension(strings.ToLower(path.Ext(target.Path))))
+	if !p.allowsType(mediaType) {
+		return fmt.Errorf("%w: images must be one of %s", ErrNotAllowed, strings.Join(p.AllowedTypes, ", "))
+	}
+
+	return nil
+}
+
+func (p Policy) allowsType(mediaType string) bool {
+	for _, allowed := range p.AllowedTypes {
+		if strings.EqualFold(allowed, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) allowsHost(host string) bool {
+	if len(p.AllowedHosts) > 0 {
+		for _, allowed := range p.AllowedHosts {
+			allowed = strings.ToLower(allowed)
+			if host == allowed || strings.HasSuffix(host, "."+allowed) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsGlobalUnicast() && !ip.IsPrivate()
+	}
+	return true
+}
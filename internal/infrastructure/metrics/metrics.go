@@ -0,0 +1,31 @@
+// Package metrics holds the Prometheus collectors the service exports on /metrics
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// RequestDuration times HTTP requests by method, matched route and status code
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "badbuddy",
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// BookingsCreated counts court bookings made
+	BookingsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "badbuddy",
+		Name:      "bookings_created_total",
+		Help:      "Court bookings created.",
+	})
+
+	// SessionsCancelled counts play sessions cancelled, by who cancelled them
+	SessionsCancelled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "badbuddy",
+		Name:      "sessions_cancelled_total",
+		Help:      "Play sessions cancelled.",
+	}, []string{"by"})
+)
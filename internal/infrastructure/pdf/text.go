@@ -0,0 +1,80 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	margin     = 50
+	lineHeight = 16
+)
+
+// TextRenderer writes single page PDFs made of a heading and plain text lines.
+// It only uses the built-in Helvetica fonts, so characters outside Latin-1
+// are replaced with "?".
+type TextRenderer struct{}
+
+func NewTextRenderer() *TextRenderer {
+	return &TextRenderer{}
+}
+
+// Render lays out the title followed by one line of text per entry
+func (r *TextRenderer) Render(title string, lines []string) ([]byte, error) {
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT /F1 18 Tf %d %d Td (%s) Tj ET\n", margin, pageHeight-margin, escape(title))
+	fmt.Fprintf(&content, "BT /F2 11 Tf %d TL %d %d Td\n", lineHeight, margin, pageHeight-margin-2*lineHeight)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", escape(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return out.Bytes(), nil
+}
+
+// escape makes text safe inside a PDF string literal
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20 || r > 0xff:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
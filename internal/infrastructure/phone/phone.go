@@ -0,0 +1,24 @@
+// Package phone normalizes the phone numbers users and venues give us
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+var ErrInvalid = errors.New("invalid phone number")
+
+// Normalize parses a phone number and formats it as E.164, e.g. +66812345678.
+// Numbers without a country code are read as local numbers of defaultRegion,
+// an ISO 3166-1 alpha-2 code such as "TH".
+func Normalize(raw, defaultRegion string) (string, error) {
+	number, err := phonenumbers.Parse(strings.TrimSpace(raw), strings.ToUpper(defaultRegion))
+	if err != nil || !phonenumbers.IsValidNumber(number) {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, raw)
+	}
+
+	return phonenumbers.Format(number, phonenumbers.E164), nil
+}
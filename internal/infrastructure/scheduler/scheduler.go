@@ -0,0 +1,78 @@
+// Package scheduler runs background jobs at fixed intervals until its context
+// is cancelled
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is one run of a background job. Its error is logged, and the job runs
+// again on the next tick either way.
+type Job func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      Job
+}
+
+// Scheduler runs each registered job on its own ticker. Runs of the same job
+// never overlap: a run that outlasts the interval delays the next one.
+type Scheduler struct {
+	jobs []job
+	wg   sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job that runs every interval. Jobs must be registered
+// before Start.
+func (s *Scheduler) Register(name string, interval time.Duration, run Job) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start runs every job once its first interval has passed and then on each
+// tick, until ctx is cancelled. It doesn't block.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j job) {
+			defer s.wg.Done()
+
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					s.runOnce(ctx, j)
+				}
+			}
+		}(j)
+	}
+}
+
+// Wait blocks until every job has returned after the Start context was cancelled
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// runOnce keeps a failing or panicking job from stopping its ticker
+func (s *Scheduler) runOnce(ctx context.Context, j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("job %s panicked: %v", j.name, r)
+		}
+	}()
+
+	if err := j.run(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("job %s failed: %v", j.name, err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package sms sends text messages to E.164 phone numbers
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TwilioSender sends messages through the Twilio Messages API
+type TwilioSender struct {
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func NewTwilioSender(accountSID, authToken, from string) *TwilioSender {
+	return &TwilioSender{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *TwilioSender) Send(ctx context.Context, to, body string) error {
+	endpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", s.accountSID)
+	form := url.Values{
+		"To":   {to},
+		"From": {s.from},
+		"Body": {body},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build SMS request: %w", err)
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS to %s: %w", to, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to send SMS to %s: status %d: %s", to, resp.StatusCode, detail)
+	}
+	return nil
+}
+
+// LogSender writes messages to the log instead of sending them, for running
+// without an SMS provider
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, to, body string) error {
+	log.Printf("sms to %s: %s", to, body)
+	return nil
+}
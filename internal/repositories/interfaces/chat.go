@@ -20,9 +20,17 @@ type ChatRepository interface {
 	UpdateChatMessageReadStatus(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error
 	GetMessageByID(ctx context.Context, messageID uuid.UUID) (*models.Message, error) // Get a message by ID
 	IsUserIsSender(ctx context.Context, userID, messageID uuid.UUID) (bool, error)
-	GetChats(ctx context.Context, userID uuid.UUID) (*[]models.Chat, error)
+	GetChats(ctx context.Context, userID uuid.UUID, limit, offset int) (*[]models.Chat, error) // Most recent message first
+	CountChats(ctx context.Context, userID uuid.UUID) (int, error)
 	GetUsersInChat(ctx context.Context, chatID uuid.UUID) (*[]models.User, error)
 	GetDirectChatID(ctx context.Context, userID, otherUserID uuid.UUID) (uuid.UUID, error)
 	IsUserPartOfSession(ctx context.Context, userID, sessionID uuid.UUID) (bool, error)
 	GetChatIDBySessionID(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, error)
+	GetUserMessages(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Message, error) // Messages sent by a user, oldest first
+	IsUserChatAdmin(ctx context.Context, userID, chatID uuid.UUID) (bool, error)                     // Chat admin, or host of the chat's session
+	SetMessagePinned(ctx context.Context, messageID uuid.UUID, pinned bool) error
+	GetPinnedMessages(ctx context.Context, chatID uuid.UUID) ([]models.Message, error) // Most recently pinned first
+	DeleteChat(ctx context.Context, chatID uuid.UUID) error                            // Soft-deletes the chat and its messages
+	SetChatMuted(ctx context.Context, userID, chatID uuid.UUID, muted bool) error
+	GetUnmutedUserIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) // Members who still get notified of new messages
 }
\ No newline at end of file
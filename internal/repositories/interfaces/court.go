@@ -16,6 +16,8 @@ type CourtRepository interface {
 	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.Court, error)
 	Update(ctx context.Context, court *models.Court) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.Court, error)
+	Restore(ctx context.Context, id uuid.UUID) error
 	GetByVenue(ctx context.Context, venueID uuid.UUID) ([]models.Court, error)
 	GetCourtWithVenueByVenue(ctx context.Context, venueID uuid.UUID) ([]models.CourtWithVenue, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.CourtStatus) error
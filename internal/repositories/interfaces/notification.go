@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"badbuddy/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	ListByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+	// ListDueReminders lists confirmed players and bookers with a phone number
+	// whose session or booking starts between now and until, and who haven't
+	// been sent a reminder for it yet
+	ListDueReminders(ctx context.Context, until time.Time) ([]models.Reminder, error)
+	// MarkReminderSent records the reminder and reports false if another
+	// instance already claimed it
+	MarkReminderSent(ctx context.Context, targetID, userID uuid.UUID) (bool, error)
+}
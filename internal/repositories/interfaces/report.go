@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"badbuddy/internal/domain/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportFilters narrows the admin report list; empty fields match everything
+type ReportFilters struct {
+	Status     models.ReportStatus
+	TargetType models.ReportTargetType
+	Limit      int
+	Offset     int
+}
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *models.Report) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Report, error)
+	List(ctx context.Context, filters ReportFilters) ([]models.Report, error)
+	Count(ctx context.Context, filters ReportFilters) (int, error)
+	Resolve(ctx context.Context, id uuid.UUID, status models.ReportStatus, resolvedBy uuid.UUID) error
+	// CountByReporterSince counts the reports a user filed since the given time
+	CountByReporterSince(ctx context.Context, reporterID uuid.UUID, since time.Time) (int, error)
+	HasOpenReport(ctx context.Context, reporterID uuid.UUID, targetType models.ReportTargetType, targetID uuid.UUID) (bool, error)
+	TargetExists(ctx context.Context, targetType models.ReportTargetType, targetID uuid.UUID) (bool, error)
+}
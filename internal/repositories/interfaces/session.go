@@ -2,20 +2,56 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"badbuddy/internal/domain/models"
 
 	"github.com/google/uuid"
 )
 
+// ErrSessionFull is returned when a confirmed participant would go over max_participants
+var ErrSessionFull = errors.New("session has no free places")
+
 type SessionRepository interface {
 	Create(ctx context.Context, session *models.Session) error
+	CreateWithHost(ctx context.Context, session *models.Session, host *models.SessionParticipant, courtIDs []uuid.UUID, rules []string) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.SessionDetail, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.SessionDetail, error)
 	Update(ctx context.Context, session *models.Session) error
 	List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error)
 	Search(ctx context.Context, searchQuery string, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error)
-	AddParticipant(ctx context.Context, participant *models.SessionParticipant) error
+	// AddParticipant reports false when the user already has a row for the session.
+	// A confirmed participant is only added while a place is free, otherwise it fails
+	// with ErrSessionFull; taking the last place marks the session full. The check
+	// and the insert are atomic, so concurrent joins can't overfill a session.
+	AddParticipant(ctx context.Context, participant *models.SessionParticipant) (bool, error)
+	// AddConfirmedParticipants adds the users as confirmed participants in one
+	// transaction and returns the ones that were added, skipping users who
+	// already have a row. It adds nobody and reports false when they wouldn't
+	// fit in max_participants, and marks the session full when they fill it.
+	AddConfirmedParticipants(ctx context.Context, sessionID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, bool, error)
 	UpdateParticipantStatus(ctx context.Context, sessionID, userID uuid.UUID, status models.ParticipantStatus) error
+	// PromoteWaitlisted confirms the pending participant who joined first when the
+	// session has a free place, and reports false when nobody was promoted. It sets
+	// an open or full session to whichever matches the new confirmed count.
+	PromoteWaitlisted(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, bool, error)
+	// CompleteSession marks the session completed and, in the same transaction, marks
+	// its confirmed participants other than the host as no-shows unless they checked
+	// in or are listed in attended
+	CompleteSession(ctx context.Context, sessionID uuid.UUID, attended []uuid.UUID) error
+	CheckInParticipant(ctx context.Context, sessionID, userID uuid.UUID) error
+	GetUserOverlappingSessions(ctx context.Context, userID uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) ([]models.Session, error)
+	GetUpcomingVenueSessions(ctx context.Context, venueID uuid.UUID, fromDate time.Time) ([]models.Session, error)
+	// GetUpcomingCourtSessions returns the open and full sessions from fromDate on that hold courtID
+	GetUpcomingCourtSessions(ctx context.Context, courtID uuid.UUID, fromDate time.Time) ([]models.Session, error)
+	// ReplaceSessionCourt moves a session's reservation from oldCourtID to newCourtID
+	ReplaceSessionCourt(ctx context.Context, sessionID, oldCourtID, newCourtID uuid.UUID) error
+	GetVenueSessionCourts(ctx context.Context, venueID uuid.UUID, date time.Time) ([]models.SessionCourt, error)
+	// GetConflictingSessionCourts returns the courts among courtIDs that an active session
+	// other than excludeSessionID holds on sessionDate during startTime-endTime
+	GetConflictingSessionCourts(ctx context.Context, courtIDs []uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) ([]models.SessionCourt, error)
+	GetCourtsHourlyRate(ctx context.Context, sessionID uuid.UUID) (float64, error)
 	GetParticipants(ctx context.Context, sessionID uuid.UUID) ([]models.SessionParticipant, error)
 	GetUserSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error)
 	GetMyJoinedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error)
@@ -4,6 +4,7 @@ package interfaces
 import (
 	"badbuddy/internal/domain/models"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +16,16 @@ type UserSearchFilters struct {
 	Offset    int
 }
 
+// LeaderboardFilters ranks users by Metric. Only users with a non-zero metric
+// are listed; ranking by rating also needs at least MinReviews reviews.
+type LeaderboardFilters struct {
+	Metric     models.LeaderboardMetric
+	Location   string
+	MinReviews int
+	Limit      int
+	Offset     int
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
@@ -22,8 +33,14 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserProfile, error)
+	GetLeaderboard(ctx context.Context, filters LeaderboardFilters) ([]models.LeaderboardEntry, error)
+	CountLeaderboard(ctx context.Context, filters LeaderboardFilters) (int, error)
 	UpdateLastActive(ctx context.Context, userID uuid.UUID) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
 	SearchUsers(ctx context.Context, query string, filters UserSearchFilters) ([]models.User, error)
 	GetVenueUserOwn(ctx context.Context, userID uuid.UUID) ([]models.VenueUserOwn, error)
 	IsUserExist(ctx context.Context, userID uuid.UUID) (bool, error)
+	// CreateEmailVerification stores a new token for the user, replacing any they already have
+	CreateEmailVerification(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error
+	VerifyEmail(ctx context.Context, tokenHash string) (bool, error)
 }
@@ -3,27 +3,109 @@ package interfaces
 import (
 	"badbuddy/internal/domain/models"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrDuplicateReview is returned when a user reviews the same venue twice
+	ErrDuplicateReview = errors.New("venue already reviewed by this user")
+
+	// ErrReviewTooSoon is returned when a user's previous review is inside the cooldown
+	ErrReviewTooSoon = errors.New("review posted too soon after the previous one")
+
+	// ErrTagNotFound is returned when removing a tag the venue doesn't have
+	ErrTagNotFound = errors.New("tag not found")
+)
+
+// VenueTagFilter restricts venue results to those carrying the given tags.
+// With MatchAll every tag must be present, otherwise any one of them is enough.
+type VenueTagFilter struct {
+	Tags     []string
+	MatchAll bool
+}
+
+// VenueListFilters narrows List. An empty Status lists every status, and a
+// non-nil OwnerID only lists that owner's venues.
+type VenueListFilters struct {
+	Location string
+	Status   models.VenueStatus
+	OwnerID  uuid.UUID
+	Tags     VenueTagFilter
+}
+
+// ReviewListFilters narrows and orders GetReviews. A zero MinRating or MaxRating
+// leaves that bound open. Reviews are listed newest first unless SortByHelpful,
+// which puts the most helpful first.
+type ReviewListFilters struct {
+	MinRating     int
+	MaxRating     int
+	SortByHelpful bool
+}
+
 type VenueRepository interface {
 	Create(ctx context.Context, venue *models.Venue) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.VenueWithCourts, error)
 	Update(ctx context.Context, venue *models.Venue) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, location string, limit, offset int) ([]models.Venue, error)
+	GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.Venue, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	SetFeatured(ctx context.Context, id uuid.UUID, featured bool, until *time.Time) error
+	ListFeatured(ctx context.Context, limit, offset int) ([]models.Venue, error)
+	List(ctx context.Context, filters VenueListFilters, limit, offset int) ([]models.Venue, error)
 	CountVenues(ctx context.Context) (int, error)
-	Search(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facility []string) ([]models.Venue, error)
+	Search(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facility []string, tagFilter VenueTagFilter) ([]models.Venue, error)
 	AddCourt(ctx context.Context, court *models.Court) error
 	UpdateCourt(ctx context.Context, court *models.Court) error
 	DeleteCourt(ctx context.Context, id uuid.UUID) error
 	GetCourts(ctx context.Context, venueID uuid.UUID) ([]models.Court, error)
-	AddReview(ctx context.Context, review *models.VenueReview) error
-	GetReviews(ctx context.Context, venueID uuid.UUID, limit, offset int) ([]models.VenueReview, error)
+	// ReorderCourts sets the display order of the venue's courts to the order of courtIDs
+	ReorderCourts(ctx context.Context, venueID uuid.UUID, courtIDs []uuid.UUID) error
+	// AddReview inserts the review and recomputes the venue's rating in one transaction.
+	// It fails with ErrDuplicateReview when the user already reviewed the venue and with
+	// ErrReviewTooSoon when their latest review of any venue is younger than cooldown.
+	AddReview(ctx context.Context, review *models.VenueReview, cooldown time.Duration) error
+	HasUserReviewed(ctx context.Context, venueID, userID uuid.UUID) (bool, error)
+	// GetUserVenueReview returns the user's review of the venue, or nil if they haven't reviewed it
+	GetUserVenueReview(ctx context.Context, venueID, userID uuid.UUID) (*models.VenueReview, error)
+	GetLastReviewAt(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	GetReviews(ctx context.Context, venueID uuid.UUID, filters ReviewListFilters, limit, offset int) ([]models.VenueReview, error)
+	GetReviewByID(ctx context.Context, id uuid.UUID) (*models.VenueReview, error)
+	// GetRatingCounts returns how many of the venue's reviews gave each star rating.
+	// Ratings nobody gave are left out.
+	GetRatingCounts(ctx context.Context, venueID uuid.UUID) (map[int]int, error)
+	// ToggleReviewVote adds the user's helpful vote on the review, or takes it back
+	// when they already voted. It reports whether the vote is now in place and the
+	// review's helpful count.
+	ToggleReviewVote(ctx context.Context, reviewID, userID uuid.UUID) (bool, int, error)
+	// SetReviewResponse sets the venue owner's public reply to a review, replacing any earlier one
+	SetReviewResponse(ctx context.Context, reviewID uuid.UUID, response string, respondedAt time.Time) error
+	GetUserReviews(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.VenueReview, error)
 	UpdateVenueRating(ctx context.Context, venueID uuid.UUID) error
 	GetFacilities(ctx context.Context, venueID uuid.UUID) ([]models.Facility, error)
 	AddFacilities(ctx context.Context, venueID uuid.UUID, facilityIDs []uuid.UUID) error
 	UpdateFacilities(ctx context.Context, venueID uuid.UUID, facilityIDs []uuid.UUID) error
-	CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string) (int, error)
+	CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string, tagFilter VenueTagFilter) (int, error)
+	GetTags(ctx context.Context, venueID uuid.UUID) ([]string, error)
+	CreateClaim(ctx context.Context, claim *models.VenueClaim) error
+	GetClaim(ctx context.Context, id uuid.UUID) (*models.VenueClaim, error)
+	HasPendingClaim(ctx context.Context, venueID, claimantID uuid.UUID) (bool, error)
+	// ListClaims lists claims with the status, oldest first; an empty status lists every claim
+	ListClaims(ctx context.Context, status models.VenueClaimStatus, limit, offset int) ([]models.VenueClaim, error)
+	CountClaims(ctx context.Context, status models.VenueClaimStatus) (int, error)
+	// ReviewClaim approves or rejects a pending claim. Approving makes the claimant the
+	// owner of the venue, which must still have none, and rejects its other pending claims.
+	ReviewClaim(ctx context.Context, id uuid.UUID, status models.VenueClaimStatus, reviewedBy uuid.UUID) error
+	// AddFavorite and RemoveFavorite are no-ops when the venue already is, or isn't, a favorite
+	AddFavorite(ctx context.Context, userID, venueID uuid.UUID) error
+	RemoveFavorite(ctx context.Context, userID, venueID uuid.UUID) error
+	// ListFavorites lists the user's favorite venues that still exist, most recently favorited first
+	ListFavorites(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Venue, error)
+	CountFavorites(ctx context.Context, userID uuid.UUID) (int, error)
+	// GetFavoritedVenueIDs returns which of the venues the user has favorited
+	GetFavoritedVenueIDs(ctx context.Context, userID uuid.UUID, venueIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	SetTags(ctx context.Context, venueID uuid.UUID, tags []string) error
+	RemoveTag(ctx context.Context, venueID uuid.UUID, tag string) error
 }
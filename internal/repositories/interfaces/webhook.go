@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"badbuddy/internal/domain/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.VenueWebhook) error
+	ListByVenue(ctx context.Context, venueID uuid.UUID) ([]models.VenueWebhook, error)
+	Delete(ctx context.Context, id, venueID uuid.UUID) error
+}
@@ -0,0 +1,399 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type bookingRepository struct {
+	store *Store
+}
+
+func NewBookingRepository(store *Store) interfaces.BookingRepository {
+	return &bookingRepository{store: store}
+}
+
+// Create checks the slot and inserts the booking under one lock, so two
+// concurrent bookings for the same slot can't both succeed
+func (r *bookingRepository) Create(ctx context.Context, booking *models.CourtBooking) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.bookings[booking.ID]; exists {
+		return fmt.Errorf("booking %s already exists", booking.ID)
+	}
+	if !r.isAvailable(booking.CourtID, booking.Date, booking.StartTime, booking.EndTime) {
+		return fmt.Errorf("court is not available for the requested time")
+	}
+
+	stored := *booking
+	stored.Payment = nil
+	r.store.bookings[booking.ID] = stored
+	return nil
+}
+
+// CreateIfAvailable needs no separate lock, the store mutex already makes the
+// check and the insert atomic
+func (r *bookingRepository) CreateIfAvailable(ctx context.Context, booking *models.CourtBooking) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.bookings[booking.ID]; exists {
+		return false, fmt.Errorf("booking %s already exists", booking.ID)
+	}
+	if !r.isAvailable(booking.CourtID, booking.Date, booking.StartTime, booking.EndTime) {
+		return false, nil
+	}
+
+	stored := *booking
+	stored.Payment = nil
+	r.store.bookings[booking.ID] = stored
+	return true, nil
+}
+
+func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	booking, ok := r.store.bookings[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	joined := r.join(booking)
+	return &joined, nil
+}
+
+// List returns bookings at venues owned by userID
+func (r *bookingRepository) List(ctx context.Context, userID uuid.UUID, filters map[string]interface{}, limit, offset int) ([]models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	bookings := r.ownerBookings(userID, filters)
+	start, end := page(len(bookings), limit, offset)
+	return bookings[start:end], nil
+}
+
+func (r *bookingRepository) Count(ctx context.Context, userID uuid.UUID, filters map[string]interface{}) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return len(r.ownerBookings(userID, filters)), nil
+}
+
+func (r *bookingRepository) ownerBookings(ownerID uuid.UUID, filters map[string]interface{}) []models.CourtBooking {
+	return r.selectBookings(func(b models.CourtBooking) bool {
+		if ownerID != uuid.Nil && r.store.venues[r.store.courts[b.CourtID].VenueID].OwnerID != ownerID {
+			return false
+		}
+		return r.matches(b, filters)
+	}, false)
+}
+
+func (r *bookingRepository) Update(ctx context.Context, booking *models.CourtBooking) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.bookings[booking.ID]
+	if !ok {
+		return fmt.Errorf("booking not found")
+	}
+
+	stored.Status = booking.Status
+	stored.Notes = booking.Notes
+	stored.PlayerCount = booking.PlayerCount
+	stored.UpdatedAt = booking.UpdatedAt
+	r.store.bookings[booking.ID] = stored
+	return nil
+}
+
+func (r *bookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.bookings[id]; !ok {
+		return fmt.Errorf("booking not found")
+	}
+
+	delete(r.store.bookings, id)
+	delete(r.store.payments, id)
+	return nil
+}
+
+func (r *bookingRepository) GetUserBookings(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.CourtBooking, error) {
+	filters := map[string]interface{}{}
+	if !includeHistory {
+		filters["when"] = "upcoming"
+	}
+
+	return r.ListUserBookings(ctx, userID, filters, false)
+}
+
+func (r *bookingRepository) ListUserBookings(ctx context.Context, userID uuid.UUID, filters map[string]interface{}, descending bool) ([]models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	today := dayOf(time.Now())
+	return r.selectBookings(func(b models.CourtBooking) bool {
+		if b.UserID != userID {
+			return false
+		}
+		switch filters["when"] {
+		case "upcoming":
+			if dayOf(b.Date) < today {
+				return false
+			}
+		case "past":
+			if dayOf(b.Date) >= today {
+				return false
+			}
+		}
+		if status, ok := filters["status"].(models.BookingStatus); ok && b.Status != status {
+			return false
+		}
+		if venueID, ok := filters["venue_id"].(uuid.UUID); ok && r.store.courts[b.CourtID].VenueID != venueID {
+			return false
+		}
+		return true
+	}, descending), nil
+}
+
+func (r *bookingRepository) GetVenueBookings(ctx context.Context, venueID uuid.UUID, startDate, endDate time.Time) ([]models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.selectBookings(func(b models.CourtBooking) bool {
+		day := dayOf(b.Date)
+		return r.store.courts[b.CourtID].VenueID == venueID && day >= dayOf(startDate) && day <= dayOf(endDate)
+	}, false), nil
+}
+
+func (r *bookingRepository) GetUpcomingVenueBookings(ctx context.Context, venueID uuid.UUID, fromDate time.Time) ([]models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.selectBookings(func(b models.CourtBooking) bool {
+		return r.store.courts[b.CourtID].VenueID == venueID &&
+			dayOf(b.Date) >= dayOf(fromDate) &&
+			(b.Status == models.BookingStatusPending || b.Status == models.BookingStatusConfirmed)
+	}, false), nil
+}
+
+func (r *bookingRepository) GetCourtBookings(ctx context.Context, courtID uuid.UUID, date time.Time) ([]models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.selectBookings(func(b models.CourtBooking) bool {
+		return b.CourtID == courtID && dayOf(b.Date) == dayOf(date)
+	}, false), nil
+}
+
+// CheckCourtAvailability only looks for clashing bookings. Unlike the Postgres
+// version it doesn't check opening hours, which the usecase already does.
+func (r *bookingRepository) CheckCourtAvailability(ctx context.Context, courtID uuid.UUID, date time.Time, startTime, endTime time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.isAvailable(courtID, date, startTime, endTime), nil
+}
+
+func (r *bookingRepository) isAvailable(courtID uuid.UUID, date, startTime, endTime time.Time) bool {
+	now := time.Now()
+	for _, b := range r.store.bookings {
+		if b.CourtID != courtID || dayOf(b.Date) != dayOf(date) || b.Status == models.BookingStatusCancelled {
+			continue
+		}
+		if r.holdExpired(b, now) {
+			continue
+		}
+		if clockOf(b.StartTime) < clockOf(endTime) && clockOf(b.EndTime) > clockOf(startTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// holdExpired matches the Postgres expiredHoldCondition
+func (r *bookingRepository) holdExpired(b models.CourtBooking, now time.Time) bool {
+	_, paid := r.store.payments[b.ID]
+	return b.Status == models.BookingStatusPending && b.ExpiresAt != nil && b.ExpiresAt.Before(now) && !paid
+}
+
+func (r *bookingRepository) CancelExpiredHolds(ctx context.Context) ([]uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	ids := []uuid.UUID{}
+	for id, b := range r.store.bookings {
+		if !r.holdExpired(b, now) {
+			continue
+		}
+		b.Status = models.BookingStatusCancelled
+		b.CancelledAt = &now
+		b.UpdatedAt = now
+		r.store.bookings[id] = b
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *bookingRepository) CancelBooking(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	b, ok := r.store.bookings[id]
+	if !ok {
+		return fmt.Errorf("booking not found")
+	}
+
+	now := time.Now()
+	b.Status = models.BookingStatusCancelled
+	b.CancelledAt = &now
+	b.UpdatedAt = now
+	r.store.bookings[id] = b
+	return nil
+}
+
+func (r *bookingRepository) GetPayment(ctx context.Context, bookingID uuid.UUID) (*models.Payment, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	payment, ok := r.store.payments[bookingID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &payment, nil
+}
+
+// CreatePayment allows one payment per booking, like the unique booking_id column
+func (r *bookingRepository) CreatePayment(ctx context.Context, payment *models.Payment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.payments[payment.BookingID]; exists {
+		return fmt.Errorf("booking %s already has a payment", payment.BookingID)
+	}
+
+	r.store.payments[payment.BookingID] = *payment
+	return nil
+}
+
+func (r *bookingRepository) UpdatePayment(ctx context.Context, payment *models.Payment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for bookingID, stored := range r.store.payments {
+		if stored.ID != payment.ID {
+			continue
+		}
+		stored.Status = payment.Status
+		stored.PaymentMethod = payment.PaymentMethod
+		stored.UpdatedAt = payment.UpdatedAt
+		r.store.payments[bookingID] = stored
+		return nil
+	}
+
+	return fmt.Errorf("payment not found")
+}
+
+func (r *bookingRepository) ListAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.CourtBooking, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	bookings := r.adminBookings(filters)
+	start, end := page(len(bookings), limit, offset)
+	return bookings[start:end], nil
+}
+
+func (r *bookingRepository) CountAll(ctx context.Context, filters map[string]interface{}) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return len(r.adminBookings(filters)), nil
+}
+
+func (r *bookingRepository) adminBookings(filters map[string]interface{}) []models.CourtBooking {
+	return r.selectBookings(func(b models.CourtBooking) bool {
+		return r.matches(b, filters)
+	}, true)
+}
+
+// matches applies the filters shared by List, Count, ListAll and CountAll
+func (r *bookingRepository) matches(b models.CourtBooking, filters map[string]interface{}) bool {
+	if courtID, ok := filters["court_id"].(uuid.UUID); ok && b.CourtID != courtID {
+		return false
+	}
+	if venueID, ok := filters["venue_id"].(uuid.UUID); ok && r.store.courts[b.CourtID].VenueID != venueID {
+		return false
+	}
+	if userID, ok := filters["user_id"].(uuid.UUID); ok && b.UserID != userID {
+		return false
+	}
+	if status, ok := filters["status"].(models.BookingStatus); ok && b.Status != status {
+		return false
+	}
+	if paymentStatus, ok := filters["payment_status"].(models.PaymentStatus); ok {
+		if payment, paid := r.store.payments[b.ID]; !paid || payment.Status != paymentStatus {
+			return false
+		}
+	}
+	if date, ok := filters["date"].(string); ok && dayOf(b.Date) != date {
+		return false
+	}
+	if dateFrom, ok := filters["date_from"].(time.Time); ok && dayOf(b.Date) < dayOf(dateFrom) {
+		return false
+	}
+	if dateTo, ok := filters["date_to"].(time.Time); ok && dayOf(b.Date) > dayOf(dateTo) {
+		return false
+	}
+	return true
+}
+
+// selectBookings returns the joined bookings that match, ordered by date and start time
+func (r *bookingRepository) selectBookings(match func(models.CourtBooking) bool, descending bool) []models.CourtBooking {
+	bookings := []models.CourtBooking{}
+	for _, b := range r.store.bookings {
+		if match(b) {
+			bookings = append(bookings, r.join(b))
+		}
+	}
+
+	sort.Slice(bookings, func(i, j int) bool {
+		a, b := bookings[i], bookings[j]
+		if dayOf(a.Date) != dayOf(b.Date) {
+			return (dayOf(a.Date) < dayOf(b.Date)) != descending
+		}
+		return (clockOf(a.StartTime) < clockOf(b.StartTime)) != descending
+	})
+
+	return bookings
+}
+
+// join fills in the court, venue, user and payment fields the SQL joins would
+func (r *bookingRepository) join(b models.CourtBooking) models.CourtBooking {
+	court := r.store.courts[b.CourtID]
+	venue := r.store.venues[court.VenueID]
+
+	b.CourtName = court.Name
+	b.PricePerHour = court.PricePerHour
+	b.VenueName = venue.Name
+	b.VenueLocation = venue.Location
+	b.VenueTimezone = venue.Timezone
+	b.UserName = r.store.userName(b.UserID)
+	b.Payment = nil
+	if payment, ok := r.store.payments[b.ID]; ok {
+		b.Payment = &payment
+	}
+
+	return b
+}
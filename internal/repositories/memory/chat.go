@@ -0,0 +1,471 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+// ErrDirectChatParticipants is returned when a direct chat would end up with
+// other than its two members
+var ErrDirectChatParticipants = errors.New("a direct chat has exactly two participants")
+
+type chatRepository struct {
+	store *Store
+}
+
+func NewChatRepository(store *Store) interfaces.ChatRepository {
+	return &chatRepository{store: store}
+}
+
+func (r *chatRepository) GetChatMessageByID(ctx context.Context, chatID uuid.UUID, limit int, offset int) (*[]models.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.liveChat(chatID); !ok {
+		return nil, ErrNotFound
+	}
+
+	messages := []models.Message{}
+	for _, message := range r.store.messages {
+		if message.ChatID == chatID && message.DeletedAt == nil {
+			messages = append(messages, r.withSender(message))
+		}
+	}
+
+	start, end := page(len(messages), limit, offset)
+	messages = messages[start:end]
+	return &messages, nil
+}
+
+func (r *chatRepository) GetChatByID(ctx context.Context, chatID uuid.UUID) (*models.Chat, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	chat, ok := r.liveChat(chatID)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &chat, nil
+}
+
+func (r *chatRepository) IsUserPartOfChat(ctx context.Context, userID, chatID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.isParticipant(userID, chatID), nil
+}
+
+func (r *chatRepository) SaveMessage(ctx context.Context, message *models.Message) (*models.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.chats[message.ChatID]; !ok {
+		return nil, fmt.Errorf("chat %s not found", message.ChatID)
+	}
+
+	now := time.Now()
+	stored := *message
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.store.messages = append(r.store.messages, stored)
+
+	saved := r.withSender(stored)
+	return &saved, nil
+}
+
+func (r *chatRepository) GetMessageByID(ctx context.Context, messageID uuid.UUID) (*models.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	i := r.messageIndex(messageID)
+	if i < 0 {
+		return nil, ErrNotFound
+	}
+
+	message := r.withSender(r.store.messages[i])
+	return &message, nil
+}
+
+// CreateChat creates a chat with no members. Direct chats are created by
+// GetDirectChatID together with both their members instead.
+func (r *chatRepository) CreateChat(ctx context.Context, chat *models.Chat) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if chat.Type == models.ChatTypeDirect {
+		return ErrDirectChatParticipants
+	}
+
+	if _, exists := r.store.chats[chat.ID]; exists {
+		return fmt.Errorf("chat %s already exists", chat.ID)
+	}
+
+	r.store.chats[chat.ID] = models.Chat{ID: chat.ID, Type: chat.Type, SessionID: chat.SessionID}
+	return nil
+}
+
+// AddUserToChat refuses direct chats, whose two members are fixed
+func (r *chatRepository) AddUserToChat(ctx context.Context, userID, chatID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.chats[chatID].Type == models.ChatTypeDirect {
+		return ErrDirectChatParticipants
+	}
+
+	r.addParticipant(userID, chatID)
+	return nil
+}
+
+func (r *chatRepository) RemoveUserFromChat(ctx context.Context, userID, chatID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	kept := r.store.chatParticipants[:0]
+	for _, p := range r.store.chatParticipants {
+		if p.ChatID != chatID || p.UserID != userID {
+			kept = append(kept, p)
+		}
+	}
+	r.store.chatParticipants = kept
+	return nil
+}
+
+func (r *chatRepository) UpdateChatMessage(ctx context.Context, message *models.Message) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if i := r.messageIndex(message.ID); i >= 0 {
+		r.store.messages[i].Content = message.Content
+		r.store.messages[i].UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *chatRepository) DeleteChatMessage(ctx context.Context, messageID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if i := r.messageIndex(messageID); i >= 0 {
+		now := time.Now()
+		r.store.messages[i].DeletedAt = &now
+		r.store.messages[i].UpdatedAt = now
+	}
+	return nil
+}
+
+func (r *chatRepository) UpdateChatMessageReadStatus(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, message := range r.store.messages {
+		if message.ChatID == chatID && message.SenderID != userID && message.Status == models.MessageStatusSent {
+			r.store.messages[i].Status = models.MessageStatusRead
+		}
+	}
+	return nil
+}
+
+func (r *chatRepository) IsUserIsSender(ctx context.Context, userID, messageID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	i := r.messageIndex(messageID)
+	return i >= 0 && r.store.messages[i].SenderID == userID, nil
+}
+
+// GetChats pages through the user's chats with their latest message and
+// members, the one with the most recent message first
+func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID, limit, offset int) (*[]models.Chat, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	chats := []models.Chat{}
+	for _, p := range r.store.chatParticipants {
+		if p.UserID != userID {
+			continue
+		}
+
+		chat, ok := r.liveChat(p.ChatID)
+		if !ok {
+			continue
+		}
+		for i := len(r.store.messages) - 1; i >= 0; i-- {
+			if r.store.messages[i].ChatID == chat.ID && r.store.messages[i].DeletedAt == nil {
+				last := r.withSender(r.store.messages[i])
+				chat.LastMessage = &last
+				break
+			}
+		}
+		chat.Users = r.members(chat.ID)
+		chat.Muted = p.Muted
+		chats = append(chats, chat)
+	}
+
+	sort.SliceStable(chats, func(i, j int) bool {
+		if chats[i].LastMessage == nil || chats[j].LastMessage == nil {
+			return chats[j].LastMessage == nil && chats[i].LastMessage != nil
+		}
+		return chats[i].LastMessage.CreatedAt.After(chats[j].LastMessage.CreatedAt)
+	})
+
+	start, end := page(len(chats), limit, offset)
+	chats = chats[start:end]
+	return &chats, nil
+}
+
+func (r *chatRepository) CountChats(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	count := 0
+	for _, p := range r.store.chatParticipants {
+		if _, ok := r.liveChat(p.ChatID); ok && p.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *chatRepository) GetUsersInChat(ctx context.Context, chatID uuid.UUID) (*[]models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	users := r.members(chatID)
+	return &users, nil
+}
+
+// GetDirectChatID finds a chat both users are in, creating a direct chat when there is none
+func (r *chatRepository) GetDirectChatID(ctx context.Context, userID, otherUserID uuid.UUID) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if userID == otherUserID {
+		return uuid.Nil, ErrDirectChatParticipants
+	}
+
+	for _, p := range r.store.chatParticipants {
+		chat, live := r.liveChat(p.ChatID)
+		if live && chat.Type == models.ChatTypeDirect && p.UserID == userID && r.isParticipant(otherUserID, p.ChatID) {
+			return p.ChatID, nil
+		}
+	}
+
+	chatID := uuid.New()
+	r.store.chats[chatID] = models.Chat{ID: chatID, Type: models.ChatTypeDirect}
+	r.addParticipant(userID, chatID)
+	r.addParticipant(otherUserID, chatID)
+	return chatID, nil
+}
+
+func (r *chatRepository) IsUserPartOfSession(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, chat := range r.store.chats {
+		if chat.SessionID != nil && *chat.SessionID == sessionID && r.isParticipant(userID, chat.ID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *chatRepository) GetChatIDBySessionID(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, chat := range r.store.chats {
+		if chat.SessionID != nil && *chat.SessionID == sessionID && chat.DeletedAt == nil {
+			return chat.ID, nil
+		}
+	}
+	return uuid.Nil, ErrNotFound
+}
+
+func (r *chatRepository) GetUserMessages(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	messages := []models.Message{}
+	for _, message := range r.store.messages {
+		if message.SenderID == userID && message.DeletedAt == nil {
+			messages = append(messages, message)
+		}
+	}
+
+	start, end := page(len(messages), limit, offset)
+	return messages[start:end], nil
+}
+
+// DeleteChat soft-deletes the chat together with its messages
+func (r *chatRepository) DeleteChat(ctx context.Context, chatID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	chat, ok := r.liveChat(chatID)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	chat.DeletedAt = &now
+	r.store.chats[chatID] = chat
+
+	for i, message := range r.store.messages {
+		if message.ChatID == chatID && message.DeletedAt == nil {
+			r.store.messages[i].DeletedAt = &now
+			r.store.messages[i].UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (r *chatRepository) liveChat(chatID uuid.UUID) (models.Chat, bool) {
+	chat, ok := r.store.chats[chatID]
+	if !ok || chat.DeletedAt != nil {
+		return models.Chat{}, false
+	}
+	return chat, true
+}
+
+func (r *chatRepository) isParticipant(userID, chatID uuid.UUID) bool {
+	for _, p := range r.store.chatParticipants {
+		if p.ChatID == chatID && p.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *chatRepository) addParticipant(userID, chatID uuid.UUID) {
+	r.store.chatParticipants = append(r.store.chatParticipants, models.ChatParticipant{
+		ID:       uuid.New(),
+		ChatID:   chatID,
+		UserID:   userID,
+		JoinedAt: time.Now(),
+	})
+}
+
+func (r *chatRepository) members(chatID uuid.UUID) []models.User {
+	users := []models.User{}
+	for _, p := range r.store.chatParticipants {
+		if p.ChatID == chatID {
+			user := r.store.users[p.UserID]
+			user.Password = ""
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+func (r *chatRepository) messageIndex(messageID uuid.UUID) int {
+	for i, message := range r.store.messages {
+		if message.ID == messageID {
+			return i
+		}
+	}
+	return -1
+}
+
+// withSender copies the sender's profile onto the message like the users join
+func (r *chatRepository) withSender(message models.Message) models.Message {
+	sender := r.store.users[message.SenderID]
+	message.Email = sender.Email
+	message.FirstName = sender.FirstName
+	message.LastName = sender.LastName
+	message.Phone = sender.Phone
+	message.PlayLevel = string(sender.PlayLevel)
+	message.AvatarURL = &sender.AvatarURL
+	message.Gender = &sender.Gender
+	message.Location = &sender.Location
+	message.Bio = &sender.Bio
+	message.LastActiveAt = sender.LastActiveAt
+	return message
+}
+
+// IsUserChatAdmin reports whether the user is an admin of the chat or hosts
+// the session the chat belongs to
+func (r *chatRepository) IsUserChatAdmin(ctx context.Context, userID, chatID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, p := range r.store.chatParticipants {
+		if p.ChatID == chatID && p.UserID == userID && p.IsAdmin {
+			return true, nil
+		}
+	}
+
+	chat, ok := r.store.chats[chatID]
+	if !ok || chat.SessionID == nil {
+		return false, nil
+	}
+	session, ok := r.store.sessions[*chat.SessionID]
+	return ok && session.HostID == userID, nil
+}
+
+func (r *chatRepository) SetMessagePinned(ctx context.Context, messageID uuid.UUID, pinned bool) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if i := r.messageIndex(messageID); i >= 0 {
+		r.store.messages[i].IsPinned = pinned
+		r.store.messages[i].PinnedAt = nil
+		if pinned {
+			now := time.Now()
+			r.store.messages[i].PinnedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *chatRepository) GetPinnedMessages(ctx context.Context, chatID uuid.UUID) ([]models.Message, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	messages := []models.Message{}
+	for _, message := range r.store.messages {
+		if message.ChatID == chatID && message.IsPinned && message.DeletedAt == nil {
+			messages = append(messages, r.withSender(message))
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].PinnedAt.After(*messages[j].PinnedAt)
+	})
+
+	return messages, nil
+}
+
+func (r *chatRepository) SetChatMuted(ctx context.Context, userID, chatID uuid.UUID, muted bool) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, p := range r.store.chatParticipants {
+		if p.ChatID == chatID && p.UserID == userID {
+			r.store.chatParticipants[i].Muted = muted
+		}
+	}
+	return nil
+}
+
+func (r *chatRepository) GetUnmutedUserIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	userIDs := []uuid.UUID{}
+	for _, p := range r.store.chatParticipants {
+		if p.ChatID == chatID && !p.Muted {
+			userIDs = append(userIDs, p.UserID)
+		}
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,343 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type courtRepository struct {
+	store *Store
+}
+
+func NewCourtRepository(store *Store) interfaces.CourtRepository {
+	return &courtRepository{store: store}
+}
+
+func (r *courtRepository) Create(ctx context.Context, court *models.Court) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertCourt(court)
+}
+
+func (r *courtRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Court, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	court, ok := r.store.courts[id]
+	if !ok || court.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+
+	return &court, nil
+}
+
+func (r *courtRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.Court, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	court, ok := r.store.courts[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &court, nil
+}
+
+func (r *courtRepository) GetCourtWithVenueByID(ctx context.Context, id uuid.UUID) (*models.CourtWithVenue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	court, ok := r.store.courts[id]
+	if !ok || court.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	if _, ok := r.store.venues[court.VenueID]; !ok {
+		return nil, ErrNotFound
+	}
+
+	withVenue := r.store.courtWithVenue(court)
+	return &withVenue, nil
+}
+
+func (r *courtRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.Court, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	courts := r.filter(filters)
+	start, end := page(len(courts), limit, offset)
+	return courts[start:end], nil
+}
+
+func (r *courtRepository) Count(ctx context.Context, filters map[string]interface{}) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Count doesn't take the price filters, same as the Postgres version
+	countFilters := make(map[string]interface{}, len(filters))
+	for key, value := range filters {
+		if key != "price_min" && key != "price_max" {
+			countFilters[key] = value
+		}
+	}
+
+	return len(r.filter(countFilters)), nil
+}
+
+// filter lists the courts matching the List filters, newest first
+func (r *courtRepository) filter(filters map[string]interface{}) []models.Court {
+	courts := []models.Court{}
+	for _, court := range r.store.courts {
+		venue, ok := r.store.venues[court.VenueID]
+		if !ok || court.DeletedAt != nil {
+			continue
+		}
+		if venueID, ok := filters["venue_id"].(uuid.UUID); ok && court.VenueID != venueID {
+			continue
+		}
+		if status, ok := filters["status"].(models.CourtStatus); ok && court.Status != status {
+			continue
+		}
+		if location, ok := filters["location"].(string); ok && !strings.Contains(strings.ToLower(venue.Location), strings.ToLower(location)) {
+			continue
+		}
+		if priceMin, ok := filters["price_min"].(float64); ok && court.PricePerHour < priceMin {
+			continue
+		}
+		if priceMax, ok := filters["price_max"].(float64); ok && court.PricePerHour > priceMax {
+			continue
+		}
+		if date, startTime, endTime, ok := availabilityWindow(filters); ok && !r.isFree(court.ID, date, startTime, endTime) {
+			continue
+		}
+		courts = append(courts, r.store.courtWithVenue(court).Court)
+	}
+
+	sort.Slice(courts, func(i, j int) bool {
+		return courts[i].CreatedAt.After(courts[j].CreatedAt)
+	})
+
+	return courts
+}
+
+// availabilityWindow reads the "available_date", "available_from" and
+// "available_to" filters, which only apply when all three are set
+func availabilityWindow(filters map[string]interface{}) (time.Time, time.Time, time.Time, bool) {
+	date, hasDate := filters["available_date"].(time.Time)
+	startTime, hasStart := filters["available_from"].(time.Time)
+	endTime, hasEnd := filters["available_to"].(time.Time)
+	return date, startTime, endTime, hasDate && hasStart && hasEnd
+}
+
+// isFree reports whether no live booking or active session holds the court
+// during the window
+func (r *courtRepository) isFree(courtID uuid.UUID, date, startTime, endTime time.Time) bool {
+	bookings := &bookingRepository{store: r.store}
+	if !bookings.isAvailable(courtID, date, startTime, endTime) {
+		return false
+	}
+
+	for sessionID, courtIDs := range r.store.sessionCourts {
+		session := r.store.sessions[sessionID]
+		if session.Status == models.SessionStatusCancelled || session.Status == models.SessionStatusCompleted {
+			continue
+		}
+		if dayOf(session.SessionDate) != dayOf(date) ||
+			clockOf(session.StartTime) >= clockOf(endTime) || clockOf(session.EndTime) <= clockOf(startTime) {
+			continue
+		}
+		for _, id := range courtIDs {
+			if id == courtID {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *courtRepository) Update(ctx context.Context, court *models.Court) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.updateCourt(court)
+}
+
+func (r *courtRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.deleteCourt(id)
+}
+
+func (r *courtRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	court, ok := r.store.courts[id]
+	if !ok || court.DeletedAt == nil {
+		return fmt.Errorf("deleted court not found")
+	}
+
+	court.DeletedAt = nil
+	court.UpdatedAt = time.Now()
+	r.store.courts[id] = court
+	return nil
+}
+
+func (r *courtRepository) GetByVenue(ctx context.Context, venueID uuid.UUID) ([]models.Court, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.venueCourts(venueID), nil
+}
+
+func (r *courtRepository) GetCourtWithVenueByVenue(ctx context.Context, venueID uuid.UUID) ([]models.CourtWithVenue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	courts := []models.CourtWithVenue{}
+	for _, court := range r.store.venueCourts(venueID) {
+		courts = append(courts, r.store.courtWithVenue(court))
+	}
+
+	return courts, nil
+}
+
+func (r *courtRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.CourtStatus) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	court, ok := r.store.courts[id]
+	if !ok || court.DeletedAt != nil {
+		return fmt.Errorf("court not found")
+	}
+
+	court.Status = status
+	court.UpdatedAt = time.Now()
+	r.store.courts[id] = court
+	return nil
+}
+
+// GetAvailableCourts lists the venue's available courts with no active booking
+// overlapping the slot
+func (r *courtRepository) GetAvailableCourts(ctx context.Context, venueID uuid.UUID, date time.Time, startTime, endTime time.Time) ([]models.Court, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	courts := []models.Court{}
+	if r.store.venues[venueID].Status != models.VenueStatusActive {
+		return courts, nil
+	}
+
+	for _, court := range r.store.venueCourts(venueID) {
+		if court.Status != models.CourtStatusAvailable {
+			continue
+		}
+
+		booked := false
+		for _, b := range r.store.bookings {
+			if b.CourtID == court.ID && dayOf(b.Date) == dayOf(date) && b.Status != models.BookingStatusCancelled &&
+				clockOf(b.StartTime) < clockOf(endTime) && clockOf(b.EndTime) > clockOf(startTime) {
+				booked = true
+				break
+			}
+		}
+		if !booked {
+			courts = append(courts, r.store.courtWithVenue(court).Court)
+		}
+	}
+
+	return courts, nil
+}
+
+// The court helpers below are shared with the venue repository, which also
+// manages courts. Callers must hold the store lock.
+
+func (s *Store) insertCourt(court *models.Court) error {
+	displayOrder := 0
+	for _, existing := range s.courts {
+		if existing.VenueID == court.VenueID {
+			displayOrder = max(displayOrder, existing.DisplayOrder)
+		}
+		if existing.ID == court.ID {
+			return fmt.Errorf("court %s already exists", court.ID)
+		}
+		if existing.VenueID == court.VenueID && existing.Name == court.Name {
+			return fmt.Errorf("court %q already exists at this venue", court.Name)
+		}
+	}
+
+	court.DisplayOrder = displayOrder + 1
+	s.courts[court.ID] = *court
+	return nil
+}
+
+func (s *Store) updateCourt(court *models.Court) error {
+	stored, ok := s.courts[court.ID]
+	if !ok || stored.DeletedAt != nil {
+		return fmt.Errorf("court not found")
+	}
+
+	stored.Name = court.Name
+	stored.Description = court.Description
+	stored.PricePerHour = court.PricePerHour
+	stored.MaxPlayers = court.MaxPlayers
+	stored.Status = court.Status
+	stored.UpdatedAt = court.UpdatedAt
+	s.courts[court.ID] = stored
+	return nil
+}
+
+func (s *Store) deleteCourt(id uuid.UUID) error {
+	court, ok := s.courts[id]
+	if !ok || court.DeletedAt != nil {
+		return fmt.Errorf("court not found")
+	}
+
+	now := time.Now()
+	court.DeletedAt = &now
+	court.UpdatedAt = now
+	s.courts[id] = court
+	return nil
+}
+
+// venueCourts lists a venue's courts that aren't deleted, in display order
+func (s *Store) venueCourts(venueID uuid.UUID) []models.Court {
+	courts := []models.Court{}
+	for _, court := range s.courts {
+		if court.VenueID == venueID && court.DeletedAt == nil {
+			courts = append(courts, court)
+		}
+	}
+
+	sort.Slice(courts, func(i, j int) bool {
+		if courts[i].DisplayOrder != courts[j].DisplayOrder {
+			return courts[i].DisplayOrder < courts[j].DisplayOrder
+		}
+		return courts[i].CreatedAt.Before(courts[j].CreatedAt)
+	})
+
+	return courts
+}
+
+func (s *Store) courtWithVenue(court models.Court) models.CourtWithVenue {
+	venue := s.venues[court.VenueID]
+	court.VenueName = venue.Name
+	court.VenueLocation = venue.Location
+	court.VenueStatus = venue.Status
+
+	return models.CourtWithVenue{
+		Court:         court,
+		VenueName:     venue.Name,
+		VenueLocation: venue.Location,
+		VenueStatus:   string(venue.Status),
+	}
+}
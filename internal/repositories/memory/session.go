@@ -0,0 +1,716 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type sessionRepository struct {
+	store *Store
+}
+
+func NewSessionRepository(store *Store) interfaces.SessionRepository {
+	return &sessionRepository{store: store}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.sessions[session.ID]; exists {
+		return fmt.Errorf("session %s already exists", session.ID)
+	}
+
+	r.store.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *sessionRepository) CreateWithHost(ctx context.Context, session *models.Session, host *models.SessionParticipant, courtIDs []uuid.UUID, rules []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.sessions[session.ID]; exists {
+		return fmt.Errorf("failed to create session: session %s already exists", session.ID)
+	}
+	for _, courtID := range courtIDs {
+		if _, ok := r.store.courts[courtID]; !ok {
+			return fmt.Errorf("failed to reserve court %s: court not found", courtID)
+		}
+	}
+
+	r.store.sessions[session.ID] = *session
+	r.store.sessionCourts[session.ID] = append([]uuid.UUID(nil), courtIDs...)
+	for _, rule := range rules {
+		r.store.sessionRules = append(r.store.sessionRules, models.SessionRule{
+			ID:        uuid.New(),
+			SessionID: session.ID,
+			RuleText:  rule,
+			CreatedAt: session.CreatedAt,
+		})
+	}
+	r.store.participants = append(r.store.participants, *host)
+
+	return nil
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	detail := r.detail(session)
+	detail.Participants = r.participants(id)
+	detail.Rules = []models.SessionRule{}
+	for _, rule := range r.store.sessionRules {
+		if rule.SessionID == id {
+			detail.Rules = append(detail.Rules, rule)
+		}
+	}
+	detail.Courts = []models.Court{}
+	for _, courtID := range r.store.sessionCourts[id] {
+		if court, ok := r.store.courts[courtID]; ok {
+			detail.Courts = append(detail.Courts, court)
+		}
+	}
+	sort.Slice(detail.Courts, func(i, j int) bool {
+		if detail.Courts[i].DisplayOrder != detail.Courts[j].DisplayOrder {
+			return detail.Courts[i].DisplayOrder < detail.Courts[j].DisplayOrder
+		}
+		return detail.Courts[i].CreatedAt.Before(detail.Courts[j].CreatedAt)
+	})
+
+	return &detail, nil
+}
+
+func (r *sessionRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := []models.SessionDetail{}
+	for _, id := range ids {
+		if session, ok := r.store.sessions[id]; ok {
+			sessions = append(sessions, r.detail(session))
+		}
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session *models.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.sessions[session.ID]
+	if !ok {
+		return fmt.Errorf("session not found")
+	}
+
+	// host_id, venue_id, check_in_code and created_at aren't updatable
+	updated := *session
+	updated.HostID = stored.HostID
+	updated.VenueID = stored.VenueID
+	updated.CheckInCode = stored.CheckInCode
+	updated.CreatedAt = stored.CreatedAt
+	r.store.sessions[session.ID] = updated
+	return nil
+}
+
+func (r *sessionRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := r.selectSessions(func(s models.Session) bool {
+		return r.matchFilters(s, filters)
+	}, false)
+
+	start, end := page(len(sessions), limit, offset)
+	return sessions[start:end], nil
+}
+
+// Search matches the query as a case-insensitive substring, the same fields the
+// ILIKE fallback in the Postgres search covers. There is no ranking.
+func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	query := strings.ToLower(searchQuery)
+	sessions := r.selectSessions(func(s models.Session) bool {
+		venue := r.store.venues[s.VenueID]
+		host := r.store.users[s.HostID]
+		description := ""
+		if s.Description != nil {
+			description = *s.Description
+		}
+
+		found := false
+		for _, field := range []string{s.Title, description, venue.Name, venue.Location, host.FirstName, host.LastName} {
+			if strings.Contains(strings.ToLower(field), query) {
+				found = true
+				break
+			}
+		}
+
+		return found && r.matchFilters(s, filters)
+	}, false)
+
+	start, end := page(len(sessions), limit, offset)
+	return sessions[start:end], nil
+}
+
+// matchFilters applies the filters understood by the Postgres List
+func (r *sessionRepository) matchFilters(s models.Session, filters map[string]interface{}) bool {
+	for key, value := range filters {
+		switch key {
+		case "date":
+			if dayOf(s.SessionDate) != dateFilter(value) {
+				return false
+			}
+		case "from_date":
+			if dayOf(s.SessionDate) < dateFilter(value) {
+				return false
+			}
+		case "location":
+			if r.store.venues[s.VenueID].Location != fmt.Sprint(value) {
+				return false
+			}
+		case "player_level":
+			if string(s.PlayerLevel) != fmt.Sprint(value) {
+				return false
+			}
+		case "status":
+			if string(s.Status) != fmt.Sprint(value) {
+				return false
+			}
+		case "is_public":
+			if isPublic, ok := value.(bool); ok && s.IsPublic != isPublic {
+				return false
+			}
+		case "host_id":
+			if hostID, ok := value.(uuid.UUID); ok && s.HostID != hostID {
+				return false
+			}
+		case "participant_id":
+			if userID, ok := value.(uuid.UUID); ok && !r.isActiveParticipant(s.ID, userID) {
+				return false
+			}
+		case "exclude_user_id":
+			if userID, ok := value.(uuid.UUID); ok && (s.HostID == userID || r.isActiveParticipant(s.ID, userID)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// dateFilter accepts the time.Time or "2006-01-02" strings the usecases pass
+func dateFilter(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return dayOf(t)
+	}
+	return fmt.Sprint(value)
+}
+
+func (r *sessionRepository) isActiveParticipant(sessionID, userID uuid.UUID) bool {
+	for _, p := range r.store.participants {
+		if p.SessionID == sessionID && p.UserID == userID && p.Status != models.ParticipantStatusCancelled {
+			return true
+		}
+	}
+	return false
+}
+
+// AddParticipant checks for an existing row and inserts under one lock, like
+// the ON CONFLICT (session_id, user_id) DO NOTHING insert
+func (r *sessionRepository) AddParticipant(ctx context.Context, participant *models.SessionParticipant) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[participant.SessionID]
+	if !ok {
+		return false, fmt.Errorf("session not found")
+	}
+	confirmed := 0
+	for _, p := range r.store.participants {
+		if p.SessionID != participant.SessionID {
+			continue
+		}
+		if p.UserID == participant.UserID {
+			return false, nil
+		}
+		if p.Status == models.ParticipantStatusConfirmed {
+			confirmed++
+		}
+	}
+
+	if participant.Status == models.ParticipantStatusConfirmed {
+		if confirmed >= session.MaxParticipants {
+			return false, interfaces.ErrSessionFull
+		}
+		if confirmed+1 >= session.MaxParticipants && session.Status == models.SessionStatusOpen {
+			session.Status = models.SessionStatusFull
+			r.store.sessions[session.ID] = session
+		}
+	}
+
+	r.store.participants = append(r.store.participants, *participant)
+	r.touch(participant.SessionID)
+	return true, nil
+}
+
+func (r *sessionRepository) AddConfirmedParticipants(ctx context.Context, sessionID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[sessionID]
+	if !ok {
+		return nil, false, fmt.Errorf("session not found")
+	}
+
+	existing := map[uuid.UUID]bool{}
+	confirmed := 0
+	for _, p := range r.store.participants {
+		if p.SessionID != sessionID {
+			continue
+		}
+		existing[p.UserID] = true
+		if p.Status == models.ParticipantStatusConfirmed {
+			confirmed++
+		}
+	}
+
+	added := []uuid.UUID{}
+	for _, userID := range userIDs {
+		if !existing[userID] {
+			existing[userID] = true
+			added = append(added, userID)
+		}
+	}
+	if confirmed+len(added) > session.MaxParticipants {
+		return nil, false, nil
+	}
+
+	now := time.Now()
+	for _, userID := range added {
+		r.store.participants = append(r.store.participants, models.SessionParticipant{
+			ID:        uuid.New(),
+			SessionID: sessionID,
+			UserID:    userID,
+			Status:    models.ParticipantStatusConfirmed,
+			JoinedAt:  now,
+		})
+	}
+
+	if confirmed+len(added) >= session.MaxParticipants && session.Status == models.SessionStatusOpen {
+		session.Status = models.SessionStatusFull
+	}
+	session.UpdatedAt = now
+	r.store.sessions[sessionID] = session
+	return added, true, nil
+}
+
+func (r *sessionRepository) UpdateParticipantStatus(ctx context.Context, sessionID, userID uuid.UUID, status models.ParticipantStatus) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for i, p := range r.store.participants {
+		if p.SessionID != sessionID || p.UserID != userID {
+			continue
+		}
+		p.Status = status
+		switch status {
+		case models.ParticipantStatusConfirmed:
+			p.JoinedAt = now
+		case models.ParticipantStatusCancelled:
+			p.CancelledAt = &now
+		}
+		r.store.participants[i] = p
+		r.touch(sessionID)
+		return nil
+	}
+
+	return fmt.Errorf("participant not found")
+}
+
+func (r *sessionRepository) PromoteWaitlisted(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[sessionID]
+	if !ok {
+		return uuid.Nil, false, ErrNotFound
+	}
+
+	confirmed, next := 0, -1
+	for i, p := range r.store.participants {
+		if p.SessionID != sessionID {
+			continue
+		}
+		switch p.Status {
+		case models.ParticipantStatusConfirmed:
+			confirmed++
+		case models.ParticipantStatusPending:
+			if next < 0 || p.JoinedAt.Before(r.store.participants[next].JoinedAt) {
+				next = i
+			}
+		}
+	}
+
+	promoted := uuid.Nil
+	if confirmed < session.MaxParticipants && next >= 0 {
+		p := r.store.participants[next]
+		p.Status = models.ParticipantStatusConfirmed
+		p.JoinedAt = time.Now()
+		r.store.participants[next] = p
+		promoted = p.UserID
+		confirmed++
+	}
+
+	if session.Status == models.SessionStatusOpen || session.Status == models.SessionStatusFull {
+		session.Status = models.SessionStatusOpen
+		if confirmed >= session.MaxParticipants {
+			session.Status = models.SessionStatusFull
+		}
+	}
+	session.UpdatedAt = time.Now()
+	r.store.sessions[sessionID] = session
+
+	return promoted, promoted != uuid.Nil, nil
+}
+
+func (r *sessionRepository) CheckInParticipant(ctx context.Context, sessionID, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, p := range r.store.participants {
+		if p.SessionID != sessionID || p.UserID != userID || p.Status != models.ParticipantStatusConfirmed {
+			continue
+		}
+		if p.CheckedInAt == nil {
+			now := time.Now()
+			r.store.participants[i].CheckedInAt = &now
+		}
+		r.touch(sessionID)
+		return nil
+	}
+
+	return fmt.Errorf("participant not found")
+}
+
+// touch bumps updated_at when the participant list changes
+func (r *sessionRepository) CompleteSession(ctx context.Context, sessionID uuid.UUID, attended []uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	session, ok := r.store.sessions[sessionID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	present := make(map[uuid.UUID]bool, len(attended))
+	for _, id := range attended {
+		present[id] = true
+	}
+
+	for i, p := range r.store.participants {
+		if p.SessionID != sessionID || p.Status != models.ParticipantStatusConfirmed {
+			continue
+		}
+		if p.UserID == session.HostID || p.CheckedInAt != nil || present[p.UserID] {
+			continue
+		}
+		r.store.participants[i].Status = models.ParticipantStatusNoShow
+	}
+
+	session.Status = models.SessionStatusCompleted
+	session.UpdatedAt = time.Now()
+	r.store.sessions[sessionID] = session
+	return nil
+}
+
+func (r *sessionRepository) touch(sessionID uuid.UUID) {
+	session := r.store.sessions[sessionID]
+	session.UpdatedAt = time.Now()
+	r.store.sessions[sessionID] = session
+}
+
+func (r *sessionRepository) GetUserOverlappingSessions(ctx context.Context, userID uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) ([]models.Session, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := []models.Session{}
+	for _, detail := range r.selectSessions(func(s models.Session) bool {
+		if s.ID == excludeSessionID || dayOf(s.SessionDate) != dayOf(sessionDate) || !isActiveSession(s) {
+			return false
+		}
+		if clockOf(s.StartTime) >= clockOf(endTime) || clockOf(s.EndTime) <= clockOf(startTime) {
+			return false
+		}
+		if s.HostID == userID {
+			return true
+		}
+		for _, p := range r.store.participants {
+			if p.SessionID == s.ID && p.UserID == userID &&
+				(p.Status == models.ParticipantStatusPending || p.Status == models.ParticipantStatusConfirmed) {
+				return true
+			}
+		}
+		return false
+	}, false) {
+		sessions = append(sessions, detail.Session)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) GetUpcomingVenueSessions(ctx context.Context, venueID uuid.UUID, fromDate time.Time) ([]models.Session, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := []models.Session{}
+	for _, detail := range r.selectSessions(func(s models.Session) bool {
+		return s.VenueID == venueID &&
+			dayOf(s.SessionDate) >= dayOf(fromDate) &&
+			(s.Status == models.SessionStatusOpen || s.Status == models.SessionStatusFull)
+	}, false) {
+		sessions = append(sessions, detail.Session)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) GetUpcomingCourtSessions(ctx context.Context, courtID uuid.UUID, fromDate time.Time) ([]models.Session, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	sessions := []models.Session{}
+	for _, detail := range r.selectSessions(func(s models.Session) bool {
+		if dayOf(s.SessionDate) < dayOf(fromDate) ||
+			(s.Status != models.SessionStatusOpen && s.Status != models.SessionStatusFull) {
+			return false
+		}
+		for _, id := range r.store.sessionCourts[s.ID] {
+			if id == courtID {
+				return true
+			}
+		}
+		return false
+	}, false) {
+		sessions = append(sessions, detail.Session)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) ReplaceSessionCourt(ctx context.Context, sessionID, oldCourtID, newCourtID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i, id := range r.store.sessionCourts[sessionID] {
+		if id == oldCourtID {
+			r.store.sessionCourts[sessionID][i] = newCourtID
+			return nil
+		}
+	}
+
+	return fmt.Errorf("session court not found")
+}
+
+func (r *sessionRepository) GetVenueSessionCourts(ctx context.Context, venueID uuid.UUID, date time.Time) ([]models.SessionCourt, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	courts := []models.SessionCourt{}
+	for _, detail := range r.selectSessions(func(s models.Session) bool {
+		return s.VenueID == venueID && dayOf(s.SessionDate) == dayOf(date) && isActiveSession(s)
+	}, false) {
+		for _, courtID := range r.store.sessionCourts[detail.ID] {
+			courts = append(courts, models.SessionCourt{
+				SessionID: detail.ID,
+				CourtID:   courtID,
+				Title:     detail.Title,
+				StartTime: detail.StartTime,
+				EndTime:   detail.EndTime,
+			})
+		}
+	}
+
+	return courts, nil
+}
+
+func (r *sessionRepository) GetConflictingSessionCourts(ctx context.Context, courtIDs []uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) ([]models.SessionCourt, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	wanted := make(map[uuid.UUID]bool, len(courtIDs))
+	for _, id := range courtIDs {
+		wanted[id] = true
+	}
+
+	courts := []models.SessionCourt{}
+	for _, detail := range r.selectSessions(func(s models.Session) bool {
+		return s.ID != excludeSessionID && dayOf(s.SessionDate) == dayOf(sessionDate) && isActiveSession(s) &&
+			clockOf(s.StartTime) < clockOf(endTime) && clockOf(s.EndTime) > clockOf(startTime)
+	}, false) {
+		for _, courtID := range r.store.sessionCourts[detail.ID] {
+			if wanted[courtID] {
+				courts = append(courts, models.SessionCourt{
+					SessionID: detail.ID,
+					CourtID:   courtID,
+					Title:     detail.Title,
+					StartTime: detail.StartTime,
+					EndTime:   detail.EndTime,
+				})
+			}
+		}
+	}
+
+	return courts, nil
+}
+
+func (r *sessionRepository) GetCourtsHourlyRate(ctx context.Context, sessionID uuid.UUID) (float64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var rate float64
+	for _, courtID := range r.store.sessionCourts[sessionID] {
+		rate += r.store.courts[courtID].PricePerHour
+	}
+
+	return rate, nil
+}
+
+func (r *sessionRepository) GetParticipants(ctx context.Context, sessionID uuid.UUID) ([]models.SessionParticipant, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.participants(sessionID), nil
+}
+
+func (r *sessionRepository) GetUserSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.userSessions(includeHistory, func(s models.Session) bool {
+		return s.HostID == userID || r.hasParticipant(s.ID, userID)
+	}), nil
+}
+
+func (r *sessionRepository) GetMyJoinedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.userSessions(includeHistory, func(s models.Session) bool {
+		return r.hasParticipant(s.ID, userID)
+	}), nil
+}
+
+func (r *sessionRepository) GetMyHostedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.userSessions(includeHistory, func(s models.Session) bool {
+		return s.HostID == userID
+	}), nil
+}
+
+// userSessions lists a user's sessions newest first, from today on unless includeHistory is set
+func (r *sessionRepository) userSessions(includeHistory bool, match func(models.Session) bool) []models.SessionDetail {
+	today := dayOf(time.Now())
+	return r.selectSessions(func(s models.Session) bool {
+		return (includeHistory || dayOf(s.SessionDate) >= today) && match(s)
+	}, true)
+}
+
+func (r *sessionRepository) hasParticipant(sessionID, userID uuid.UUID) bool {
+	for _, p := range r.store.participants {
+		if p.SessionID == sessionID && p.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// participants lists a session's participants in the order they joined
+func (r *sessionRepository) participants(sessionID uuid.UUID) []models.SessionParticipant {
+	participants := []models.SessionParticipant{}
+	for _, p := range r.store.participants {
+		if p.SessionID == sessionID {
+			p.UserName = r.store.userName(p.UserID)
+			participants = append(participants, p)
+		}
+	}
+
+	sort.SliceStable(participants, func(i, j int) bool {
+		return participants[i].JoinedAt.Before(participants[j].JoinedAt)
+	})
+
+	return participants
+}
+
+// selectSessions returns the matching sessions with their joined fields, ordered by date and start time
+func (r *sessionRepository) selectSessions(match func(models.Session) bool, descending bool) []models.SessionDetail {
+	sessions := []models.SessionDetail{}
+	for _, s := range r.store.sessions {
+		if match(s) {
+			sessions = append(sessions, r.detail(s))
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		if dayOf(a.SessionDate) != dayOf(b.SessionDate) {
+			return (dayOf(a.SessionDate) < dayOf(b.SessionDate)) != descending
+		}
+		return (clockOf(a.StartTime) < clockOf(b.StartTime)) != descending
+	})
+
+	return sessions
+}
+
+// detail fills in the venue, host and player count fields the SQL joins would.
+// Participants and rules are only loaded by GetByID.
+func (r *sessionRepository) detail(s models.Session) models.SessionDetail {
+	venue := r.store.venues[s.VenueID]
+	host := r.store.users[s.HostID]
+
+	detail := models.SessionDetail{
+		Session:       s,
+		VenueName:     venue.Name,
+		VenueLocation: venue.Location,
+		VenueTimezone: venue.Timezone,
+		HostName:      host.FirstName + " " + host.LastName,
+		HostGender:    host.Gender,
+		HostLevel:     host.PlayLevel,
+		IsPublic:      s.IsPublic,
+	}
+	for _, p := range r.store.participants {
+		if p.SessionID != s.ID {
+			continue
+		}
+		switch p.Status {
+		case models.ParticipantStatusConfirmed:
+			detail.ConfirmedPlayers++
+		case models.ParticipantStatusPending:
+			detail.PendingPlayers++
+		}
+	}
+
+	return detail
+}
+
+func isActiveSession(s models.Session) bool {
+	return s.Status != models.SessionStatusCancelled && s.Status != models.SessionStatusCompleted
+}
@@ -0,0 +1,136 @@
+// Package memory implements the repository interfaces on top of in-process maps,
+// so usecases can be exercised without Postgres.
+//
+// Every repository built from the same Store shares its tables and its lock.
+// A repository call holds the lock from start to finish, which gives each call
+// the same all-or-nothing behaviour as a single Postgres transaction.
+package memory
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+// Store holds the rows shared by the in-memory repositories
+type Store struct {
+	mu sync.Mutex
+
+	users    map[uuid.UUID]models.User
+	venues   map[uuid.UUID]models.Venue
+	courts   map[uuid.UUID]models.Court
+	bookings map[uuid.UUID]models.CourtBooking
+	payments map[uuid.UUID]models.Payment // Keyed by booking ID
+
+	sessions      map[uuid.UUID]models.Session
+	participants  []models.SessionParticipant
+	sessionCourts map[uuid.UUID][]uuid.UUID // Session ID to reserved court IDs
+	sessionRules  []models.SessionRule
+
+	chats            map[uuid.UUID]models.Chat
+	chatParticipants []models.ChatParticipant
+	messages         []models.Message // In the order they were saved
+
+	reviews         []models.VenueReview
+	reviewVotes     map[uuid.UUID]map[uuid.UUID]bool // Review ID to the users who voted it helpful
+	facilities      map[uuid.UUID]models.Facility
+	venueFacilities map[uuid.UUID][]uuid.UUID
+	venueTags       map[uuid.UUID][]string
+	venueClaims     map[uuid.UUID]models.VenueClaim
+	favoriteVenues  map[uuid.UUID]map[uuid.UUID]time.Time // User ID to their favorite venues and when they were added
+
+	emailVerifications map[string]emailVerification // Keyed by token hash
+}
+
+type emailVerification struct {
+	userID    uuid.UUID
+	expiresAt time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:           make(map[uuid.UUID]models.User),
+		venues:          make(map[uuid.UUID]models.Venue),
+		courts:          make(map[uuid.UUID]models.Court),
+		bookings:        make(map[uuid.UUID]models.CourtBooking),
+		payments:        make(map[uuid.UUID]models.Payment),
+		sessions:        make(map[uuid.UUID]models.Session),
+		sessionCourts:   make(map[uuid.UUID][]uuid.UUID),
+		chats:           make(map[uuid.UUID]models.Chat),
+		facilities:      make(map[uuid.UUID]models.Facility),
+		venueFacilities: make(map[uuid.UUID][]uuid.UUID),
+		venueTags:       make(map[uuid.UUID][]string),
+		reviewVotes:     make(map[uuid.UUID]map[uuid.UUID]bool),
+		venueClaims:     make(map[uuid.UUID]models.VenueClaim),
+		favoriteVenues:  make(map[uuid.UUID]map[uuid.UUID]time.Time),
+
+		emailVerifications: make(map[string]emailVerification),
+	}
+}
+
+// PutUser inserts or replaces a user row
+func (s *Store) PutUser(user models.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+}
+
+// PutVenue inserts or replaces a venue row. Courts on the venue are ignored,
+// add them with PutCourt.
+func (s *Store) PutVenue(venue models.Venue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	venue.Courts = nil
+	s.venues[venue.ID] = venue
+}
+
+// PutCourt inserts or replaces a court row
+func (s *Store) PutCourt(court models.Court) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.courts[court.ID] = court
+}
+
+// PutFacility inserts or replaces a row of the facilities catalogue
+func (s *Store) PutFacility(facility models.Facility) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.facilities[facility.ID] = facility
+}
+
+// ErrNotFound is returned where the Postgres repositories would return sql.ErrNoRows
+var ErrNotFound = sql.ErrNoRows
+
+// userName mirrors the first_name || ' ' || last_name joins
+func (s *Store) userName(userID uuid.UUID) string {
+	user := s.users[userID]
+	return user.FirstName + " " + user.LastName
+}
+
+// dayOf and clockOf compare times the way the date and time columns store them
+func dayOf(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func clockOf(t time.Time) string {
+	return t.Format("15:04:05")
+}
+
+// page applies LIMIT/OFFSET to n rows, returning the slice bounds
+func page(n, limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if limit > 0 && offset+limit < n {
+		end = offset + limit
+	}
+	return offset, end
+}
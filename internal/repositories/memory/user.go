@@ -0,0 +1,369 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrDuplicateEmail = errors.New("email already exists")
+)
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) interfaces.UserRepository {
+	return &userRepository{store: store}
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.users {
+		if existing.Email == user.Email {
+			return ErrDuplicateEmail
+		}
+	}
+
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+
+	now := time.Now()
+	user.CreatedAt = now
+	user.LastActiveAt = now
+
+	if user.Status == "" {
+		user.Status = models.UserStatusActive
+	}
+
+	r.store.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, ok := r.activeUser(id)
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	users := []models.User{}
+	for _, id := range ids {
+		if user, ok := r.activeUser(id); ok {
+			user.Password = ""
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, user := range r.store.users {
+		if user.Email == email && user.Status != models.UserStatusInactive {
+			return &user, nil
+		}
+	}
+
+	return nil, ErrUserNotFound
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.activeUser(user.ID)
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	stored.FirstName = user.FirstName
+	stored.LastName = user.LastName
+	stored.Phone = user.Phone
+	stored.PlayLevel = user.PlayLevel
+	stored.Location = user.Location
+	stored.Bio = user.Bio
+	stored.AvatarURL = user.AvatarURL
+	stored.Role = user.Role
+	r.store.users[user.ID] = stored
+	return nil
+}
+
+// GetProfile counts hosted, joined and no-show sessions from the session
+// tables. Player reviews aren't stored, so ratings and regular partners stay zero.
+func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*models.UserProfile, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, ok := r.activeUser(userID)
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	profile := &models.UserProfile{User: user}
+	for _, session := range r.store.sessions {
+		if session.HostID == userID && session.Status != models.SessionStatusCancelled {
+			profile.HostedSessions++
+		}
+	}
+	for _, p := range r.store.participants {
+		if p.UserID != userID {
+			continue
+		}
+		session := r.store.sessions[p.SessionID]
+		if session.Status != models.SessionStatusCancelled && session.HostID != userID {
+			profile.JoinedSessions++
+		}
+		if p.Status == models.ParticipantStatusNoShow {
+			profile.NoShowSessions++
+		}
+	}
+
+	return profile, nil
+}
+
+// GetLeaderboard ranks users by the same hosted count as GetProfile, but only
+// counts the sessions a user joined once they've played them. Player reviews
+// aren't stored, so nobody is ranked by rating.
+func (r *userRepository) GetLeaderboard(ctx context.Context, filters interfaces.LeaderboardFilters) ([]models.LeaderboardEntry, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	entries := r.leaderboard(filters)
+	start, end := page(len(entries), filters.Limit, filters.Offset)
+	return entries[start:end], nil
+}
+
+func (r *userRepository) CountLeaderboard(ctx context.Context, filters interfaces.LeaderboardFilters) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return len(r.leaderboard(filters)), nil
+}
+
+func (r *userRepository) leaderboard(filters interfaces.LeaderboardFilters) []models.LeaderboardEntry {
+	hosted := map[uuid.UUID]int{}
+	for _, session := range r.store.sessions {
+		if session.Status != models.SessionStatusCancelled {
+			hosted[session.HostID]++
+		}
+	}
+	// Joined sessions count once the player was confirmed and the session is
+	// completed or past its end time in the venue's zone
+	now := time.Now()
+	joined := map[uuid.UUID]int{}
+	for _, p := range r.store.participants {
+		session := r.store.sessions[p.SessionID]
+		if p.Status != models.ParticipantStatusConfirmed || session.HostID == p.UserID {
+			continue
+		}
+		zone := models.LoadTimezone(r.store.venues[session.VenueID].Timezone)
+		end := time.Date(session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
+			session.EndTime.Hour(), session.EndTime.Minute(), 0, 0, zone)
+		if session.Status == models.SessionStatusCompleted || (session.Status != models.SessionStatusCancelled && end.Before(now)) {
+			joined[p.UserID]++
+		}
+	}
+
+	entries := []models.LeaderboardEntry{}
+	metrics := map[uuid.UUID]int{}
+	for _, user := range r.store.users {
+		if user.Status == models.UserStatusInactive {
+			continue
+		}
+		if filters.Location != "" && user.Location != filters.Location {
+			continue
+		}
+
+		var metric int
+		switch filters.Metric {
+		case models.LeaderboardMetricSessionsPlayed:
+			metric = hosted[user.ID] + joined[user.ID]
+		case models.LeaderboardMetricHosted:
+			metric = hosted[user.ID]
+		}
+		if metric == 0 {
+			continue
+		}
+
+		metrics[user.ID] = metric
+		entries = append(entries, models.LeaderboardEntry{
+			ID:             user.ID,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+			AvatarURL:      user.AvatarURL,
+			Location:       user.Location,
+			PlayLevel:      user.PlayLevel,
+			HostedSessions: hosted[user.ID],
+			JoinedSessions: joined[user.ID],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if metrics[entries[i].ID] != metrics[entries[j].ID] {
+			return metrics[entries[i].ID] > metrics[entries[j].ID]
+		}
+		return r.store.users[entries[i].ID].CreatedAt.Before(r.store.users[entries[j].ID].CreatedAt)
+	})
+
+	return entries
+}
+
+func (r *userRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, ok := r.activeUser(userID)
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	user.LastActiveAt = time.Now()
+	r.store.users[userID] = user
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	user, ok := r.store.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	user.Password = passwordHash
+	r.store.users[userID] = user
+	return nil
+}
+
+// SearchUsers matches the query as a case-insensitive substring of the name,
+// email or location instead of the full text search. Recently active users
+// come first, then the newest.
+func (r *userRepository) SearchUsers(ctx context.Context, query string, filters interfaces.UserSearchFilters) ([]models.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	query = strings.ToLower(query)
+	users := []models.User{}
+	for _, user := range r.store.users {
+		if user.Status == models.UserStatusInactive {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(strings.Join([]string{user.FirstName, user.LastName, user.Email, user.Location}, " ")), query) {
+			continue
+		}
+		if filters.PlayLevel != "" && user.PlayLevel != filters.PlayLevel {
+			continue
+		}
+		if filters.Location != "" && user.Location != filters.Location {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	recent := time.Now().AddDate(0, 0, -7)
+	sort.Slice(users, func(i, j int) bool {
+		iRecent, jRecent := users[i].LastActiveAt.After(recent), users[j].LastActiveAt.After(recent)
+		if iRecent != jRecent {
+			return iRecent
+		}
+		return users[i].CreatedAt.After(users[j].CreatedAt)
+	})
+
+	start, end := page(len(users), filters.Limit, filters.Offset)
+	return users[start:end], nil
+}
+
+func (r *userRepository) GetVenueUserOwn(ctx context.Context, userID uuid.UUID) ([]models.VenueUserOwn, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venues := []models.VenueUserOwn{}
+	for _, venue := range r.store.venues {
+		if venue.OwnerID == userID {
+			venues = append(venues, models.VenueUserOwn{ID: venue.ID.String()})
+		}
+	}
+
+	return venues, nil
+}
+
+func (r *userRepository) IsUserExist(ctx context.Context, userID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	_, ok := r.store.users[userID]
+	return ok, nil
+}
+
+func (r *userRepository) activeUser(id uuid.UUID) (models.User, bool) {
+	user, ok := r.store.users[id]
+	if !ok || user.Status == models.UserStatusInactive {
+		return models.User{}, false
+	}
+	return user, true
+}
+
+func (r *userRepository) CreateEmailVerification(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.users[userID]; !ok {
+		return ErrUserNotFound
+	}
+
+	for hash, v := range r.store.emailVerifications {
+		if v.userID == userID {
+			delete(r.store.emailVerifications, hash)
+		}
+	}
+	r.store.emailVerifications[tokenHash] = emailVerification{userID: userID, expiresAt: expiresAt}
+	return nil
+}
+
+func (r *userRepository) VerifyEmail(ctx context.Context, tokenHash string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	verification, ok := r.store.emailVerifications[tokenHash]
+	if !ok || !verification.expiresAt.After(time.Now()) {
+		return false, nil
+	}
+
+	if user, ok := r.store.users[verification.userID]; ok {
+		user.EmailVerified = true
+		r.store.users[user.ID] = user
+	}
+	for hash, v := range r.store.emailVerifications {
+		if v.userID == verification.userID {
+			delete(r.store.emailVerifications, hash)
+		}
+	}
+
+	return true, nil
+}
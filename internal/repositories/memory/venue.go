@@ -0,0 +1,843 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+// ErrDuplicateReview and ErrReviewTooSoon are the interfaces errors AddReview returns
+var (
+	ErrDuplicateReview = interfaces.ErrDuplicateReview
+	ErrReviewTooSoon   = interfaces.ErrReviewTooSoon
+)
+
+// noPriceFilter disables the price bounds of Search and CountSearch
+const noPriceFilter = -99
+
+type venueRepository struct {
+	store *Store
+}
+
+func NewVenueRepository(store *Store) interfaces.VenueRepository {
+	return &venueRepository{store: store}
+}
+
+// Create assigns the venue a new ID, like the safe_generate_uuid() default
+func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.venues {
+		if existing.DeletedAt == nil &&
+			strings.EqualFold(existing.Name, venue.Name) &&
+			strings.EqualFold(existing.Location, venue.Location) {
+			return fmt.Errorf("venue with name '%s' already exists in %s", venue.Name, venue.Location)
+		}
+	}
+
+	venue.ID = uuid.New()
+	stored := *venue
+	stored.Courts = nil
+	stored.Facilities = nil
+	stored.Tags = nil
+	r.store.venues[venue.ID] = stored
+	return nil
+}
+
+func (r *venueRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.VenueWithCourts, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venue, ok := r.store.venues[id]
+	if !ok || venue.DeletedAt != nil {
+		return nil, fmt.Errorf("venue not found")
+	}
+
+	venue.Facilities = r.facilities(id)
+	venue.Tags = r.tags(id)
+
+	return &models.VenueWithCourts{
+		Venue:  venue,
+		Courts: r.store.venueCourts(id),
+	}, nil
+}
+
+func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.venues[venue.ID]
+	if !ok || stored.DeletedAt != nil {
+		return fmt.Errorf("venue not found")
+	}
+
+	stored.Name = venue.Name
+	stored.Description = venue.Description
+	stored.Address = venue.Address
+	stored.Location = venue.Location
+	stored.Phone = venue.Phone
+	stored.Email = venue.Email
+	stored.OpenRange = venue.OpenRange
+	stored.ImageURLs = venue.ImageURLs
+	stored.Status = venue.Status
+	stored.UpdatedAt = venue.UpdatedAt
+	stored.Rules = venue.Rules
+	stored.Latitude = venue.Latitude
+	stored.Longitude = venue.Longitude
+	stored.Timezone = venue.Timezone
+	r.store.venues[venue.ID] = stored
+	return nil
+}
+
+func (r *venueRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venue, ok := r.store.venues[id]
+	if !ok || venue.DeletedAt != nil {
+		return fmt.Errorf("venue not found")
+	}
+
+	now := time.Now()
+	venue.DeletedAt = &now
+	venue.UpdatedAt = now
+	r.store.venues[id] = venue
+	return nil
+}
+
+func (r *venueRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venue, ok := r.store.venues[id]
+	if !ok {
+		return nil, fmt.Errorf("venue not found")
+	}
+
+	return &venue, nil
+}
+
+func (r *venueRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venue, ok := r.store.venues[id]
+	if !ok || venue.DeletedAt == nil {
+		return fmt.Errorf("deleted venue not found")
+	}
+
+	venue.DeletedAt = nil
+	venue.UpdatedAt = time.Now()
+	r.store.venues[id] = venue
+	return nil
+}
+
+func (r *venueRepository) SetFeatured(ctx context.Context, id uuid.UUID, featured bool, until *time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venue, ok := r.store.venues[id]
+	if !ok || venue.DeletedAt != nil {
+		return fmt.Errorf("venue not found")
+	}
+
+	if !featured {
+		until = nil
+	}
+
+	venue.Featured = featured
+	venue.FeaturedUntil = until
+	venue.UpdatedAt = time.Now()
+	r.store.venues[id] = venue
+	return nil
+}
+
+func (r *venueRepository) ListFeatured(ctx context.Context, limit, offset int) ([]models.Venue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	venues := r.selectVenues(func(v models.Venue) bool {
+		return v.Status == models.VenueStatusActive && v.IsFeatured(now)
+	})
+
+	sort.SliceStable(venues, func(i, j int) bool {
+		return byRating(venues[i], venues[j])
+	})
+
+	start, end := page(len(venues), limit, offset)
+	return venues[start:end], nil
+}
+
+// List puts currently featured venues first, then the best rated
+func (r *venueRepository) List(ctx context.Context, filters interfaces.VenueListFilters, limit, offset int) ([]models.Venue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	venues := r.selectVenues(func(v models.Venue) bool {
+		return (filters.Location == "" || v.Location == filters.Location) &&
+			(filters.Status == "" || v.Status == filters.Status) &&
+			(filters.OwnerID == uuid.Nil || v.OwnerID == filters.OwnerID) &&
+			r.matchTags(v.ID, filters.Tags)
+	})
+	for i := range venues {
+		venues[i].Featured = venues[i].IsFeatured(now)
+	}
+
+	sort.SliceStable(venues, func(i, j int) bool {
+		if venues[i].Featured != venues[j].Featured {
+			return venues[i].Featured
+		}
+		return byRating(venues[i], venues[j])
+	})
+
+	start, end := page(len(venues), limit, offset)
+	return venues[start:end], nil
+}
+
+func (r *venueRepository) CountVenues(ctx context.Context) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	count := 0
+	for _, venue := range r.store.venues {
+		if venue.DeletedAt == nil {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// Search matches the query against the name only, as the ILIKE fallback does.
+// Every match gets the same relevance, so results are ordered by rating.
+func (r *venueRepository) Search(ctx context.Context, query string, limit, offset int, minPrice, maxPrice int, location string, facilities []string, tagFilter interfaces.VenueTagFilter) ([]models.Venue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	venues := r.search(query, minPrice, maxPrice, location, facilities, tagFilter)
+	sort.SliceStable(venues, func(i, j int) bool {
+		return byRating(venues[i], venues[j])
+	})
+
+	start, end := page(len(venues), limit, offset)
+	return venues[start:end], nil
+}
+
+func (r *venueRepository) CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string, tagFilter interfaces.VenueTagFilter) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return len(r.search(query, minPrice, maxPrice, location, facilities, tagFilter)), nil
+}
+
+func (r *venueRepository) search(query string, minPrice, maxPrice int, location string, facilities []string, tagFilter interfaces.VenueTagFilter) []models.Venue {
+	query = strings.ToLower(query)
+	return r.selectVenues(func(v models.Venue) bool {
+		if !strings.Contains(strings.ToLower(v.Name), query) {
+			return false
+		}
+		if location != "" && v.Location != location {
+			return false
+		}
+		if minPrice != noPriceFilter && !r.hasCourt(v.ID, func(c models.Court) bool { return c.PricePerHour >= float64(minPrice) }) {
+			return false
+		}
+		if maxPrice != noPriceFilter && !r.hasCourt(v.ID, func(c models.Court) bool { return c.PricePerHour <= float64(maxPrice) }) {
+			return false
+		}
+		names := make(map[string]bool)
+		for _, facility := range r.facilities(v.ID) {
+			names[facility.Name] = true
+		}
+		for _, facility := range facilities {
+			if !names[facility] {
+				return false
+			}
+		}
+		return r.matchTags(v.ID, tagFilter)
+	})
+}
+
+func (r *venueRepository) hasCourt(venueID uuid.UUID, match func(models.Court) bool) bool {
+	for _, court := range r.store.courts {
+		if court.VenueID == venueID && match(court) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *venueRepository) AddCourt(ctx context.Context, court *models.Court) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.store.insertCourt(court); err != nil {
+		return fmt.Errorf("failed to add court: %w", err)
+	}
+	return nil
+}
+
+func (r *venueRepository) UpdateCourt(ctx context.Context, court *models.Court) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.updateCourt(court)
+}
+
+func (r *venueRepository) DeleteCourt(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.deleteCourt(id)
+}
+
+func (r *venueRepository) GetCourts(ctx context.Context, venueID uuid.UUID) ([]models.Court, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.venueCourts(venueID), nil
+}
+
+func (r *venueRepository) ReorderCourts(ctx context.Context, venueID uuid.UUID, courtIDs []uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for i, id := range courtIDs {
+		court, ok := r.store.courts[id]
+		if !ok || court.VenueID != venueID || court.DeletedAt != nil {
+			continue
+		}
+		court.DisplayOrder = i + 1
+		court.UpdatedAt = now
+		r.store.courts[id] = court
+	}
+	return nil
+}
+
+// AddReview stores the review and recomputes the venue rating under one lock
+func (r *venueRepository) AddReview(ctx context.Context, review *models.VenueReview, cooldown time.Duration) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.venues[review.VenueID]; !ok {
+		return fmt.Errorf("venue not found")
+	}
+	for _, existing := range r.store.reviews {
+		if existing.VenueID == review.VenueID && existing.UserID == review.UserID {
+			return ErrDuplicateReview
+		}
+	}
+	for _, existing := range r.store.reviews {
+		if existing.UserID == review.UserID && review.CreatedAt.Sub(existing.CreatedAt) < cooldown {
+			return ErrReviewTooSoon
+		}
+	}
+
+	r.store.reviews = append(r.store.reviews, *review)
+	return r.updateRating(review.VenueID)
+}
+
+func (r *venueRepository) HasUserReviewed(ctx context.Context, venueID, userID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, review := range r.store.reviews {
+		if review.VenueID == venueID && review.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *venueRepository) GetLastReviewAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var lastReviewAt *time.Time
+	for _, review := range r.store.reviews {
+		if review.UserID == userID && (lastReviewAt == nil || review.CreatedAt.After(*lastReviewAt)) {
+			createdAt := review.CreatedAt
+			lastReviewAt = &createdAt
+		}
+	}
+
+	return lastReviewAt, nil
+}
+
+func (r *venueRepository) GetReviews(ctx context.Context, venueID uuid.UUID, filters interfaces.ReviewListFilters, limit, offset int) ([]models.VenueReview, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	reviews := []models.VenueReview{}
+	for _, review := range r.store.reviews {
+		if review.VenueID != venueID ||
+			(filters.MinRating > 0 && review.Rating < filters.MinRating) ||
+			(filters.MaxRating > 0 && review.Rating > filters.MaxRating) {
+			continue
+		}
+		review.HelpfulCount = len(r.store.reviewVotes[review.ID])
+		reviews = append(reviews, review)
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool {
+		if filters.SortByHelpful && reviews[i].HelpfulCount != reviews[j].HelpfulCount {
+			return reviews[i].HelpfulCount > reviews[j].HelpfulCount
+		}
+		return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+	})
+
+	start, end := page(len(reviews), limit, offset)
+	return reviews[start:end], nil
+}
+
+func (r *venueRepository) GetRatingCounts(ctx context.Context, venueID uuid.UUID) (map[int]int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	counts := make(map[int]int)
+	for _, review := range r.store.reviews {
+		if review.VenueID == venueID {
+			counts[review.Rating]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *venueRepository) GetUserVenueReview(ctx context.Context, venueID, userID uuid.UUID) (*models.VenueReview, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, review := range r.store.reviews {
+		if review.VenueID == venueID && review.UserID == userID {
+			review.HelpfulCount = len(r.store.reviewVotes[review.ID])
+			return &review, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *venueRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*models.VenueReview, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, review := range r.store.reviews {
+		if review.ID == id {
+			review.HelpfulCount = len(r.store.reviewVotes[review.ID])
+			return &review, nil
+		}
+	}
+	return nil, fmt.Errorf("review not found")
+}
+
+func (r *venueRepository) ToggleReviewVote(ctx context.Context, reviewID, userID uuid.UUID) (bool, int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	votes := r.store.reviewVotes[reviewID]
+	if votes[userID] {
+		delete(votes, userID)
+		return false, len(votes), nil
+	}
+
+	if votes == nil {
+		votes = make(map[uuid.UUID]bool)
+		r.store.reviewVotes[reviewID] = votes
+	}
+	votes[userID] = true
+	return true, len(votes), nil
+}
+
+func (r *venueRepository) SetReviewResponse(ctx context.Context, reviewID uuid.UUID, response string, respondedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := range r.store.reviews {
+		if r.store.reviews[i].ID == reviewID {
+			r.store.reviews[i].OwnerResponse = &response
+			r.store.reviews[i].OwnerRespondedAt = &respondedAt
+			return nil
+		}
+	}
+	return fmt.Errorf("review not found")
+}
+
+func (r *venueRepository) GetUserReviews(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.VenueReview, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	reviews := []models.VenueReview{}
+	for _, review := range r.store.reviews {
+		if review.UserID == userID {
+			reviews = append(reviews, review)
+		}
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].CreatedAt.Before(reviews[j].CreatedAt)
+	})
+
+	start, end := page(len(reviews), limit, offset)
+	return reviews[start:end], nil
+}
+
+func (r *venueRepository) UpdateVenueRating(ctx context.Context, venueID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.updateRating(venueID)
+}
+
+func (r *venueRepository) updateRating(venueID uuid.UUID) error {
+	venue, ok := r.store.venues[venueID]
+	if !ok {
+		return fmt.Errorf("venue not found")
+	}
+
+	total, count := 0, 0
+	for _, review := range r.store.reviews {
+		if review.VenueID == venueID {
+			total += review.Rating
+			count++
+		}
+	}
+
+	venue.Rating = 0
+	if count > 0 {
+		venue.Rating = float64(total) / float64(count)
+	}
+	venue.TotalReviews = count
+	venue.UpdatedAt = time.Now()
+	r.store.venues[venueID] = venue
+	return nil
+}
+
+func (r *venueRepository) GetFacilities(ctx context.Context, venueID uuid.UUID) ([]models.Facility, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.facilities(venueID), nil
+}
+
+func (r *venueRepository) AddFacilities(ctx context.Context, venueID uuid.UUID, facilityIDs []uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.addFacilities(venueID, facilityIDs)
+}
+
+func (r *venueRepository) UpdateFacilities(ctx context.Context, venueID uuid.UUID, facilityIDs []uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.venueFacilities, venueID)
+	if err := r.addFacilities(venueID, facilityIDs); err != nil {
+		return fmt.Errorf("failed to update facilities: %w", err)
+	}
+	return nil
+}
+
+func (r *venueRepository) addFacilities(venueID uuid.UUID, facilityIDs []uuid.UUID) error {
+	for _, id := range facilityIDs {
+		if _, ok := r.store.facilities[id]; !ok {
+			return fmt.Errorf("failed to add facility: facility %s not found", id)
+		}
+		for _, existing := range r.store.venueFacilities[venueID] {
+			if existing == id {
+				return fmt.Errorf("failed to add facility: facility %s already added", id)
+			}
+		}
+		r.store.venueFacilities[venueID] = append(r.store.venueFacilities[venueID], id)
+	}
+	return nil
+}
+
+func (r *venueRepository) GetTags(ctx context.Context, venueID uuid.UUID) ([]string, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.tags(venueID), nil
+}
+
+func (r *venueRepository) SetTags(ctx context.Context, venueID uuid.UUID, tags []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	unique := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+
+	r.store.venueTags[venueID] = unique
+	return nil
+}
+
+func (r *venueRepository) RemoveTag(ctx context.Context, venueID uuid.UUID, tag string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tags := r.store.venueTags[venueID]
+	for i, existing := range tags {
+		if existing == tag {
+			r.store.venueTags[venueID] = append(tags[:i:i], tags[i+1:]...)
+			return nil
+		}
+	}
+
+	return interfaces.ErrTagNotFound
+}
+
+// selectVenues returns the live venues that match, with facilities, courts and tags filled in
+func (r *venueRepository) selectVenues(match func(models.Venue) bool) []models.Venue {
+	venues := []models.Venue{}
+	for _, venue := range r.store.venues {
+		if venue.DeletedAt != nil || !match(venue) {
+			continue
+		}
+		venue.Facilities = r.facilities(venue.ID)
+		venue.Courts = r.store.venueCourts(venue.ID)
+		venue.Tags = r.tags(venue.ID)
+		venues = append(venues, venue)
+	}
+	return venues
+}
+
+func (r *venueRepository) matchTags(venueID uuid.UUID, filter interfaces.VenueTagFilter) bool {
+	if len(filter.Tags) == 0 {
+		return true
+	}
+
+	has := make(map[string]bool)
+	for _, tag := range r.store.venueTags[venueID] {
+		has[tag] = true
+	}
+
+	for _, tag := range filter.Tags {
+		if has[tag] && !filter.MatchAll {
+			return true
+		}
+		if !has[tag] && filter.MatchAll {
+			return false
+		}
+	}
+	return filter.MatchAll
+}
+
+func (r *venueRepository) facilities(venueID uuid.UUID) []models.Facility {
+	facilities := []models.Facility{}
+	for _, id := range r.store.venueFacilities[venueID] {
+		facilities = append(facilities, r.store.facilities[id])
+	}
+	return facilities
+}
+
+func (r *venueRepository) tags(venueID uuid.UUID) []string {
+	tags := append([]string{}, r.store.venueTags[venueID]...)
+	sort.Strings(tags)
+	return tags
+}
+
+// byRating orders venues by rating, review count and then newest first
+func byRating(a, b models.Venue) bool {
+	if a.Rating != b.Rating {
+		return a.Rating > b.Rating
+	}
+	if a.TotalReviews != b.TotalReviews {
+		return a.TotalReviews > b.TotalReviews
+	}
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+func (r *venueRepository) CreateClaim(ctx context.Context, claim *models.VenueClaim) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.venueClaims[claim.ID]; ok {
+		return fmt.Errorf("failed to create claim: claim %s already exists", claim.ID)
+	}
+	r.store.venueClaims[claim.ID] = *claim
+	return nil
+}
+
+func (r *venueRepository) GetClaim(ctx context.Context, id uuid.UUID) (*models.VenueClaim, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	claim, ok := r.store.venueClaims[id]
+	if !ok {
+		return nil, fmt.Errorf("claim not found")
+	}
+	return &claim, nil
+}
+
+func (r *venueRepository) HasPendingClaim(ctx context.Context, venueID, claimantID uuid.UUID) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, claim := range r.store.venueClaims {
+		if claim.VenueID == venueID && claim.ClaimantID == claimantID && claim.Status == models.VenueClaimStatusPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *venueRepository) ListClaims(ctx context.Context, status models.VenueClaimStatus, limit, offset int) ([]models.VenueClaim, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	claims := r.claimsWithStatus(status)
+	start, end := page(len(claims), limit, offset)
+	return claims[start:end], nil
+}
+
+func (r *venueRepository) CountClaims(ctx context.Context, status models.VenueClaimStatus) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return len(r.claimsWithStatus(status)), nil
+}
+
+// claimsWithStatus returns the claims with the status, oldest first. The caller holds the lock.
+func (r *venueRepository) claimsWithStatus(status models.VenueClaimStatus) []models.VenueClaim {
+	claims := []models.VenueClaim{}
+	for _, claim := range r.store.venueClaims {
+		if status == "" || claim.Status == status {
+			claims = append(claims, claim)
+		}
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		if !claims[i].CreatedAt.Equal(claims[j].CreatedAt) {
+			return claims[i].CreatedAt.Before(claims[j].CreatedAt)
+		}
+		return claims[i].ID.String() < claims[j].ID.String()
+	})
+	return claims
+}
+
+func (r *venueRepository) ReviewClaim(ctx context.Context, id uuid.UUID, status models.VenueClaimStatus, reviewedBy uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	claim, ok := r.store.venueClaims[id]
+	if !ok || claim.Status != models.VenueClaimStatusPending {
+		return fmt.Errorf("pending claim not found")
+	}
+
+	now := time.Now()
+	if status == models.VenueClaimStatusApproved {
+		venue, ok := r.store.venues[claim.VenueID]
+		if !ok || venue.DeletedAt != nil || venue.OwnerID != uuid.Nil {
+			return fmt.Errorf("venue already has an owner")
+		}
+		venue.OwnerID = claim.ClaimantID
+		venue.UpdatedAt = now
+		r.store.venues[venue.ID] = venue
+
+		// The venue is taken, so nobody else's claim on it can succeed
+		for otherID, other := range r.store.venueClaims {
+			if other.VenueID == claim.VenueID && otherID != id && other.Status == models.VenueClaimStatusPending {
+				other.Status = models.VenueClaimStatusRejected
+				other.ReviewedBy = &reviewedBy
+				other.ReviewedAt = &now
+				r.store.venueClaims[otherID] = other
+			}
+		}
+	}
+
+	claim.Status = status
+	claim.ReviewedBy = &reviewedBy
+	claim.ReviewedAt = &now
+	r.store.venueClaims[id] = claim
+	return nil
+}
+
+func (r *venueRepository) AddFavorite(ctx context.Context, userID, venueID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.venues[venueID]; !ok {
+		return fmt.Errorf("failed to add favorite: venue %s not found", venueID)
+	}
+
+	favorites, ok := r.store.favoriteVenues[userID]
+	if !ok {
+		favorites = make(map[uuid.UUID]time.Time)
+		r.store.favoriteVenues[userID] = favorites
+	}
+	if _, ok := favorites[venueID]; !ok {
+		favorites[venueID] = time.Now()
+	}
+	return nil
+}
+
+func (r *venueRepository) RemoveFavorite(ctx context.Context, userID, venueID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.favoriteVenues[userID], venueID)
+	return nil
+}
+
+func (r *venueRepository) ListFavorites(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Venue, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	favorites := r.store.favoriteVenues[userID]
+	venues := r.selectVenues(func(v models.Venue) bool {
+		_, ok := favorites[v.ID]
+		return ok
+	})
+
+	sort.Slice(venues, func(i, j int) bool {
+		a, b := favorites[venues[i].ID], favorites[venues[j].ID]
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return venues[i].ID.String() < venues[j].ID.String()
+	})
+
+	start, end := page(len(venues), limit, offset)
+	return venues[start:end], nil
+}
+
+func (r *venueRepository) CountFavorites(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	count := 0
+	for venueID := range r.store.favoriteVenues[userID] {
+		if venue, ok := r.store.venues[venueID]; ok && venue.DeletedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *venueRepository) GetFavoritedVenueIDs(ctx context.Context, userID uuid.UUID, venueIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	favorited := make(map[uuid.UUID]bool)
+	for _, venueID := range venueIDs {
+		if _, ok := r.store.favoriteVenues[userID][venueID]; ok {
+			favorited[venueID] = true
+		}
+	}
+	return favorited, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type bookingRepository struct {
@@ -39,18 +40,59 @@ func (r *bookingRepository) Create(ctx context.Context, booking *models.CourtBoo
 		return fmt.Errorf("court is not available for the requested time")
 	}
 
-	query := `
+	_, err = r.db.NamedExecContext(ctx, insertBookingQuery, booking)
+	return err
+}
+
+// CreateIfAvailable inserts the booking unless a live booking clashes with it.
+// The check and the insert run under a transaction-scoped advisory lock on the
+// court and date, so concurrent requests for the same court-day are serialized
+// and can't both see the slot as free.
+func (r *bookingRepository) CreateIfAvailable(ctx context.Context, booking *models.CourtBooking) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockCourtDay(ctx, tx, booking.CourtID, booking.Date); err != nil {
+		return false, fmt.Errorf("failed to lock court: %w", err)
+	}
+
+	clashes, err := countClashingBookings(ctx, tx, booking.CourtID, booking.Date, booking.StartTime, booking.EndTime)
+	if err != nil {
+		return false, fmt.Errorf("error checking availability: %w", err)
+	}
+	if clashes > 0 {
+		return false, nil
+	}
+
+	if _, err := tx.NamedExecContext(ctx, insertBookingQuery, booking); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+const insertBookingQuery = `
         INSERT INTO court_bookings (
             id, court_id, user_id, booking_date, start_time, end_time,
-            total_amount, status, notes, created_at, updated_at
+            total_amount, status, notes, created_at, updated_at, expires_at,
+            player_count
         ) VALUES (
             :id, :court_id, :user_id, :booking_date, :start_time, :end_time,
-            :total_amount, :status, :notes, :created_at, :updated_at
+            :total_amount, :status, :notes, :created_at, :updated_at, :expires_at,
+            :player_count
         )`
 
-	_, err = r.db.NamedExecContext(ctx, query, booking)
+// lockCourtDay takes a Postgres advisory lock keyed on the court and date,
+// released when the transaction ends
+func lockCourtDay(ctx context.Context, tx *sqlx.Tx, courtID uuid.UUID, date time.Time) error {
+	key := courtID.String() + ":" + date.Format("2006-01-02")
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key)
 	return err
 }
+
 func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CourtBooking, error) {
 	query := `
 		SELECT 
@@ -59,6 +101,7 @@ func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 			c.price_per_hour,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as user_name
 		FROM court_bookings b
 		JOIN courts c ON c.id = b.court_id
@@ -82,65 +125,38 @@ func (r *bookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 	return &booking, nil
 }
 
+// List returns bookings at venues owned by userID, or at every venue when userID is nil
 func (r *bookingRepository) List(ctx context.Context, userID uuid.UUID, filters map[string]interface{}, limit, offset int) ([]models.CourtBooking, error) {
-	query := `
+	where, args := bookingConditions(filters)
+	if userID != uuid.Nil {
+		args = append(args, userID)
+		where += fmt.Sprintf(" AND v.owner_id = $%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
 		SELECT
-			cb.*,
+			b.*,
 			c.name as court_name,
 			c.price_per_hour,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as user_name
-		FROM
-		users u
-		JOIN venues v ON v.owner_id = u.id
-		JOIN courts c ON c.venue_id = v.id
-		JOIN court_bookings cb ON cb.court_id = c.id
-		WHERE 1=1`
-
-	args := []interface{}{}
-	argCount := 1
-
-	if courtID, ok := filters["court_id"].(uuid.UUID); ok {
-		query += fmt.Sprintf(" AND b.court_id = $%d", argCount)
-		args = append(args, courtID)
-		argCount++
-	}
-
-	if date, ok := filters["date"].(string); ok {
-		query += fmt.Sprintf(" AND cb.booking_date = $%d", argCount)
-		args = append(args, date)
-		argCount++
-	}
-
-	if status, ok := filters["status"].(string); ok {
-		query += fmt.Sprintf(" AND cb.status = $%d", argCount)
-		args = append(args, status)
-		argCount++
-	}
-
-	if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
-		query += fmt.Sprintf(" AND v.id = $%d", argCount)
-		args = append(args, venueID)
-		argCount++
-	}
-
-	if userID != uuid.Nil {
-		query += fmt.Sprintf(" AND u.id = $%d", argCount)
-		args = append(args, userID)
-		argCount++
-	}
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		JOIN venues v ON v.id = c.venue_id
+		JOIN users u ON u.id = b.user_id
+		WHERE %s
+		ORDER BY b.booking_date ASC, b.start_time ASC`, where)
 
 	if limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argCount)
 		args = append(args, limit)
-		argCount++
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
 	}
 
 	if offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argCount)
 		args = append(args, offset)
-		argCount++
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
 	}
 
 	var bookings []models.CourtBooking
@@ -149,13 +165,8 @@ func (r *bookingRepository) List(ctx context.Context, userID uuid.UUID, filters
 		return nil, err
 	}
 
-	// Get payments for bookings
-	for i, booking := range bookings {
-		var payment models.Payment
-		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
-		if err := r.db.GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
-			bookings[i].Payment = &payment
-		}
+	if err := r.attachPayments(ctx, bookings); err != nil {
+		return nil, err
 	}
 
 	return bookings, nil
@@ -166,6 +177,7 @@ func (r *bookingRepository) Update(ctx context.Context, booking *models.CourtBoo
 		UPDATE court_bookings SET
 			status = :status,
 			notes = :notes,
+			player_count = :player_count,
 			updated_at = :updated_at
 		WHERE id = :id`
 
@@ -206,6 +218,17 @@ func (r *bookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *bookingRepository) GetUserBookings(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.CourtBooking, error) {
+	filters := map[string]interface{}{}
+	if !includeHistory {
+		filters["when"] = "upcoming"
+	}
+
+	return r.ListUserBookings(ctx, userID, filters, false)
+}
+
+// ListUserBookings lists a customer's bookings. Filters are "when" (upcoming or past),
+// "status" and "venue_id"; descending puts the latest booking first.
+func (r *bookingRepository) ListUserBookings(ctx context.Context, userID uuid.UUID, filters map[string]interface{}, descending bool) ([]models.CourtBooking, error) {
 	query := `
 		SELECT 
 			b.*,
@@ -213,6 +236,7 @@ func (r *bookingRepository) GetUserBookings(ctx context.Context, userID uuid.UUI
 			c.price_per_hour,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as user_name
 		FROM court_bookings b
 		JOIN courts c ON c.id = b.court_id
@@ -220,25 +244,39 @@ func (r *bookingRepository) GetUserBookings(ctx context.Context, userID uuid.UUI
 		JOIN users u ON u.id = b.user_id
 		WHERE b.user_id = $1`
 
-	if !includeHistory {
+	args := []interface{}{userID}
+
+	switch filters["when"] {
+	case "upcoming":
 		query += " AND b.booking_date >= CURRENT_DATE"
+	case "past":
+		query += " AND b.booking_date < CURRENT_DATE"
 	}
 
-	query += " ORDER BY b.booking_date ASC, b.start_time ASC"
+	if status, ok := filters["status"].(models.BookingStatus); ok {
+		args = append(args, status)
+		query += fmt.Sprintf(" AND b.status = $%d", len(args))
+	}
+
+	if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
+		args = append(args, venueID)
+		query += fmt.Sprintf(" AND v.id = $%d", len(args))
+	}
+
+	if descending {
+		query += " ORDER BY b.booking_date DESC, b.start_time DESC"
+	} else {
+		query += " ORDER BY b.booking_date ASC, b.start_time ASC"
+	}
 
 	var bookings []models.CourtBooking
-	err := r.db.SelectContext(ctx, &bookings, query, userID)
+	err := r.db.SelectContext(ctx, &bookings, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get payments for bookings
-	for i, booking := range bookings {
-		var payment models.Payment
-		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
-		if err := r.db.GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
-			bookings[i].Payment = &payment
-		}
+	if err := r.attachPayments(ctx, bookings); err != nil {
+		return nil, err
 	}
 
 	return bookings, nil
@@ -252,6 +290,7 @@ func (r *bookingRepository) GetVenueBookings(ctx context.Context, venueID uuid.U
 			c.price_per_hour,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as user_name
 		FROM court_bookings b
 		JOIN courts c ON c.id = b.court_id
@@ -266,13 +305,41 @@ func (r *bookingRepository) GetVenueBookings(ctx context.Context, venueID uuid.U
 		return nil, err
 	}
 
-	// Get payments for bookings
-	for i, booking := range bookings {
-		var payment models.Payment
-		paymentQuery := `SELECT * FROM payments WHERE booking_id = $1`
-		if err := r.db.GetContext(ctx, &payment, paymentQuery, booking.ID); err == nil {
-			bookings[i].Payment = &payment
-		}
+	if err := r.attachPayments(ctx, bookings); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// GetUpcomingVenueBookings returns the pending or confirmed bookings at a venue on or after fromDate
+func (r *bookingRepository) GetUpcomingVenueBookings(ctx context.Context, venueID uuid.UUID, fromDate time.Time) ([]models.CourtBooking, error) {
+	query := `
+		SELECT 
+			b.*,
+			c.name as court_name,
+			c.price_per_hour,
+			v.name as venue_name,
+			v.location as venue_location,
+			v.timezone as venue_timezone,
+			u.first_name || ' ' || u.last_name as user_name
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		JOIN venues v ON v.id = c.venue_id
+		JOIN users u ON u.id = b.user_id
+		WHERE v.id = $1 
+			AND b.booking_date >= $2::date
+			AND b.status IN ('pending', 'confirmed')
+		ORDER BY b.booking_date ASC, b.start_time ASC`
+
+	var bookings []models.CourtBooking
+	err := r.db.SelectContext(ctx, &bookings, query, venueID, fromDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachPayments(ctx, bookings); err != nil {
+		return nil, err
 	}
 
 	return bookings, nil
@@ -286,6 +353,7 @@ func (r *bookingRepository) GetCourtBookings(ctx context.Context, courtID uuid.U
 			c.price_per_hour,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as user_name
 		FROM court_bookings b
 		JOIN courts c ON c.id = b.court_id
@@ -301,20 +369,8 @@ func (r *bookingRepository) GetCourtBookings(ctx context.Context, courtID uuid.U
 
 func (r *bookingRepository) CheckCourtAvailability(ctx context.Context, courtID uuid.UUID, date time.Time, startTime, endTime time.Time) (bool, error) {
 	// First check if any existing bookings conflict
-	bookingQuery := `
-        SELECT COUNT(*)
-        FROM court_bookings
-        WHERE court_id = $1 
-        AND booking_date = $2
-        AND status != 'cancelled'
-        AND (
-            (start_time <= $3 AND end_time > $3)
-            OR (start_time < $4 AND end_time >= $4)
-            OR (start_time >= $3 AND end_time <= $4)
-        )`
-
-	var bookingCount int
-	if err := r.db.GetContext(ctx, &bookingCount, bookingQuery, courtID, date, startTime, endTime); err != nil {
+	bookingCount, err := countClashingBookings(ctx, r.db, courtID, date, startTime, endTime)
+	if err != nil {
 		return false, err
 	}
 
@@ -370,6 +426,52 @@ func (r *bookingRepository) CheckCourtAvailability(ctx context.Context, courtID
 	return false, nil
 }
 
+// countClashingBookings counts the live bookings on the court that overlap the slot
+func countClashingBookings(ctx context.Context, q sqlx.QueryerContext, courtID uuid.UUID, date time.Time, startTime, endTime time.Time) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM court_bookings
+        WHERE court_id = $1 
+        AND booking_date = $2
+        AND status != 'cancelled'
+        AND NOT (` + expiredHoldCondition + `)
+        AND (
+            (start_time <= $3 AND end_time > $3)
+            OR (start_time < $4 AND end_time >= $4)
+            OR (start_time >= $3 AND end_time <= $4)
+        )`
+
+	var count int
+	err := sqlx.GetContext(ctx, q, &count, query, courtID, date, startTime, endTime)
+	return count, err
+}
+
+// expiredHoldCondition matches court_bookings rows whose hold ran out before
+// payment. Such bookings no longer block the court, even before they are swept.
+const expiredHoldCondition = `court_bookings.status = 'pending'
+	AND court_bookings.expires_at IS NOT NULL
+	AND court_bookings.expires_at < NOW()
+	AND NOT EXISTS (SELECT 1 FROM payments p WHERE p.booking_id = court_bookings.id)`
+
+// CancelExpiredHolds cancels unpaid pending bookings whose hold has expired and
+// returns their IDs
+func (r *bookingRepository) CancelExpiredHolds(ctx context.Context) ([]uuid.UUID, error) {
+	query := `
+		UPDATE court_bookings
+		SET status = 'cancelled',
+			cancelled_at = NOW(),
+			updated_at = NOW()
+		WHERE ` + expiredHoldCondition + `
+		RETURNING id`
+
+	ids := []uuid.UUID{}
+	if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+		return nil, fmt.Errorf("failed to cancel expired holds: %w", err)
+	}
+
+	return ids, nil
+}
+
 func (r *bookingRepository) CancelBooking(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE court_bookings 
@@ -446,49 +548,136 @@ func (r *bookingRepository) UpdatePayment(ctx context.Context, payment *models.P
 	return nil
 }
 
-func (r *bookingRepository) Count(ctx context.Context, userID uuid.UUID, filters map[string]interface{}) (int, error) {
-	query := `
-		SELECT
-			COUNT(*)
-		FROM
-		users u
-		JOIN venues v ON v.owner_id = u.id
-		JOIN courts c ON c.venue_id = v.id
-		JOIN court_bookings cb ON cb.court_id = c.id
-		WHERE 1=1`
-
+// bookingConditions builds the WHERE clause shared by List, Count, ListAll and CountAll
+func bookingConditions(filters map[string]interface{}) (string, []interface{}) {
+	conditions := []string{"1=1"}
 	args := []interface{}{}
-	argCount := 1
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
 
 	if courtID, ok := filters["court_id"].(uuid.UUID); ok {
-		query += fmt.Sprintf(" AND b.court_id = $%d", argCount)
-		args = append(args, courtID)
-		argCount++
+		add("b.court_id = $%d", courtID)
+	}
+	if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
+		add("c.venue_id = $%d", venueID)
+	}
+	if userID, ok := filters["user_id"].(uuid.UUID); ok {
+		add("b.user_id = $%d", userID)
+	}
+	if status, ok := filters["status"].(models.BookingStatus); ok {
+		add("b.status = $%d", status)
+	}
+	if paymentStatus, ok := filters["payment_status"].(models.PaymentStatus); ok {
+		add("EXISTS (SELECT 1 FROM payments p WHERE p.booking_id = b.id AND p.status = $%d)", paymentStatus)
+	}
+	if date, ok := filters["date"].(string); ok {
+		add("b.booking_date = $%d", date)
+	}
+	if dateFrom, ok := filters["date_from"].(time.Time); ok {
+		add("b.booking_date >= $%d", dateFrom)
+	}
+	if dateTo, ok := filters["date_to"].(time.Time); ok {
+		add("b.booking_date <= $%d", dateTo)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// ListAll lists bookings across every venue, newest first
+func (r *bookingRepository) ListAll(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.CourtBooking, error) {
+	where, args := bookingConditions(filters)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT
+			b.*,
+			c.name as court_name,
+			c.price_per_hour,
+			v.name as venue_name,
+			v.location as venue_location,
+			v.timezone as venue_timezone,
+			u.first_name || ' ' || u.last_name as user_name
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		JOIN venues v ON v.id = c.venue_id
+		JOIN users u ON u.id = b.user_id
+		WHERE %s
+		ORDER BY b.booking_date DESC, b.start_time DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args))
+
+	bookings := []models.CourtBooking{}
+	if err := r.db.SelectContext(ctx, &bookings, query, args...); err != nil {
+		return nil, err
 	}
 
-	if date, ok := filters["date"].(time.Time); ok {
-		query += fmt.Sprintf(" AND b.booking_date = $%d", argCount)
-		args = append(args, date)
-		argCount++
+	if err := r.attachPayments(ctx, bookings); err != nil {
+		return nil, err
 	}
 
-	if status, ok := filters["status"].(string); ok {
-		query += fmt.Sprintf(" AND b.status = $%d", argCount)
-		args = append(args, status)
-		argCount++
+	return bookings, nil
+}
+
+func (r *bookingRepository) CountAll(ctx context.Context, filters map[string]interface{}) (int, error) {
+	where, args := bookingConditions(filters)
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		WHERE %s`, where)
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, err
 	}
 
-	if venueID, ok := filters["venue_id"].(uuid.UUID); ok {
-		query += fmt.Sprintf(" AND v.id = $%d", argCount)
-		args = append(args, venueID)
-		argCount++
+	return count, nil
+}
+
+// attachPayments loads the payments of all bookings in one query
+func (r *bookingRepository) attachPayments(ctx context.Context, bookings []models.CourtBooking) error {
+	if len(bookings) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(bookings))
+	for i, booking := range bookings {
+		ids[i] = booking.ID
+	}
+
+	payments := []models.Payment{}
+	if err := r.db.SelectContext(ctx, &payments, `SELECT * FROM payments WHERE booking_id = ANY($1)`, pq.Array(ids)); err != nil {
+		return err
 	}
 
+	byBooking := make(map[uuid.UUID]*models.Payment, len(payments))
+	for i := range payments {
+		byBooking[payments[i].BookingID] = &payments[i]
+	}
+	for i := range bookings {
+		bookings[i].Payment = byBooking[bookings[i].ID]
+	}
+
+	return nil
+}
+
+func (r *bookingRepository) Count(ctx context.Context, userID uuid.UUID, filters map[string]interface{}) (int, error) {
+	where, args := bookingConditions(filters)
 	if userID != uuid.Nil {
-		query += fmt.Sprintf(" AND u.id = $%d", argCount)
 		args = append(args, userID)
-		argCount++
+		where += fmt.Sprintf(" AND v.owner_id = $%d", len(args))
 	}
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM court_bookings b
+		JOIN courts c ON c.id = b.court_id
+		JOIN venues v ON v.id = c.venue_id
+		WHERE %s`, where)
+
 	var count int
 	err := r.db.GetContext(ctx, &count, query, args...)
 	if err != nil {
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateIfAvailableConcurrentRequestsBookSlotOnce(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	userRepo := NewUserRepository(db)
+	venueRepo := NewVenueRepository(db)
+	courtRepo := NewCourtRepository(db)
+	bookingRepo := NewBookingRepository(db)
+
+	user := &models.User{
+		Email:     uuid.NewString() + "@example.com",
+		FirstName: "Booker",
+		LastName:  "Test",
+		PlayLevel: models.PlayerLevelIntermediate,
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM users WHERE id = $1`, user.ID)
+	})
+
+	now := time.Now()
+	venue := &models.Venue{
+		Name:      "Booking Race Hall " + uuid.NewString(),
+		Location:  "Bangkok",
+		OpenRange: models.NullRawMessage{RawMessage: json.RawMessage(`[]`), Valid: true},
+		Rules:     models.NullRawMessage{RawMessage: json.RawMessage(`[]`), Valid: true},
+		Status:    models.VenueStatusActive,
+		OwnerID:   user.ID,
+		Timezone:  "Asia/Bangkok",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := venueRepo.Create(ctx, venue); err != nil {
+		t.Fatalf("create venue: %v", err)
+	}
+	court := &models.Court{
+		ID:           uuid.New(),
+		VenueID:      venue.ID,
+		Name:         "Court 1",
+		PricePerHour: 200,
+		MaxPlayers:   4,
+		Status:       models.CourtStatusAvailable,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := courtRepo.Create(ctx, court); err != nil {
+		t.Fatalf("create court: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM court_bookings WHERE court_id = $1`, court.ID)
+		db.Exec(`DELETE FROM courts WHERE id = $1`, court.ID)
+		db.Exec(`DELETE FROM venues WHERE id = $1`, venue.ID)
+	})
+
+	// Every slot covers 18:00-19:00, so any two of them clash
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 2)
+	slots := [][2]int{{18, 20}, {17, 19}, {18, 19}, {17, 20}, {18, 21}, {16, 19}}
+
+	const requests = 24
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+	start := make(chan struct{})
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(slot [2]int) {
+			defer wg.Done()
+			<-start
+			booking := &models.CourtBooking{
+				ID:          uuid.New(),
+				CourtID:     court.ID,
+				UserID:      user.ID,
+				Date:        date,
+				StartTime:   time.Date(0, 1, 1, slot[0], 0, 0, 0, time.UTC),
+				EndTime:     time.Date(0, 1, 1, slot[1], 0, 0, 0, time.UTC),
+				TotalAmount: float64(slot[1]-slot[0]) * court.PricePerHour,
+				Status:      models.BookingStatusPending,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+				PlayerCount: 2,
+			}
+			ok, err := bookingRepo.CreateIfAvailable(ctx, booking)
+			if err != nil {
+				t.Errorf("create booking: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				created++
+				mu.Unlock()
+			}
+		}(slots[i%len(slots)])
+	}
+	close(start)
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("bookings created = %d, want 1", created)
+	}
+
+	var stored int
+	if err := db.GetContext(ctx, &stored, `SELECT COUNT(*) FROM court_bookings WHERE court_id = $1`, court.ID); err != nil {
+		t.Fatalf("count bookings: %v", err)
+	}
+	if stored != 1 {
+		t.Errorf("stored bookings = %d, want 1", stored)
+	}
+}
@@ -5,11 +5,17 @@ import (
 	"badbuddy/internal/repositories/interfaces"
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+// ErrDirectChatParticipants is returned when a direct chat would end up with
+// other than its two members
+var ErrDirectChatParticipants = errors.New("a direct chat has exactly two participants")
+
 type chatRepository struct {
 	db *sqlx.DB
 }
@@ -22,7 +28,7 @@ func (r *chatRepository) GetChatMessageByID(ctx context.Context, chatID uuid.UUI
 	// Get chat
 	chat := models.Chat{}
 
-	query := `SELECT * FROM chats WHERE id = $1`
+	query := `SELECT * FROM chats WHERE id = $1 AND deleted_at IS NULL`
 
 	err := r.db.GetContext(ctx, &chat, query, chatID)
 	if err != nil {
@@ -36,6 +42,7 @@ func (r *chatRepository) GetChatMessageByID(ctx context.Context, chatID uuid.UUI
 			m.sender_id,
 			m.type,
 			m.content,
+			m.is_pinned,
 			m.created_at,
 			m.updated_at,
 			u.email,
@@ -74,7 +81,7 @@ func (r *chatRepository) GetChatMessageByID(ctx context.Context, chatID uuid.UUI
 func (r *chatRepository) GetChatByID(ctx context.Context, chatID uuid.UUID) (*models.Chat, error) {
 	chat := models.Chat{}
 
-	query := `SELECT * FROM chats WHERE id = $1`
+	query := `SELECT * FROM chats WHERE id = $1 AND deleted_at IS NULL`
 
 	err := r.db.GetContext(ctx, &chat, query, chatID)
 	if err != nil {
@@ -125,6 +132,7 @@ func (r *chatRepository) GetMessageByID(ctx context.Context, messageID uuid.UUID
 			m.sender_id,
 			m.type,
 			m.content,
+			m.is_pinned,
 			m.created_at,
 			m.updated_at,
 			u.email,
@@ -154,7 +162,12 @@ func (r *chatRepository) GetMessageByID(ctx context.Context, messageID uuid.UUID
 
 }
 
+// CreateChat creates a chat with no members. Direct chats are created by
+// GetDirectChatID together with both their members instead.
 func (r *chatRepository) CreateChat(ctx context.Context, chat *models.Chat) error {
+	if chat.Type == models.ChatTypeDirect {
+		return ErrDirectChatParticipants
+	}
 
 	query := `INSERT INTO chats (id, type, session_id) VALUES ($1, $2, $3)`
 
@@ -166,14 +179,26 @@ func (r *chatRepository) CreateChat(ctx context.Context, chat *models.Chat) erro
 	return nil
 }
 
+// AddUserToChat refuses direct chats, whose two members are fixed
 func (r *chatRepository) AddUserToChat(ctx context.Context, userID, chatID uuid.UUID) error {
 
-	query := `INSERT INTO chat_participants (id, chat_id, user_id) VALUES ($1, $2, $3)`
+	query := `
+		INSERT INTO chat_participants (id, chat_id, user_id)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM chats WHERE id = $2 AND type = 'direct')`
+
+	result, err := r.db.ExecContext(ctx, query, uuid.New(), chatID, userID)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.db.ExecContext(ctx, query, uuid.New(), chatID, userID)
+	rows, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rows == 0 {
+		return ErrDirectChatParticipants
+	}
 
 	return nil
 }
@@ -239,26 +264,39 @@ func (r *chatRepository) IsUserIsSender(ctx context.Context, userID, messageID u
 	return count > 0, nil
 }
 
-func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]models.Chat, error) {
+// GetChats pages through the user's chats, the one with the most recent
+// message first and chats without messages last
+func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID, limit, offset int) (*[]models.Chat, error) {
 	chats := []models.Chat{}
 
 	query := `
 		SELECT 
-			id,
-			type,
-			session_id
+			c.id,
+			c.type,
+			c.session_id,
+			cp.muted
 		FROM
-			chats
+			chats c
+		JOIN
+			chat_participants cp ON cp.chat_id = c.id AND cp.user_id = $1
+		LEFT JOIN LATERAL (
+			SELECT MAX(created_at) AS last_message_at FROM chat_messages WHERE chat_id = c.id AND delete_at IS NULL
+		) lm ON true
 		WHERE
-			id IN (SELECT chat_id FROM chat_participants WHERE user_id = $1)`
+			c.deleted_at IS NULL
+		ORDER BY
+			lm.last_message_at DESC NULLS LAST,
+			c.id
+		LIMIT $2
+		OFFSET $3`
 
-	err := r.db.SelectContext(ctx, &chats, query, userID)
+	err := r.db.SelectContext(ctx, &chats, query, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	lastMessages := []models.Message{}
 	for i, chat := range chats {
+		lastMessages := []models.Message{}
 		query = `
 			SELECT
 				m.id AS m_id,
@@ -266,6 +304,8 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 				m.sender_id,
 				m.type,
 				m.content,
+				m.status,
+				m.is_pinned,
 				m.created_at,
 				m.updated_at,
 				u.email,
@@ -286,6 +326,7 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 				users u ON m.sender_id = u.id
 			WHERE
 				m.chat_id = $1
+				AND m.delete_at IS NULL
 			ORDER BY
 				m.created_at DESC
 			LIMIT 1`
@@ -303,8 +344,8 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 
 	}
 
-	chatUsers := []models.User{}
 	for i, chat := range chats {
+		chatUsers := []models.User{}
 		query = `
 			SELECT
 				u.id,
@@ -338,6 +379,22 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) (*[]mod
 	return &chats, nil
 }
 
+func (r *chatRepository) CountChats(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+
+	query := `
+		SELECT COUNT(*)
+		FROM chats c
+		JOIN chat_participants cp ON cp.chat_id = c.id AND cp.user_id = $1
+		WHERE c.deleted_at IS NULL`
+
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *chatRepository) GetUsersInChat(ctx context.Context, chatID uuid.UUID) (*[]models.User, error) {
 	users := []models.User{}
 
@@ -371,6 +428,10 @@ func (r *chatRepository) GetUsersInChat(ctx context.Context, chatID uuid.UUID) (
 func (r *chatRepository) GetDirectChatID(ctx context.Context, userID, otherUserUUID uuid.UUID) (uuid.UUID, error) {
 	var chatID uuid.UUID
 
+	if userID == otherUserUUID {
+		return uuid.Nil, ErrDirectChatParticipants
+	}
+
 	query := `
 		SELECT 
 			chat_id
@@ -378,7 +439,8 @@ func (r *chatRepository) GetDirectChatID(ctx context.Context, userID, otherUserU
 			chat_participants
 		WHERE 
 			user_id = $1
-			AND chat_id IN (SELECT chat_id FROM chat_participants WHERE user_id = $2)`
+			AND chat_id IN (SELECT chat_id FROM chat_participants WHERE user_id = $2)
+			AND chat_id IN (SELECT id FROM chats WHERE type = 'direct' AND deleted_at IS NULL)`
 
 	err := r.db.GetContext(ctx, &chatID, query, userID, otherUserUUID)
 	if err != nil {
@@ -408,7 +470,7 @@ func (r *chatRepository) GetDirectChatID(ctx context.Context, userID, otherUserU
 func (r *chatRepository) GetChatIDBySessionID(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, error) {
 	var chatID uuid.UUID
 
-	query := `SELECT id FROM chats WHERE session_id = $1`
+	query := `SELECT id FROM chats WHERE session_id = $1 AND deleted_at IS NULL`
 
 	err := r.db.GetContext(ctx, &chatID, query, sessionID)
 	if err != nil {
@@ -432,3 +494,136 @@ func (r *chatRepository) IsUserPartOfSession(ctx context.Context, userID, sessio
 
 	return count > 0, nil
 }
+
+func (r *chatRepository) GetUserMessages(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Message, error) {
+	query := `
+		SELECT
+			m.id AS m_id,
+			m.chat_id,
+			m.sender_id,
+			m.type,
+			m.content,
+			m.created_at,
+			m.updated_at
+		FROM chat_messages m
+		WHERE m.sender_id = $1
+			AND m.delete_at IS NULL
+		ORDER BY m.created_at ASC, m.id ASC
+		LIMIT $2 OFFSET $3`
+
+	messages := []models.Message{}
+	if err := r.db.SelectContext(ctx, &messages, query, userID, limit, offset); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// IsUserChatAdmin reports whether the user is an admin of the chat or hosts
+// the session the chat belongs to
+func (r *chatRepository) IsUserChatAdmin(ctx context.Context, userID, chatID uuid.UUID) (bool, error) {
+	var isAdmin bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM chat_participants
+			WHERE chat_id = $2 AND user_id = $1 AND is_admin = true
+		) OR EXISTS (
+			SELECT 1 FROM chats c
+			JOIN play_sessions ps ON ps.id = c.session_id
+			WHERE c.id = $2 AND ps.host_id = $1
+		)`
+
+	err := r.db.GetContext(ctx, &isAdmin, query, userID, chatID)
+	if err != nil {
+		return false, err
+	}
+
+	return isAdmin, nil
+}
+
+func (r *chatRepository) SetMessagePinned(ctx context.Context, messageID uuid.UUID, pinned bool) error {
+	query := `
+		UPDATE chat_messages
+		SET is_pinned = $1,
+			pinned_at = CASE WHEN $1 THEN NOW() ELSE NULL END
+		WHERE id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, pinned, messageID)
+	return err
+}
+
+func (r *chatRepository) GetPinnedMessages(ctx context.Context, chatID uuid.UUID) ([]models.Message, error) {
+	query := `
+		SELECT
+			m.id AS m_id,
+			m.chat_id,
+			m.sender_id,
+			m.type,
+			m.content,
+			m.is_pinned,
+			m.pinned_at,
+			m.created_at,
+			m.updated_at,
+			u.email,
+			u.first_name,
+			u.last_name,
+			u.phone,
+			u.play_level,
+			u.avatar_url,
+			u.gender,
+			u.location,
+			u.bio,
+			u.last_active_at
+		FROM chat_messages m
+		JOIN users u ON m.sender_id = u.id
+		WHERE m.chat_id = $1
+			AND m.is_pinned
+			AND m.delete_at IS NULL
+		ORDER BY m.pinned_at DESC`
+
+	messages := []models.Message{}
+	if err := r.db.SelectContext(ctx, &messages, query, chatID); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// DeleteChat soft-deletes the chat together with its messages
+func (r *chatRepository) DeleteChat(ctx context.Context, chatID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE chats SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, chatID); err != nil {
+		return fmt.Errorf("failed to delete chat: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE chat_messages SET delete_at = NOW(), updated_at = NOW() WHERE chat_id = $1 AND delete_at IS NULL`, chatID); err != nil {
+		return fmt.Errorf("failed to delete chat messages: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *chatRepository) SetChatMuted(ctx context.Context, userID, chatID uuid.UUID, muted bool) error {
+	query := `UPDATE chat_participants SET muted = $1 WHERE chat_id = $2 AND user_id = $3`
+
+	_, err := r.db.ExecContext(ctx, query, muted, chatID, userID)
+	return err
+}
+
+func (r *chatRepository) GetUnmutedUserIDs(ctx context.Context, chatID uuid.UUID) ([]uuid.UUID, error) {
+	userIDs := []uuid.UUID{}
+
+	query := `SELECT user_id FROM chat_participants WHERE chat_id = $1 AND NOT muted`
+
+	if err := r.db.SelectContext(ctx, &userIDs, query, chatID); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
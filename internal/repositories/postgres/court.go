@@ -25,10 +25,12 @@ func (r *courtRepository) Create(ctx context.Context, court *models.Court) error
 	query := `
 		INSERT INTO courts (
 			id, venue_id, name, description, price_per_hour,
-			status, created_at, updated_at
+			max_players, status, display_order, created_at, updated_at
 		) VALUES (
 			:id, :venue_id, :name, :description, :price_per_hour,
-			:status, :created_at, :updated_at
+			:max_players, :status,
+			(SELECT COALESCE(MAX(display_order), 0) + 1 FROM courts WHERE venue_id = :venue_id),
+			:created_at, :updated_at
 		)`
 
 	_, err := r.db.NamedExecContext(ctx, query, court)
@@ -51,6 +53,23 @@ func (r *courtRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Co
 	return &court, nil
 }
 
+// GetByIDIncludingDeleted looks up a court even if it has been soft-deleted
+func (r *courtRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.Court, error) {
+	query := `
+		SELECT 
+			*
+		FROM courts
+		WHERE id = $1`
+
+	var court models.Court
+	err := r.db.GetContext(ctx, &court, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &court, nil
+}
+
 func (r *courtRepository) GetCourtWithVenueByID(ctx context.Context, id uuid.UUID) (*models.CourtWithVenue, error) {
 	query := `
 		SELECT 
@@ -119,6 +138,12 @@ func (r *courtRepository) List(ctx context.Context, filters map[string]interface
 			argCount++
 		}
 
+		if date, startTime, endTime, ok := courtAvailabilityFilter(filters); ok {
+			whereConditions = append(whereConditions, courtFreeCondition(argCount))
+			args = append(args, date.Format("2006-01-02"), startTime.Format("15:04:05"), endTime.Format("15:04:05"))
+			argCount += 3
+		}
+
 		if len(whereConditions) > 0 {
 			query += " AND " + strings.Join(whereConditions, " AND ")
 		}
@@ -154,6 +179,7 @@ func (r *courtRepository) Update(ctx context.Context, court *models.Court) error
 			name = :name,
 			description = :description,
 			price_per_hour = :price_per_hour,
+			max_players = :max_players,
 			status = :status,
 			updated_at = :updated_at
 		WHERE id = :id AND deleted_at IS NULL`
@@ -199,13 +225,38 @@ func (r *courtRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Restore clears deleted_at on a soft-deleted court
+func (r *courtRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE courts SET
+			deleted_at = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("deleted court not found")
+	}
+
+	return nil
+}
+
 func (r *courtRepository) GetByVenue(ctx context.Context, venueID uuid.UUID) ([]models.Court, error) {
 	query := `
 		SELECT 
 			*
 		FROM courts
 		WHERE venue_id = $1 AND deleted_at IS NULL
-		ORDER BY c.name ASC`
+		ORDER BY display_order ASC, created_at ASC`
 
 	var courts []models.Court
 	err := r.db.SelectContext(ctx, &courts, query, venueID)
@@ -222,7 +273,7 @@ func (r *courtRepository) GetCourtWithVenueByVenue(ctx context.Context, venueID
 		FROM courts c
 		JOIN venues v ON v.id = c.venue_id
 		WHERE c.venue_id = $1 AND c.deleted_at IS NULL
-		ORDER BY c.name ASC`
+		ORDER BY c.display_order ASC, c.created_at ASC`
 
 	var courts []models.CourtWithVenue
 	err := r.db.SelectContext(ctx, &courts, query, venueID)
@@ -277,7 +328,7 @@ func (r *courtRepository) GetAvailableCourts(ctx context.Context, venueID uuid.U
 				OR (b.start_time >= $3 AND b.end_time <= $4)
 			)
 		)
-		ORDER BY c.name ASC`
+		ORDER BY c.display_order ASC, c.created_at ASC`
 
 	var courts []models.Court
 	err := r.db.SelectContext(ctx, &courts, query, venueID, date, startTime, endTime)
@@ -316,6 +367,12 @@ func (r *courtRepository) Count(ctx context.Context, filters map[string]interfac
 			argCount++
 		}
 
+		if date, startTime, endTime, ok := courtAvailabilityFilter(filters); ok {
+			whereConditions = append(whereConditions, courtFreeCondition(argCount))
+			args = append(args, date.Format("2006-01-02"), startTime.Format("15:04:05"), endTime.Format("15:04:05"))
+			argCount += 3
+		}
+
 		if len(whereConditions) > 0 {
 			query += " AND " + strings.Join(whereConditions, " AND ")
 		}
@@ -325,3 +382,35 @@ func (r *courtRepository) Count(ctx context.Context, filters map[string]interfac
 	err := r.db.GetContext(ctx, &count, query, args...)
 	return count, err
 }
+
+// courtAvailabilityFilter reads the "available_date", "available_from" and
+// "available_to" filters, which only apply when all three are set
+func courtAvailabilityFilter(filters map[string]interface{}) (time.Time, time.Time, time.Time, bool) {
+	date, hasDate := filters["available_date"].(time.Time)
+	startTime, hasStart := filters["available_from"].(time.Time)
+	endTime, hasEnd := filters["available_to"].(time.Time)
+	return date, startTime, endTime, hasDate && hasStart && hasEnd
+}
+
+// courtFreeCondition excludes courts with a live booking or an active session
+// overlapping the window. Its placeholders are the date, start and end time.
+func courtFreeCondition(argIndex int) string {
+	return fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM court_bookings
+			WHERE court_bookings.court_id = c.id
+			AND court_bookings.booking_date = $%[1]d::date
+			AND court_bookings.status != 'cancelled'
+			AND NOT (`+expiredHoldCondition+`)
+			AND court_bookings.start_time < $%[3]d::time
+			AND court_bookings.end_time > $%[2]d::time
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM session_courts sc
+			JOIN play_sessions ps ON ps.id = sc.session_id
+			WHERE sc.court_id = c.id
+			AND ps.session_date = $%[1]d::date
+			AND ps.status NOT IN ('cancelled', 'completed')
+			AND ps.start_time < $%[3]d::time
+			AND ps.end_time > $%[2]d::time
+		)`, argIndex, argIndex+1, argIndex+2)
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type notificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) interfaces.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	query := `
+		INSERT INTO notifications (
+			id, user_id, type, title, body, data, created_at
+		) VALUES (
+			:id, :user_id, :type, :title, :body, :data, :created_at
+		)`
+
+	_, err := r.db.NamedExecContext(ctx, query, notification)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, error) {
+	query := `
+		SELECT *
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	notifications := []models.Notification{}
+	err := r.db.SelectContext(ctx, &notifications, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM notifications
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("notification not found")
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `
+		UPDATE notifications
+		SET read_at = NOW()
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListDueReminders reads session and booking times as wall clock times at the
+// venue, so starts_at is the real instant the player has to be there
+func (r *notificationRepository) ListDueReminders(ctx context.Context, until time.Time) ([]models.Reminder, error) {
+	query := `
+		SELECT * FROM (
+			SELECT
+				'session' AS kind,
+				ps.id AS target_id,
+				u.id AS user_id,
+				u.phone,
+				ps.title,
+				v.name AS venue_name,
+				v.timezone,
+				(ps.session_date::date + ps.start_time::time) AT TIME ZONE v.timezone AS starts_at
+			FROM play_sessions ps
+			JOIN venues v ON v.id = ps.venue_id
+			JOIN session_participants sp ON sp.session_id = ps.id AND sp.status = 'confirmed'
+			JOIN users u ON u.id = sp.user_id
+			WHERE ps.status IN ('open', 'full')
+			UNION ALL
+			SELECT
+				'booking' AS kind,
+				b.id AS target_id,
+				u.id AS user_id,
+				u.phone,
+				c.name AS title,
+				v.name AS venue_name,
+				v.timezone,
+				(b.booking_date::date + b.start_time::time) AT TIME ZONE v.timezone AS starts_at
+			FROM court_bookings b
+			JOIN courts c ON c.id = b.court_id
+			JOIN venues v ON v.id = c.venue_id
+			JOIN users u ON u.id = b.user_id
+			WHERE b.status = 'confirmed'
+		) due
+		WHERE due.phone <> ''
+		AND due.starts_at > NOW() AND due.starts_at <= $1
+		AND NOT EXISTS (
+			SELECT 1 FROM sms_reminders sr
+			WHERE sr.target_id = due.target_id AND sr.user_id = due.user_id
+		)
+		ORDER BY due.starts_at`
+
+	reminders := []models.Reminder{}
+	if err := r.db.SelectContext(ctx, &reminders, query, until); err != nil {
+		return nil, fmt.Errorf("failed to list due reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func (r *notificationRepository) MarkReminderSent(ctx context.Context, targetID, userID uuid.UUID) (bool, error) {
+	query := `
+		INSERT INTO sms_reminders (target_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, targetID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record reminder: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type reportRepository struct {
+	db *sqlx.DB
+}
+
+func NewReportRepository(db *sqlx.DB) interfaces.ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) Create(ctx context.Context, report *models.Report) error {
+	query := `
+		INSERT INTO reports (
+			id, reporter_id, target_type, target_id, reason, status, created_at
+		) VALUES (
+			:id, :reporter_id, :target_type, :target_id, :reason, :status, :created_at
+		)`
+
+	if _, err := r.db.NamedExecContext(ctx, query, report); err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reportRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Report, error) {
+	var report models.Report
+	if err := r.db.GetContext(ctx, &report, `SELECT * FROM reports WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("report not found")
+		}
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// List returns the oldest reports first, so the queue is worked in order
+func (r *reportRepository) List(ctx context.Context, filters interfaces.ReportFilters) ([]models.Report, error) {
+	where, args := reportFilterConditions(filters)
+	query := `SELECT * FROM reports WHERE 1=1` + where + ` ORDER BY created_at`
+
+	if filters.Limit > 0 {
+		args = append(args, filters.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filters.Offset > 0 {
+		args = append(args, filters.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	reports := []models.Report{}
+	if err := r.db.SelectContext(ctx, &reports, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *reportRepository) Count(ctx context.Context, filters interfaces.ReportFilters) (int, error) {
+	where, args := reportFilterConditions(filters)
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM reports WHERE 1=1`+where, args...); err != nil {
+		return 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	return count, nil
+}
+
+func reportFilterConditions(filters interfaces.ReportFilters) (string, []interface{}) {
+	where := ""
+	args := []interface{}{}
+
+	if filters.Status != "" {
+		args = append(args, filters.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filters.TargetType != "" {
+		args = append(args, filters.TargetType)
+		where += fmt.Sprintf(" AND target_type = $%d", len(args))
+	}
+
+	return where, args
+}
+
+// Resolve closes an open report. Reports that are already closed count as not found.
+func (r *reportRepository) Resolve(ctx context.Context, id uuid.UUID, status models.ReportStatus, resolvedBy uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE reports
+		SET status = $2, resolved_by = $3, resolved_at = NOW()
+		WHERE id = $1 AND status = 'open'`,
+		id, status, resolvedBy)
+	if err != nil {
+		return fmt.Errorf("failed to resolve report: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("report not found")
+	}
+
+	return nil
+}
+
+func (r *reportRepository) CountByReporterSince(ctx context.Context, reporterID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM reports
+		WHERE reporter_id = $1 AND created_at >= $2`,
+		reporterID, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *reportRepository) HasOpenReport(ctx context.Context, reporterID uuid.UUID, targetType models.ReportTargetType, targetID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, `
+		SELECT EXISTS (
+			SELECT 1 FROM reports
+			WHERE reporter_id = $1 AND target_type = $2 AND target_id = $3 AND status = 'open'
+		)`,
+		reporterID, targetType, targetID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check report: %w", err)
+	}
+
+	return exists, nil
+}
+
+// reportTargetQueries checks that a reported row exists and isn't deleted
+var reportTargetQueries = map[models.ReportTargetType]string{
+	models.ReportTargetSession: `SELECT EXISTS (SELECT 1 FROM play_sessions WHERE id = $1)`,
+	models.ReportTargetVenue:   `SELECT EXISTS (SELECT 1 FROM venues WHERE id = $1 AND deleted_at IS NULL)`,
+	models.ReportTargetUser:    `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND status != 'inactive')`,
+	models.ReportTargetMessage: `SELECT EXISTS (SELECT 1 FROM chat_messages WHERE id = $1 AND delete_at IS NULL)`,
+}
+
+func (r *reportRepository) TargetExists(ctx context.Context, targetType models.ReportTargetType, targetID uuid.UUID) (bool, error) {
+	query, ok := reportTargetQueries[targetType]
+	if !ok {
+		return false, nil
+	}
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, targetID); err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", targetType, err)
+	}
+
+	return exists, nil
+}
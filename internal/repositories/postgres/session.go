@@ -2,37 +2,47 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"badbuddy/internal/domain/models"
 	"badbuddy/internal/repositories/interfaces"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type sessionRepository struct {
-	db *sqlx.DB
+	db           *sqlx.DB
+	searchConfig string
 }
 
-func NewSessionRepository(db *sqlx.DB) interfaces.SessionRepository {
-	return &sessionRepository{db: db}
+// NewSessionRepository creates a session repository. searchConfig is the
+// Postgres text search configuration used for full-text search (e.g. "simple",
+// "english"); it defaults to "simple" so non-English titles are not stemmed away.
+func NewSessionRepository(db *sqlx.DB, searchConfig string) interfaces.SessionRepository {
+	if searchConfig == "" {
+		searchConfig = "simple"
+	}
+	return &sessionRepository{db: db, searchConfig: searchConfig}
 }
 
 func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
 	query := `
 		INSERT INTO play_sessions (
 			id, host_id, venue_id, title, description,
-			session_date, start_time, end_time, player_level,
-			max_participants, cost_per_person, allow_cancellation,
-			cancellation_deadline_hours, is_public, status,
+			session_date, start_time, end_time, open_ended, player_level,
+			max_participants, cost_per_person, cost_mode, play_format, total_court_cost, allow_cancellation,
+			cancellation_deadline_hours, is_public, check_in_code, status,
 			created_at, updated_at
 		) VALUES (
 			:id, :host_id, :venue_id, :title, :description,
-			:session_date, :start_time, :end_time, :player_level,
-			:max_participants, :cost_per_person, :allow_cancellation,
-			:cancellation_deadline_hours, :is_public, :status,
+			:session_date, :start_time, :end_time, :open_ended, :player_level,
+			:max_participants, :cost_per_person, :cost_mode, :play_format, :total_court_cost, :allow_cancellation,
+			:cancellation_deadline_hours, :is_public, :check_in_code, :status,
 			:created_at, :updated_at
 		)`
 
@@ -44,12 +54,78 @@ func (r *sessionRepository) Create(ctx context.Context, session *models.Session)
 	return nil
 }
 
+// CreateWithHost inserts the session together with its courts, rules and the host
+// participant in one transaction, so a failure never leaves a session without a host.
+func (r *sessionRepository) CreateWithHost(ctx context.Context, session *models.Session, host *models.SessionParticipant, courtIDs []uuid.UUID, rules []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sessionQuery := `
+		INSERT INTO play_sessions (
+			id, host_id, venue_id, title, description,
+			session_date, start_time, end_time, open_ended, player_level,
+			max_participants, cost_per_person, cost_mode, play_format, total_court_cost, allow_cancellation,
+			cancellation_deadline_hours, is_public, check_in_code, status,
+			created_at, updated_at
+		) VALUES (
+			:id, :host_id, :venue_id, :title, :description,
+			:session_date, :start_time, :end_time, :open_ended, :player_level,
+			:max_participants, :cost_per_person, :cost_mode, :play_format, :total_court_cost, :allow_cancellation,
+			:cancellation_deadline_hours, :is_public, :check_in_code, :status,
+			:created_at, :updated_at
+		)`
+
+	if _, err := tx.NamedExecContext(ctx, sessionQuery, session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	for _, courtID := range courtIDs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO session_courts (session_id, court_id) VALUES ($1, $2)`,
+			session.ID, courtID)
+		if err != nil {
+			return fmt.Errorf("failed to reserve court %s: %w", courtID, err)
+		}
+	}
+
+	if len(rules) > 0 {
+		rulesQuery := `
+			INSERT INTO session_rules (session_id, rule_text)
+			SELECT $1, UNNEST($2::text[])`
+
+		if _, err := tx.ExecContext(ctx, rulesQuery, session.ID, pq.Array(rules)); err != nil {
+			return fmt.Errorf("failed to add session rules: %w", err)
+		}
+	}
+
+	participantQuery := `
+		INSERT INTO session_participants (
+			id, session_id, user_id, status, joined_at
+		) VALUES (
+			:id, :session_id, :user_id, :status, :joined_at
+		)`
+
+	if _, err := tx.NamedExecContext(ctx, participantQuery, host); err != nil {
+		return fmt.Errorf("failed to add host as participant: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit session: %w", err)
+	}
+
+	return nil
+}
+
 func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SessionDetail, error) {
 	query := `
 		SELECT 
 			ps.*,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as host_name,
 			u.gender as host_gender,
 			u.play_level as host_level,
@@ -60,7 +136,7 @@ func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		JOIN users u ON u.id = ps.host_id
 		LEFT JOIN session_participants sp ON sp.session_id = ps.id
 		WHERE ps.id = $1
-		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level, u.gender`
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender`
 
 	session := &models.SessionDetail{}
 	err := r.db.GetContext(ctx, session, query, id)
@@ -92,6 +168,19 @@ func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.
 		return nil, err
 	}
 
+	// Get reserved courts
+	courtsQuery := `
+		SELECT c.*
+		FROM session_courts sc
+		JOIN courts c ON c.id = sc.court_id
+		WHERE sc.session_id = $1
+		ORDER BY c.display_order, c.created_at`
+
+	err = r.db.SelectContext(ctx, &session.Courts, courtsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return session, nil
 }
 
@@ -103,9 +192,13 @@ func (r *sessionRepository) Update(ctx context.Context, session *models.Session)
 			session_date = :session_date,
 			start_time = :start_time,
 			end_time = :end_time,
+			open_ended = :open_ended,
 			player_level = :player_level,
 			max_participants = :max_participants,
 			cost_per_person = :cost_per_person,
+			cost_mode = :cost_mode,
+			play_format = :play_format,
+			total_court_cost = :total_court_cost,
 			allow_cancellation = :allow_cancellation,
 			cancellation_deadline_hours = :cancellation_deadline_hours,
 			is_public = :is_public,
@@ -130,6 +223,32 @@ func (r *sessionRepository) Update(ctx context.Context, session *models.Session)
 	return nil
 }
 
+// GetByIDs loads several sessions in one query, without participants and rules like List.
+// IDs that don't exist are skipped.
+func (r *sessionRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.SessionDetail, error) {
+	query := `
+		SELECT 
+			ps.*,
+			v.name as venue_name,
+			v.location as venue_location,
+			v.timezone as venue_timezone,
+			u.first_name || ' ' || u.last_name as host_name,
+			u.gender as host_gender,
+			u.play_level as host_level,
+			COUNT(sp.id) FILTER (WHERE sp.status = 'confirmed') as confirmed_players,
+			COUNT(sp.id) FILTER (WHERE sp.status = 'pending') as pending_players
+		FROM play_sessions ps
+		JOIN venues v ON v.id = ps.venue_id
+		JOIN users u ON u.id = ps.host_id
+		LEFT JOIN session_participants sp ON sp.session_id = ps.id
+		WHERE ps.id = ANY($1)
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender`
+
+	sessions := []models.SessionDetail{}
+	err := r.db.SelectContext(ctx, &sessions, query, pq.Array(ids))
+	return sessions, err
+}
+
 func (r *sessionRepository) List(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error) {
 	conditions := []string{"1=1"}
 	args := []interface{}{}
@@ -153,6 +272,34 @@ func (r *sessionRepository) List(ctx context.Context, filters map[string]interfa
 			conditions = append(conditions, fmt.Sprintf("ps.status = $%d", argIndex))
 			args = append(args, value)
 			argIndex++
+		case "from_date":
+			conditions = append(conditions, fmt.Sprintf("ps.session_date >= $%d", argIndex))
+			args = append(args, value)
+			argIndex++
+		case "is_public":
+			conditions = append(conditions, fmt.Sprintf("ps.is_public = $%d", argIndex))
+			args = append(args, value)
+			argIndex++
+		case "host_id":
+			conditions = append(conditions, fmt.Sprintf("ps.host_id = $%d", argIndex))
+			args = append(args, value)
+			argIndex++
+		case "participant_id":
+			// Sessions the user has an active place in
+			conditions = append(conditions, fmt.Sprintf(`EXISTS (
+				SELECT 1 FROM session_participants sp3
+				WHERE sp3.session_id = ps.id AND sp3.user_id = $%d AND sp3.status <> 'cancelled'
+			)`, argIndex))
+			args = append(args, value)
+			argIndex++
+		case "exclude_user_id":
+			// Skip sessions the user hosts or has an active place in
+			conditions = append(conditions, fmt.Sprintf(`ps.host_id <> $%[1]d AND NOT EXISTS (
+				SELECT 1 FROM session_participants sp2
+				WHERE sp2.session_id = ps.id AND sp2.user_id = $%[1]d AND sp2.status <> 'cancelled'
+			)`, argIndex))
+			args = append(args, value)
+			argIndex++
 		}
 	}
 
@@ -163,6 +310,7 @@ func (r *sessionRepository) List(ctx context.Context, filters map[string]interfa
 			ps.*,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as host_name,
 			u.gender as host_gender,
 			u.play_level as host_level,
@@ -173,7 +321,7 @@ func (r *sessionRepository) List(ctx context.Context, filters map[string]interfa
 		JOIN users u ON u.id = ps.host_id
 		LEFT JOIN session_participants sp ON sp.session_id = ps.id
 		WHERE %s
-		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level, u.gender
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender
 		ORDER BY ps.session_date ASC, ps.start_time ASC
 		LIMIT $%d OFFSET $%d`,
 		strings.Join(conditions, " AND "),
@@ -187,11 +335,15 @@ func (r *sessionRepository) List(ctx context.Context, filters map[string]interfa
 }
 func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filters map[string]interface{}, limit, offset int) ([]models.SessionDetail, error) {
 	conditions := []string{}
-	args := []interface{}{searchQuery} // First argument ($1) is always the search query
-	argIndex := 2                      // Start from $2 for filter conditions
+	args := []interface{}{searchQuery, r.searchConfig} // $1 is the search query, $2 the text search configuration
+	argIndex := 3                                      // Start from $3 for filter conditions
 
+	// The ILIKE clauses act as a fallback for text the tsquery can't match,
+	// e.g. Thai titles that the configured parser doesn't tokenize.
 	conditions = append(conditions, `(
-		ps.search_vector @@ plainto_tsquery('english', $1)
+		ps.search_vector @@ plainto_tsquery($2::regconfig, $1)
+		OR ps.title ILIKE '%' || $1 || '%'
+		OR ps.description ILIKE '%' || $1 || '%'
 		OR v.name ILIKE '%' || $1 || '%'
 		OR v.location ILIKE '%' || $1 || '%'
 		OR u.first_name ILIKE '%' || $1 || '%'
@@ -228,6 +380,7 @@ func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filt
 			ps.*,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as host_name,
 			u.gender as host_gender,
 			u.play_level as host_level,
@@ -238,11 +391,11 @@ func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filt
 		JOIN users u ON u.id = ps.host_id
 		LEFT JOIN session_participants sp ON sp.session_id = ps.id
 		WHERE %s
-		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level, u.gender
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender
 		ORDER BY 
 			CASE 
-				WHEN ps.search_vector @@ plainto_tsquery('english', $1) 
-				THEN ts_rank(ps.search_vector, plainto_tsquery('english', $1))
+				WHEN ps.search_vector @@ plainto_tsquery($2::regconfig, $1) 
+				THEN ts_rank(ps.search_vector, plainto_tsquery($2::regconfig, $1))
 				ELSE 0
 			END DESC,
 			ps.session_date ASC,
@@ -262,15 +415,243 @@ func (r *sessionRepository) Search(ctx context.Context, searchQuery string, filt
 	return sessions, nil
 }
 
-func (r *sessionRepository) AddParticipant(ctx context.Context, participant *models.SessionParticipant) error {
+func (r *sessionRepository) AddParticipant(ctx context.Context, participant *models.SessionParticipant) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the session row serializes joins, so the confirmed count read
+	// below can't change before the insert commits
+	var maxParticipants int
+	if err := tx.GetContext(ctx, &maxParticipants,
+		`SELECT max_participants FROM play_sessions WHERE id = $1 FOR UPDATE`, participant.SessionID); err != nil {
+		return false, err
+	}
+
+	confirmed := 0
+	if participant.Status == models.ParticipantStatusConfirmed {
+		if err := tx.GetContext(ctx, &confirmed,
+			`SELECT COUNT(*) FROM session_participants WHERE session_id = $1 AND status = 'confirmed'`, participant.SessionID); err != nil {
+			return false, err
+		}
+		if confirmed >= maxParticipants {
+			return false, interfaces.ErrSessionFull
+		}
+	}
+
+	query := `
+		INSERT INTO session_participants (
+			id, session_id, user_id, status, joined_at, join_message
+		) VALUES (
+			:id, :session_id, :user_id, :status, :joined_at, :join_message
+		)
+		ON CONFLICT (session_id, user_id) DO NOTHING`
+
+	result, err := tx.NamedExecContext(ctx, query, participant)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	fills := participant.Status == models.ParticipantStatusConfirmed && confirmed+1 >= maxParticipants
+	statusQuery := `
+		UPDATE play_sessions SET
+			status = CASE WHEN $2 AND status = 'open' THEN 'full' ELSE status END,
+			updated_at = NOW()
+		WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, statusQuery, participant.SessionID, fills); err != nil {
+		return false, fmt.Errorf("failed to update session status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit participant: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *sessionRepository) PromoteWaitlisted(ctx context.Context, sessionID uuid.UUID) (uuid.UUID, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the session row serializes this with joins taking the free place
+	var maxParticipants int
+	if err := tx.GetContext(ctx, &maxParticipants,
+		`SELECT max_participants FROM play_sessions WHERE id = $1 FOR UPDATE`, sessionID); err != nil {
+		return uuid.Nil, false, err
+	}
+
+	var confirmed int
+	if err := tx.GetContext(ctx, &confirmed,
+		`SELECT COUNT(*) FROM session_participants WHERE session_id = $1 AND status = 'confirmed'`, sessionID); err != nil {
+		return uuid.Nil, false, err
+	}
+
+	var promoted uuid.UUID
+	if confirmed < maxParticipants {
+		query := `
+			UPDATE session_participants SET
+				status = 'confirmed',
+				joined_at = NOW()
+			WHERE id = (
+				SELECT id FROM session_participants
+				WHERE session_id = $1 AND status = 'pending'
+				ORDER BY joined_at
+				LIMIT 1
+			)
+			RETURNING user_id`
+
+		err := tx.GetContext(ctx, &promoted, query, sessionID)
+		if err != nil && err != sql.ErrNoRows {
+			return uuid.Nil, false, fmt.Errorf("failed to promote participant: %w", err)
+		}
+		if err == nil {
+			confirmed++
+		}
+	}
+
+	statusQuery := `
+		UPDATE play_sessions SET
+			status = CASE
+				WHEN status NOT IN ('open', 'full') THEN status
+				WHEN $2 THEN 'full'
+				ELSE 'open'
+			END,
+			updated_at = NOW()
+		WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, statusQuery, sessionID, confirmed >= maxParticipants); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to update session status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to commit promotion: %w", err)
+	}
+
+	return promoted, promoted != uuid.Nil, nil
+}
+
+func (r *sessionRepository) AddConfirmedParticipants(ctx context.Context, sessionID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the session row serializes this with other bulk adds
+	var maxParticipants int
+	if err := tx.GetContext(ctx, &maxParticipants,
+		`SELECT max_participants FROM play_sessions WHERE id = $1 FOR UPDATE`, sessionID); err != nil {
+		return nil, false, err
+	}
+
+	var confirmed int
+	if err := tx.GetContext(ctx, &confirmed,
+		`SELECT COUNT(*) FROM session_participants WHERE session_id = $1 AND status = 'confirmed'`, sessionID); err != nil {
+		return nil, false, err
+	}
+
 	query := `
 		INSERT INTO session_participants (
 			id, session_id, user_id, status, joined_at
 		) VALUES (
-			:id, :session_id, :user_id, :status, :joined_at
-		)`
+			$1, $2, $3, 'confirmed', NOW()
+		)
+		ON CONFLICT (session_id, user_id) DO NOTHING`
+
+	added := []uuid.UUID{}
+	for _, userID := range userIDs {
+		result, err := tx.ExecContext(ctx, query, uuid.New(), sessionID, userID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to add participant %s: %w", userID, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, false, err
+		}
+		if rows > 0 {
+			added = append(added, userID)
+		}
+	}
+
+	if confirmed+len(added) > maxParticipants {
+		return nil, false, nil
+	}
+
+	statusQuery := `
+		UPDATE play_sessions SET
+			status = CASE WHEN $2 AND status = 'open' THEN 'full' ELSE status END,
+			updated_at = NOW()
+		WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, statusQuery, sessionID, confirmed+len(added) >= maxParticipants); err != nil {
+		return nil, false, fmt.Errorf("failed to update session status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit participants: %w", err)
+	}
+
+	return added, true, nil
+}
+
+func (r *sessionRepository) CompleteSession(ctx context.Context, sessionID uuid.UUID, attended []uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the session row keeps check-ins from landing between the two updates
+	var hostID uuid.UUID
+	if err := tx.GetContext(ctx, &hostID,
+		`SELECT host_id FROM play_sessions WHERE id = $1 FOR UPDATE`, sessionID); err != nil {
+		return err
+	}
+
+	noShowQuery := `
+		UPDATE session_participants SET
+			status = 'no_show'
+		WHERE session_id = $1
+			AND status = 'confirmed'
+			AND user_id <> $2
+			AND checked_in_at IS NULL
+			AND user_id <> ALL($3::uuid[])`
+
+	if _, err := tx.ExecContext(ctx, noShowQuery, sessionID, hostID, pq.Array(attended)); err != nil {
+		return fmt.Errorf("failed to mark no-shows: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE play_sessions SET status = 'completed', updated_at = NOW() WHERE id = $1`, sessionID); err != nil {
+		return fmt.Errorf("failed to update session status: %w", err)
+	}
 
-	_, err := r.db.NamedExecContext(ctx, query, participant)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit session completion: %w", err)
+	}
+
+	return nil
+}
+
+// touchSession bumps updated_at when the participant list changes, so the
+// session detail's updated_at reflects the new counts
+func (r *sessionRepository) touchSession(ctx context.Context, sessionID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE play_sessions SET updated_at = NOW() WHERE id = $1`, sessionID)
 	return err
 }
 
@@ -301,8 +682,97 @@ func (r *sessionRepository) UpdateParticipantStatus(ctx context.Context, session
 		return fmt.Errorf("participant not found")
 	}
 
-	return nil
+	return r.touchSession(ctx, sessionID)
+}
+
+func (r *sessionRepository) CheckInParticipant(ctx context.Context, sessionID, userID uuid.UUID) error {
+	query := `
+		UPDATE session_participants SET
+			checked_in_at = COALESCE(checked_in_at, NOW())
+		WHERE session_id = $1 AND user_id = $2 AND status = 'confirmed'`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("participant not found")
+	}
+
+	return r.touchSession(ctx, sessionID)
+}
 
+// GetVenueSessionCourts lists the courts held by active sessions at a venue on a date
+func (r *sessionRepository) GetVenueSessionCourts(ctx context.Context, venueID uuid.UUID, date time.Time) ([]models.SessionCourt, error) {
+	query := `
+		SELECT sc.session_id, sc.court_id, ps.title, ps.start_time, ps.end_time
+		FROM session_courts sc
+		JOIN play_sessions ps ON ps.id = sc.session_id
+		WHERE ps.venue_id = $1
+			AND ps.session_date = $2::date
+			AND ps.status NOT IN ('cancelled', 'completed')
+		ORDER BY ps.start_time`
+
+	var courts []models.SessionCourt
+	if err := r.db.SelectContext(ctx, &courts, query, venueID, date.Format("2006-01-02")); err != nil {
+		return nil, fmt.Errorf("failed to get session courts: %w", err)
+	}
+
+	return courts, nil
+}
+
+func (r *sessionRepository) GetConflictingSessionCourts(ctx context.Context, courtIDs []uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) ([]models.SessionCourt, error) {
+	if len(courtIDs) == 0 {
+		return []models.SessionCourt{}, nil
+	}
+
+	query := `
+		SELECT sc.session_id, sc.court_id, ps.title, ps.start_time, ps.end_time
+		FROM session_courts sc
+		JOIN play_sessions ps ON ps.id = sc.session_id
+		WHERE sc.court_id = ANY($1)
+			AND ps.id <> $2
+			AND ps.session_date = $3::date
+			AND ps.status NOT IN ('cancelled', 'completed')
+			AND ps.start_time < $5::time
+			AND ps.end_time > $4::time
+		ORDER BY ps.start_time`
+
+	courts := []models.SessionCourt{}
+	err := r.db.SelectContext(ctx, &courts, query,
+		pq.Array(courtIDs),
+		excludeSessionID,
+		sessionDate.Format("2006-01-02"),
+		startTime.Format("15:04:05"),
+		endTime.Format("15:04:05"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflicting session courts: %w", err)
+	}
+
+	return courts, nil
+}
+
+// GetCourtsHourlyRate sums the hourly price of the courts reserved for a session
+func (r *sessionRepository) GetCourtsHourlyRate(ctx context.Context, sessionID uuid.UUID) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(c.price_per_hour), 0)
+		FROM session_courts sc
+		JOIN courts c ON c.id = sc.court_id
+		WHERE sc.session_id = $1`
+
+	var rate float64
+	if err := r.db.GetContext(ctx, &rate, query, sessionID); err != nil {
+		return 0, fmt.Errorf("failed to get session court rate: %w", err)
+	}
+
+	return rate, nil
 }
 
 func (r *sessionRepository) GetParticipants(ctx context.Context, sessionID uuid.UUID) ([]models.SessionParticipant, error) {
@@ -318,6 +788,98 @@ func (r *sessionRepository) GetParticipants(ctx context.Context, sessionID uuid.
 	return participants, err
 }
 
+// GetUserOverlappingSessions returns the active sessions on sessionDate that userID hosts
+// or is a pending/confirmed participant of and whose time range overlaps startTime-endTime.
+func (r *sessionRepository) GetUserOverlappingSessions(ctx context.Context, userID uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) ([]models.Session, error) {
+	query := `
+		SELECT DISTINCT ps.*
+		FROM play_sessions ps
+		LEFT JOIN session_participants sp ON sp.session_id = ps.id
+			AND sp.user_id = $1
+			AND sp.status IN ('pending', 'confirmed')
+		WHERE (ps.host_id = $1 OR sp.id IS NOT NULL)
+			AND ps.id <> $2
+			AND ps.session_date = $3::date
+			AND ps.status NOT IN ('cancelled', 'completed')
+			AND ps.start_time < $5::time
+			AND ps.end_time > $4::time
+		ORDER BY ps.start_time`
+
+	var sessions []models.Session
+	err := r.db.SelectContext(ctx, &sessions, query,
+		userID,
+		excludeSessionID,
+		sessionDate.Format("2006-01-02"),
+		startTime.Format("15:04:05"),
+		endTime.Format("15:04:05"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overlapping sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetUpcomingVenueSessions returns the open or full sessions at a venue on or after fromDate
+func (r *sessionRepository) GetUpcomingVenueSessions(ctx context.Context, venueID uuid.UUID, fromDate time.Time) ([]models.Session, error) {
+	query := `
+		SELECT ps.*
+		FROM play_sessions ps
+		WHERE ps.venue_id = $1
+			AND ps.session_date >= $2::date
+			AND ps.status IN ('open', 'full')
+		ORDER BY ps.session_date, ps.start_time`
+
+	var sessions []models.Session
+	err := r.db.SelectContext(ctx, &sessions, query, venueID, fromDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) GetUpcomingCourtSessions(ctx context.Context, courtID uuid.UUID, fromDate time.Time) ([]models.Session, error) {
+	query := `
+		SELECT ps.*
+		FROM play_sessions ps
+		JOIN session_courts sc ON sc.session_id = ps.id
+		WHERE sc.court_id = $1
+			AND ps.session_date >= $2::date
+			AND ps.status IN ('open', 'full')
+		ORDER BY ps.session_date, ps.start_time`
+
+	var sessions []models.Session
+	err := r.db.SelectContext(ctx, &sessions, query, courtID, fromDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get court sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) ReplaceSessionCourt(ctx context.Context, sessionID, oldCourtID, newCourtID uuid.UUID) error {
+	query := `
+		UPDATE session_courts
+		SET court_id = $3
+		WHERE session_id = $1 AND court_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, sessionID, oldCourtID, newCourtID)
+	if err != nil {
+		return fmt.Errorf("failed to replace session court: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("session court not found")
+	}
+
+	return nil
+}
+
 func (r *sessionRepository) GetUserSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]models.SessionDetail, error) {
 	conditions := []string{
 		"(ps.host_id = $1 OR sp.user_id = $1)",
@@ -332,6 +894,7 @@ func (r *sessionRepository) GetUserSessions(ctx context.Context, userID uuid.UUI
 			ps.*,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as host_name,
 			u.gender as host_gender,
 			u.play_level as host_level,
@@ -343,7 +906,7 @@ func (r *sessionRepository) GetUserSessions(ctx context.Context, userID uuid.UUI
 		LEFT JOIN session_participants sp ON sp.session_id = ps.id
 		LEFT JOIN session_participants sp2 ON sp2.session_id = ps.id
 		WHERE %s
-		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level, u.gender
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender
 		ORDER BY ps.session_date DESC, ps.start_time DESC`,
 		strings.Join(conditions, " AND "),
 	)
@@ -366,6 +929,7 @@ func (r *sessionRepository) GetMyJoinedSessions(ctx context.Context, userID uuid
 			ps.*,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as host_name,
 			u.gender as host_gender,
 			u.play_level as host_level,
@@ -377,7 +941,7 @@ func (r *sessionRepository) GetMyJoinedSessions(ctx context.Context, userID uuid
 		LEFT JOIN session_participants sp ON sp.session_id = ps.id
 		LEFT JOIN session_participants sp2 ON sp2.session_id = ps.id
 		WHERE %s
-		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level, u.gender
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender
 		ORDER BY ps.session_date DESC, ps.start_time DESC`,
 		strings.Join(conditions, " AND "),
 	)
@@ -401,6 +965,7 @@ func (r *sessionRepository) GetMyHostedSessions(ctx context.Context, userID uuid
 			ps.*,
 			v.name as venue_name,
 			v.location as venue_location,
+			v.timezone as venue_timezone,
 			u.first_name || ' ' || u.last_name as host_name,
 			u.gender as host_gender,
 			u.play_level as host_level,
@@ -411,7 +976,7 @@ func (r *sessionRepository) GetMyHostedSessions(ctx context.Context, userID uuid
 		JOIN users u ON u.id = ps.host_id
 		LEFT JOIN session_participants sp ON sp.session_id = ps.id
 		WHERE %s
-		GROUP BY ps.id, v.name, v.location, u.first_name, u.last_name, u.play_level, u.gender
+		GROUP BY ps.id, v.name, v.location, v.timezone, u.first_name, u.last_name, u.play_level, u.gender
 		ORDER BY ps.session_date DESC, ps.start_time DESC`,
 		strings.Join(conditions, " AND "),
 	)
@@ -201,6 +201,10 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*mod
                     AND ps.host_id != u.id
                 ) as joined_sessions,
                 
+                COUNT(DISTINCT sp.session_id) FILTER (
+                    WHERE sp.status = 'no_show'
+                ) as no_show_sessions,
+                
                 COALESCE(AVG(pr.rating), 0) as avg_rating,
                 
                 COUNT(DISTINCT pr.id) as total_reviews,
@@ -237,6 +241,121 @@ func (r *userRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*mod
 	return &profile, nil
 }
 
+// leaderboardQuery computes the profile's hosted session and review counts, and
+// the sessions each user has played in, for every user that isn't inactive,
+// filtered to those ranked by the metric
+func leaderboardQuery(filters interfaces.LeaderboardFilters) (string, []interface{}, error) {
+	var metric string
+	switch filters.Metric {
+	case models.LeaderboardMetricSessionsPlayed:
+		metric = "hosted_sessions + joined_sessions"
+	case models.LeaderboardMetricHosted:
+		metric = "hosted_sessions"
+	case models.LeaderboardMetricRating:
+		metric = "avg_rating"
+	default:
+		return "", nil, fmt.Errorf("unknown leaderboard metric %q", filters.Metric)
+	}
+
+	query := `
+		WITH user_stats AS (
+			SELECT
+				u.id, u.first_name, u.last_name, u.avatar_url, u.location, u.play_level, u.created_at,
+				(
+					SELECT COUNT(*) FROM play_sessions ps
+					WHERE ps.host_id = u.id AND ps.status != 'cancelled'
+				) as hosted_sessions,
+				(
+					-- Sessions the user actually played in: confirmed, and either
+					-- completed or past their end time in the venue's zone
+					SELECT COUNT(DISTINCT sp.session_id) FROM session_participants sp
+					JOIN play_sessions ps ON ps.id = sp.session_id
+					JOIN venues v ON v.id = ps.venue_id
+					WHERE sp.user_id = u.id AND sp.status = 'confirmed' AND ps.host_id != u.id
+					AND (
+						ps.status = 'completed'
+						OR (ps.status != 'cancelled' AND (ps.session_date::date + ps.end_time::time) AT TIME ZONE v.timezone < NOW())
+					)
+				) as joined_sessions,
+				COALESCE((SELECT AVG(pr.rating) FROM player_reviews pr WHERE pr.reviewed_id = u.id), 0) as avg_rating,
+				(SELECT COUNT(*) FROM player_reviews pr WHERE pr.reviewed_id = u.id) as total_reviews
+			FROM users u
+			WHERE u.status != $1`
+
+	args := []interface{}{models.UserStatusInactive}
+	if filters.Location != "" {
+		query += ` AND u.location = $2`
+		args = append(args, filters.Location)
+	}
+
+	query += fmt.Sprintf(`
+		)
+		SELECT *, %s as metric FROM user_stats
+		WHERE %s > 0`, metric, metric)
+
+	if filters.Metric == models.LeaderboardMetricRating {
+		query += fmt.Sprintf(` AND total_reviews >= %d`, filters.MinReviews)
+	}
+
+	return query, args, nil
+}
+
+// GetLeaderboard ranks users by the metric, breaking ties by review count and
+// then by who joined first
+func (r *userRepository) GetLeaderboard(ctx context.Context, filters interfaces.LeaderboardFilters) ([]models.LeaderboardEntry, error) {
+	query, args, err := leaderboardQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, first_name, last_name, avatar_url, location, play_level,
+			hosted_sessions, joined_sessions, avg_rating, total_reviews
+		FROM (%s) ranked
+		ORDER BY metric DESC, total_reviews DESC, created_at, id
+		LIMIT $%d OFFSET $%d`, query, len(args)+1, len(args)+2)
+	args = append(args, filters.Limit, filters.Offset)
+
+	entries := []models.LeaderboardEntry{}
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *userRepository) CountLeaderboard(ctx context.Context, filters interfaces.LeaderboardFilters) (int, error) {
+	query, args, err := leaderboardQuery(filters)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, fmt.Sprintf(`SELECT COUNT(*) FROM (%s) ranked`, query), args...); err != nil {
+		return 0, fmt.Errorf("failed to count leaderboard: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2`, passwordHash, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (r *userRepository) UpdateLastActive(ctx context.Context, userID uuid.UUID) error {
 	result, err := r.db.ExecContext(ctx, `
         UPDATE users 
@@ -354,3 +473,66 @@ func (r *userRepository) IsUserExist(ctx context.Context, userID uuid.UUID) (boo
 
 	return count > 0, nil
 }
+
+func (r *userRepository) CreateEmailVerification(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only the latest email's link works
+	if _, err := tx.ExecContext(ctx, `DELETE FROM email_verification_tokens WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete email verifications: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO email_verification_tokens (token_hash, user_id, expires_at)
+		VALUES ($1, $2, $3)`,
+		tokenHash, userID, expiresAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create email verification: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the token's user as verified and drops all of their tokens.
+// It returns false when the token is unknown or has expired.
+func (r *userRepository) VerifyEmail(ctx context.Context, tokenHash string) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID uuid.UUID
+	err = tx.GetContext(ctx, &userID, `
+		SELECT user_id FROM email_verification_tokens
+		WHERE token_hash = $1 AND expires_at > NOW()`,
+		tokenHash)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get email verification: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET email_verified = true WHERE id = $1`, userID); err != nil {
+		return false, fmt.Errorf("failed to verify email: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM email_verification_tokens WHERE user_id = $1`, userID); err != nil {
+		return false, fmt.Errorf("failed to delete email verifications: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
@@ -7,12 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"badbuddy/internal/domain/models"
 	"badbuddy/internal/repositories/interfaces"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type venueRepository struct {
@@ -26,22 +28,25 @@ func NewVenueRepository(db *sqlx.DB) interfaces.VenueRepository {
 }
 
 func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error {
-	// First check if venue with same name exists
+	// First check if a live venue with the same name exists in the same location.
+	// Deleted venues don't count, and different cities may share a name.
 	checkQuery := `
         SELECT EXISTS (
             SELECT 1 FROM venues 
             WHERE LOWER(name) = LOWER($1)
+            AND LOWER(location) = LOWER($2)
+            AND deleted_at IS NULL
         )
     `
 
 	var exists bool
-	err := r.db.GetContext(ctx, &exists, checkQuery, venue.Name)
+	err := r.db.GetContext(ctx, &exists, checkQuery, venue.Name, venue.Location)
 	if err != nil {
 		return fmt.Errorf("failed to check venue name: %w", err)
 	}
 
 	if exists {
-		return fmt.Errorf("venue with name '%s' already exists", venue.Name)
+		return fmt.Errorf("venue with name '%s' already exists in %s", venue.Name, venue.Location)
 	}
 
 	venueInsert := models.VenueInsert{
@@ -66,6 +71,7 @@ func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error
 		Facilities:    venue.Facilities,
 		Latitude:      venue.Latitude,
 		Longitude:     venue.Longitude,
+		Timezone:      venue.Timezone,
 	}
 
 	// If no duplicate, proceed with insert
@@ -73,11 +79,11 @@ func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error
         INSERT INTO venues (
             id, name, description, address, location, phone, email,
             open_range, image_urls, status, rating,
-            total_reviews, owner_id, created_at, updated_at, rules, latitude, longitude
+            total_reviews, owner_id, created_at, updated_at, rules, latitude, longitude, timezone
         ) VALUES (
             safe_generate_uuid(), :name, :description, :address, :location, :phone, :email,
             :open_range, :image_urls, :status, :rating,
-            :total_reviews, :owner_id, :created_at, :updated_at, :rules, :latitude, :longitude
+            :total_reviews, :owner_id, :created_at, :updated_at, :rules, :latitude, :longitude, :timezone
         )
         RETURNING *
     `
@@ -101,6 +107,12 @@ func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error
 }
 
 // You might want to create custom errors for better error handling
+// ErrDuplicateReview and ErrReviewTooSoon are the interfaces errors AddReview returns
+var (
+	ErrDuplicateReview = interfaces.ErrDuplicateReview
+	ErrReviewTooSoon   = interfaces.ErrReviewTooSoon
+)
+
 type ErrDuplicateVenue struct {
 	Name string
 }
@@ -144,12 +156,17 @@ func (r *venueRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Ve
 	courtsQuery := `
 		SELECT * FROM courts 
 		WHERE venue_id = $1 AND deleted_at IS NULL 
-		ORDER BY created_at`
+		ORDER BY display_order, created_at`
 	err = r.db.SelectContext(ctx, &result.Courts, courtsQuery, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get courts: %w", err)
 	}
 
+	result.Venue.Tags, err = r.GetTags(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -170,6 +187,7 @@ func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error
 		"rules":       venue.Rules.RawMessage,
 		"latitude":    venue.Latitude,
 		"longitude":   venue.Longitude,
+		"timezone":    venue.Timezone,
 	}
 
 	query := `
@@ -186,7 +204,8 @@ func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error
 			updated_at = :updated_at,
 			rules = :rules,
 			latitude = :latitude,
-			longitude = :longitude
+			longitude = :longitude,
+			timezone = :timezone
 		WHERE id = :id AND deleted_at IS NULL`
 
 	result, err := r.db.NamedExecContext(ctx, query, params)
@@ -206,6 +225,98 @@ func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error
 	return nil
 }
 
+// GetByIDIncludingDeleted looks up a venue even if it has been soft-deleted
+func (r *venueRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*models.Venue, error) {
+	var venue models.Venue
+	err := r.db.GetContext(ctx, &venue, `SELECT * FROM venues WHERE id = $1`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("venue not found")
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	return &venue, nil
+}
+
+// Restore clears deleted_at on a soft-deleted venue
+func (r *venueRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE venues
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore venue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("deleted venue not found")
+	}
+
+	return nil
+}
+
+// SetFeatured promotes or demotes a venue. A nil until keeps it featured indefinitely.
+func (r *venueRepository) SetFeatured(ctx context.Context, id uuid.UUID, featured bool, until *time.Time) error {
+	query := `
+		UPDATE venues
+		SET featured = $2, featured_until = $3, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	if !featured {
+		until = nil
+	}
+
+	result, err := r.db.ExecContext(ctx, query, id, featured, until)
+	if err != nil {
+		return fmt.Errorf("failed to update featured flag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("venue not found")
+	}
+
+	return nil
+}
+
+// ListFeatured returns the venues whose promotion is currently active
+func (r *venueRepository) ListFeatured(ctx context.Context, limit, offset int) ([]models.Venue, error) {
+	query := `
+		SELECT v.* FROM venues v
+		WHERE v.deleted_at IS NULL
+			AND v.status = 'active'
+			AND ` + venueFeaturedExpr + `
+		ORDER BY v.rating DESC, v.total_reviews DESC, v.created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	venues := []models.Venue{}
+	if err := r.db.SelectContext(ctx, &venues, query, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list featured venues: %w", err)
+	}
+
+	for i := range venues {
+		tags, err := r.GetTags(ctx, venues[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		venues[i].Tags = tags
+	}
+
+	return venues, nil
+}
+
 func (r *venueRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE venues 
@@ -229,7 +340,9 @@ func (r *venueRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *venueRepository) List(ctx context.Context, location string, limit, offset int) ([]models.Venue, error) {
+func (r *venueRepository) List(ctx context.Context, filters interfaces.VenueListFilters, limit, offset int) ([]models.Venue, error) {
+	tagCondition, tagArgs := venueTagCondition(filters.Tags, 6)
+
 	query := `
 		SELECT 
 			v.id, v.name, v.description, v.address, v.location, v.phone, v.email,
@@ -240,7 +353,9 @@ func (r *venueRepository) List(ctx context.Context, location string, limit, offs
 			) FILTER (WHERE f.id IS NOT NULL), '[]') AS facilities,
 			COALESCE(json_agg(
 				json_build_object('id', c.id, 'name', c.name, 'description', c.description, 'price_per_hour', c.price_per_hour, 'status', c.status)
-			) FILTER (WHERE c.id IS NOT NULL), '[]') AS courts
+			) FILTER (WHERE c.id IS NOT NULL), '[]') AS courts,
+			` + venueTagsColumn + `,
+			` + venueFeaturedExpr + ` AS featured
 		FROM 
 			venues v
 		LEFT JOIN 
@@ -252,13 +367,22 @@ func (r *venueRepository) List(ctx context.Context, location string, limit, offs
 		WHERE 
 			v.deleted_at IS NULL
 			AND ($1 = '' OR v.location = $1)
+			AND ($4 = '' OR v.status::text = $4)
+			AND ($5::uuid IS NULL OR v.owner_id = $5)
+			` + tagCondition + `
 		GROUP BY 
 			v.id
 		ORDER BY 
-			v.rating DESC, v.total_reviews DESC, v.created_at DESC
+			` + venueFeaturedExpr + ` DESC, v.rating DESC, v.total_reviews DESC, v.created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, location, limit, offset)
+	var ownerID interface{}
+	if filters.OwnerID != uuid.Nil {
+		ownerID = filters.OwnerID
+	}
+
+	args := append([]interface{}{filters.Location, limit, offset, string(filters.Status), ownerID}, tagArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list venues: %w", err)
 	}
@@ -269,13 +393,14 @@ func (r *venueRepository) List(ctx context.Context, location string, limit, offs
 		var venue models.Venue
 		var facilitiesJSON []byte
 		var courtsJSON []byte
+		var tagsJSON []byte
 
 		err := rows.Scan(
 			&venue.ID, &venue.Name, &venue.Description, &venue.Address, &venue.Location,
 			&venue.Phone, &venue.Email, &venue.OpenRange, &venue.ImageURLs,
 			&venue.Status, &venue.Rating, &venue.TotalReviews, &venue.OwnerID,
 			&venue.CreatedAt, &venue.UpdatedAt, &venue.Search_vector, &venue.Rules,
-			&facilitiesJSON, &courtsJSON,
+			&facilitiesJSON, &courtsJSON, &tagsJSON, &venue.Featured,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan venue: %w", err)
@@ -293,6 +418,11 @@ func (r *venueRepository) List(ctx context.Context, location string, limit, offs
 			return nil, fmt.Errorf("failed to unmarshal courts for venue %s: %w", venue.ID, err)
 		}
 
+		err = json.Unmarshal(tagsJSON, &venue.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags for venue %s: %w", venue.ID, err)
+		}
+
 		venues = append(venues, venue)
 	}
 
@@ -317,12 +447,13 @@ func (r *venueRepository) CountVenues(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-func (r *venueRepository) Search(ctx context.Context, query string, limit, offset int, minPrice, maxPrice int, location string, facilities []string) ([]models.Venue, error) {
+func (r *venueRepository) Search(ctx context.Context, query string, limit, offset int, minPrice, maxPrice int, location string, facilities []string, tagFilter interfaces.VenueTagFilter) ([]models.Venue, error) {
 	searchQuery := `
 			SELECT 
 				v.id, v.name, v.description, v.address, v.location, v.phone, v.email,
 				v.open_range, v.image_urls, v.status, v.rating, v.total_reviews, v.owner_id,
-				v.created_at, v.updated_at, v.rules, v.latitude, v.longitude,
+				v.created_at, v.updated_at, v.rules, v.latitude, v.longitude, v.timezone,
+				v.featured, v.featured_until,
 				COALESCE(
 					(
 						SELECT json_agg(json_build_object('id', unique_facilities.id, 'name', unique_facilities.name))
@@ -349,7 +480,13 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 							WHERE c.venue_id = v.id
 						) AS unique_courts
 					), '[]'
-				) AS courts
+				) AS courts,
+				` + venueTagsColumn + `,
+				CASE
+					WHEN v.search_vector @@ plainto_tsquery($1)
+					THEN ts_rank(v.search_vector, plainto_tsquery($1))
+					ELSE 0
+				END AS relevance
 			FROM 
 				venues v
 			WHERE 
@@ -383,12 +520,16 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 		searchQuery += " " + facilitiesCondition
 	}
 
-	// Close the query with GROUP BY and ORDER BY clauses
+	tagCondition, tagArgs := venueTagCondition(tagFilter, 7+len(facilities))
+	searchQuery += " " + tagCondition
+
+	// Close the query with GROUP BY and ORDER BY clauses; the best text match
+	// ranks first and rating only breaks ties
 	searchQuery += `
 		GROUP BY 
 			v.id
 		ORDER BY 
-			v.rating DESC, v.total_reviews DESC, v.created_at DESC
+			relevance DESC, v.rating DESC, v.total_reviews DESC, v.created_at DESC
 		LIMIT $5 OFFSET $6`
 
 	// Prepare parameters, including facilities
@@ -396,6 +537,7 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 	for _, facility := range facilities {
 		params = append(params, facility)
 	}
+	params = append(params, tagArgs...)
 
 	// Execute the query
 	rows, err := r.db.QueryContext(ctx, searchQuery, params...)
@@ -409,14 +551,16 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 		var venue models.Venue
 		var facilitiesJSON []byte
 		var courtsJSON []byte
+		var tagsJSON []byte
 
 		// Scan venue fields, then the aggregated JSON for facilities
 		err := rows.Scan(
 			&venue.ID, &venue.Name, &venue.Description, &venue.Address, &venue.Location,
 			&venue.Phone, &venue.Email, &venue.OpenRange, &venue.ImageURLs,
 			&venue.Status, &venue.Rating, &venue.TotalReviews, &venue.OwnerID,
-			&venue.CreatedAt, &venue.UpdatedAt, &venue.Rules, &venue.Latitude, &venue.Longitude,
-			&facilitiesJSON, &courtsJSON,
+			&venue.CreatedAt, &venue.UpdatedAt, &venue.Rules, &venue.Latitude, &venue.Longitude, &venue.Timezone,
+			&venue.Featured, &venue.FeaturedUntil,
+			&facilitiesJSON, &courtsJSON, &tagsJSON, &venue.Relevance,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan venue: %w", err)
@@ -434,6 +578,11 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 			return nil, fmt.Errorf("failed to unmarshal courts for venue %s: %w", venue.ID, err)
 		}
 
+		err = json.Unmarshal(tagsJSON, &venue.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags for venue %s: %w", venue.ID, err)
+		}
+
 		venues = append(venues, venue)
 	}
 
@@ -444,7 +593,7 @@ func (r *venueRepository) Search(ctx context.Context, query string, limit, offse
 	return venues, nil
 }
 
-func (r *venueRepository) CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string) (int, error) {
+func (r *venueRepository) CountSearch(ctx context.Context, query string, minPrice, maxPrice int, location string, facilities []string, tagFilter interfaces.VenueTagFilter) (int, error) {
 	countQuery := `
 		SELECT 
 			COUNT(DISTINCT v.id)
@@ -479,11 +628,15 @@ func (r *venueRepository) CountSearch(ctx context.Context, query string, minPric
 		countQuery += " " + facilitiesCondition
 	}
 
+	tagCondition, tagArgs := venueTagCondition(tagFilter, 5+len(facilities))
+	countQuery += " " + tagCondition
+
 	// Prepare parameters, including facilities
 	params := []interface{}{query, location, minPrice, maxPrice}
 	for _, facility := range facilities {
 		params = append(params, facility)
 	}
+	params = append(params, tagArgs...)
 
 	// Execute the count query
 	var count int
@@ -499,10 +652,12 @@ func (r *venueRepository) AddCourt(ctx context.Context, court *models.Court) err
 	query := `
 		INSERT INTO courts (
 			id, venue_id, name, description, price_per_hour,
-			status, created_at, updated_at
+			max_players, status, display_order, created_at, updated_at
 		) VALUES (
 			:id, :venue_id, :name, :description, :price_per_hour,
-			:status, :created_at, :updated_at
+			:max_players, :status,
+			(SELECT COALESCE(MAX(display_order), 0) + 1 FROM courts WHERE venue_id = :venue_id),
+			:created_at, :updated_at
 		)`
 
 	_, err := r.db.NamedExecContext(ctx, query, court)
@@ -519,6 +674,7 @@ func (r *venueRepository) UpdateCourt(ctx context.Context, court *models.Court)
 			name = :name,
 			description = :description,
 			price_per_hour = :price_per_hour,
+			max_players = :max_players,
 			status = :status,
 			updated_at = :updated_at
 		WHERE id = :id AND deleted_at IS NULL`
@@ -567,7 +723,7 @@ func (r *venueRepository) GetCourts(ctx context.Context, venueID uuid.UUID) ([]m
 	query := `
 		SELECT * FROM courts 
 		WHERE venue_id = $1 AND deleted_at IS NULL 
-		ORDER BY created_at`
+		ORDER BY display_order, created_at`
 
 	courts := []models.Court{}
 	err := r.db.SelectContext(ctx, &courts, query, venueID)
@@ -578,9 +734,59 @@ func (r *venueRepository) GetCourts(ctx context.Context, venueID uuid.UUID) ([]m
 	return courts, nil
 }
 
-func (r *venueRepository) AddReview(ctx context.Context, review *models.VenueReview) error {
+// ReorderCourts numbers the venue's courts in the given order in a single
+// statement, so readers never see a half-applied order
+func (r *venueRepository) ReorderCourts(ctx context.Context, venueID uuid.UUID, courtIDs []uuid.UUID) error {
+	query := `
+		UPDATE courts SET
+			display_order = array_position($2::uuid[], id),
+			updated_at = NOW()
+		WHERE venue_id = $1 AND id = ANY($2::uuid[]) AND deleted_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, venueID, pq.Array(courtIDs)); err != nil {
+		return fmt.Errorf("failed to reorder courts: %w", err)
+	}
+
+	return nil
+}
+
+// AddReview inserts the review and recomputes the venue rating in one transaction,
+// so the rating and review count never disagree with the reviews table
+func (r *venueRepository) AddReview(ctx context.Context, review *models.VenueReview, cooldown time.Duration) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the reviewer so their concurrent reviews of different venues can't
+	// both pass the cooldown check
+	var reviewerID uuid.UUID
+	if err := tx.GetContext(ctx, &reviewerID, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, review.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user not found")
+		}
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	var lastReviewAt *time.Time
+	if err := tx.GetContext(ctx, &lastReviewAt, `SELECT MAX(created_at) FROM venue_reviews WHERE user_id = $1`, review.UserID); err != nil {
+		return fmt.Errorf("failed to get last review: %w", err)
+	}
+	if lastReviewAt != nil && review.CreatedAt.Sub(*lastReviewAt) < cooldown {
+		return ErrReviewTooSoon
+	}
+
+	// Then lock the venue row: concurrent reviews then recompute the rating one
+	// after another, each seeing the reviews committed before it
+	var lockedID uuid.UUID
+	if err := tx.GetContext(ctx, &lockedID, `SELECT id FROM venues WHERE id = $1 FOR UPDATE`, review.VenueID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("venue not found")
+		}
+		return fmt.Errorf("failed to lock venue: %w", err)
+	}
 
-	// Insert review
 	query := `
 		INSERT INTO venue_reviews (
 			id, venue_id, user_id, rating, comment, created_at
@@ -588,34 +794,80 @@ func (r *venueRepository) AddReview(ctx context.Context, review *models.VenueRev
 			:id, :venue_id, :user_id, :rating, :comment, :created_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, review)
-	if err != nil {
+	if _, err := tx.NamedExecContext(ctx, query, review); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return ErrDuplicateReview
+		}
 		return fmt.Errorf("failed to add review: %w", err)
 	}
 
-	fmt.Println(review)
+	if err := updateVenueRating(ctx, tx, review.VenueID); err != nil {
+		return err
+	}
 
-	// Update venue rating
-	err = r.UpdateVenueRating(ctx, review.VenueID)
-	if err != nil {
-		return fmt.Errorf("failed to update venue rating: %w", err)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review: %w", err)
 	}
 
 	return nil
 }
 
-func (r *venueRepository) GetReviews(ctx context.Context, venueID uuid.UUID, limit, offset int) ([]models.VenueReview, error) {
-	query := `
-		SELECT vr.*, 
-			u.id as user_id
+// HasUserReviewed reports whether the user already reviewed the venue
+func (r *venueRepository) HasUserReviewed(ctx context.Context, venueID, userID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM venue_reviews WHERE venue_id = $1 AND user_id = $2)`
+	if err := r.db.GetContext(ctx, &exists, query, venueID, userID); err != nil {
+		return false, fmt.Errorf("failed to check review: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetLastReviewAt returns when the user last reviewed any venue, or nil if never
+func (r *venueRepository) GetLastReviewAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	var lastReviewAt *time.Time
+	query := `SELECT MAX(created_at) FROM venue_reviews WHERE user_id = $1`
+	if err := r.db.GetContext(ctx, &lastReviewAt, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get last review: %w", err)
+	}
+
+	return lastReviewAt, nil
+}
+
+// reviewColumns selects a venue_reviews row aliased vr together with its helpful votes
+const reviewColumns = `
+	vr.id, vr.venue_id, vr.user_id, vr.rating, COALESCE(vr.comment, '') AS comment,
+	vr.created_at, vr.updated_at, vr.owner_response, vr.owner_responded_at,
+	(SELECT COUNT(*) FROM review_votes rv WHERE rv.review_id = vr.id) AS helpful_count`
+
+func (r *venueRepository) GetReviews(ctx context.Context, venueID uuid.UUID, filters interfaces.ReviewListFilters, limit, offset int) ([]models.VenueReview, error) {
+	orderBy := "vr.created_at DESC, vr.id"
+	if filters.SortByHelpful {
+		orderBy = "helpful_count DESC, " + orderBy
+	}
+
+	conditions := []string{"vr.venue_id = $1"}
+	args := []interface{}{venueID}
+	if filters.MinRating > 0 {
+		args = append(args, filters.MinRating)
+		conditions = append(conditions, fmt.Sprintf("vr.rating >= $%d", len(args)))
+	}
+	if filters.MaxRating > 0 {
+		args = append(args, filters.MaxRating)
+		conditions = append(conditions, fmt.Sprintf("vr.rating <= $%d", len(args)))
+	}
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT `+reviewColumns+`
 		FROM venue_reviews vr
 		JOIN users u ON u.id = vr.user_id
-		WHERE vr.venue_id = $1
-		ORDER BY vr.created_at DESC
-		LIMIT $2 OFFSET $3`
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, strings.Join(conditions, " AND "), orderBy, len(args)-1, len(args))
 
 	reviews := []models.VenueReview{}
-	err := r.db.SelectContext(ctx, &reviews, query, venueID, limit, offset)
+	err := r.db.SelectContext(ctx, &reviews, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviews: %w", err)
 	}
@@ -623,7 +875,339 @@ func (r *venueRepository) GetReviews(ctx context.Context, venueID uuid.UUID, lim
 	return reviews, nil
 }
 
+func (r *venueRepository) GetRatingCounts(ctx context.Context, venueID uuid.UUID) (map[int]int, error) {
+	query := `
+		SELECT rating, COUNT(*) AS count
+		FROM venue_reviews
+		WHERE venue_id = $1
+		GROUP BY rating`
+
+	var rows []struct {
+		Rating int `db:"rating"`
+		Count  int `db:"count"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, venueID); err != nil {
+		return nil, fmt.Errorf("failed to get rating counts: %w", err)
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.Rating] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (r *venueRepository) GetUserVenueReview(ctx context.Context, venueID, userID uuid.UUID) (*models.VenueReview, error) {
+	query := `SELECT ` + reviewColumns + ` FROM venue_reviews vr WHERE vr.venue_id = $1 AND vr.user_id = $2`
+
+	var review models.VenueReview
+	if err := r.db.GetContext(ctx, &review, query, venueID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return &review, nil
+}
+
+func (r *venueRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*models.VenueReview, error) {
+	query := `SELECT ` + reviewColumns + ` FROM venue_reviews vr WHERE vr.id = $1`
+
+	var review models.VenueReview
+	if err := r.db.GetContext(ctx, &review, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("review not found")
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return &review, nil
+}
+
+func (r *venueRepository) ToggleReviewVote(ctx context.Context, reviewID, userID uuid.UUID) (bool, int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM review_votes WHERE review_id = $1 AND user_id = $2`, reviewID, userID)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to remove vote: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	voted := rows == 0
+	if voted {
+		query := `
+			INSERT INTO review_votes (review_id, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (review_id, user_id) DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, reviewID, userID); err != nil {
+			return false, 0, fmt.Errorf("failed to add vote: %w", err)
+		}
+	}
+
+	var count int
+	if err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM review_votes WHERE review_id = $1`, reviewID); err != nil {
+		return false, 0, fmt.Errorf("failed to count votes: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, 0, fmt.Errorf("failed to commit vote: %w", err)
+	}
+
+	return voted, count, nil
+}
+
+func (r *venueRepository) SetReviewResponse(ctx context.Context, reviewID uuid.UUID, response string, respondedAt time.Time) error {
+	query := `
+		UPDATE venue_reviews
+		SET owner_response = $2, owner_responded_at = $3
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, reviewID, response, respondedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set review response: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("review not found")
+	}
+
+	return nil
+}
+
+func (r *venueRepository) GetUserReviews(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.VenueReview, error) {
+	query := `
+		SELECT id, venue_id, user_id, rating, COALESCE(comment, '') AS comment, created_at, updated_at
+		FROM venue_reviews
+		WHERE user_id = $1
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2 OFFSET $3`
+
+	reviews := []models.VenueReview{}
+	err := r.db.SelectContext(ctx, &reviews, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *venueRepository) CreateClaim(ctx context.Context, claim *models.VenueClaim) error {
+	query := `
+		INSERT INTO venue_claims (
+			id, venue_id, claimant_id, phone, evidence, status, created_at
+		) VALUES (
+			:id, :venue_id, :claimant_id, :phone, :evidence, :status, :created_at
+		)`
+
+	if _, err := r.db.NamedExecContext(ctx, query, claim); err != nil {
+		return fmt.Errorf("failed to create claim: %w", err)
+	}
+
+	return nil
+}
+
+func (r *venueRepository) GetClaim(ctx context.Context, id uuid.UUID) (*models.VenueClaim, error) {
+	var claim models.VenueClaim
+	if err := r.db.GetContext(ctx, &claim, `SELECT * FROM venue_claims WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("claim not found")
+		}
+		return nil, fmt.Errorf("failed to get claim: %w", err)
+	}
+
+	return &claim, nil
+}
+
+func (r *venueRepository) HasPendingClaim(ctx context.Context, venueID, claimantID uuid.UUID) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM venue_claims WHERE venue_id = $1 AND claimant_id = $2 AND status = 'pending')`
+	if err := r.db.GetContext(ctx, &exists, query, venueID, claimantID); err != nil {
+		return false, fmt.Errorf("failed to check claim: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *venueRepository) ListClaims(ctx context.Context, status models.VenueClaimStatus, limit, offset int) ([]models.VenueClaim, error) {
+	query := `
+		SELECT * FROM venue_claims
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3`
+
+	claims := []models.VenueClaim{}
+	if err := r.db.SelectContext(ctx, &claims, query, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+func (r *venueRepository) CountClaims(ctx context.Context, status models.VenueClaimStatus) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM venue_claims WHERE $1 = '' OR status = $1`
+	if err := r.db.GetContext(ctx, &count, query, status); err != nil {
+		return 0, fmt.Errorf("failed to count claims: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *venueRepository) ReviewClaim(ctx context.Context, id uuid.UUID, status models.VenueClaimStatus, reviewedBy uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var claim models.VenueClaim
+	query := `SELECT * FROM venue_claims WHERE id = $1 AND status = 'pending' FOR UPDATE`
+	if err := tx.GetContext(ctx, &claim, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("pending claim not found")
+		}
+		return fmt.Errorf("failed to get claim: %w", err)
+	}
+
+	if status == models.VenueClaimStatusApproved {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE venues
+			SET owner_id = $2, updated_at = NOW()
+			WHERE id = $1 AND owner_id IS NULL AND deleted_at IS NULL`,
+			claim.VenueID, claim.ClaimantID)
+		if err != nil {
+			return fmt.Errorf("failed to assign venue owner: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("venue already has an owner")
+		}
+
+		// The venue is taken, so nobody else's claim on it can succeed
+		_, err = tx.ExecContext(ctx, `
+			UPDATE venue_claims
+			SET status = 'rejected', reviewed_by = $3, reviewed_at = NOW()
+			WHERE venue_id = $1 AND id <> $2 AND status = 'pending'`,
+			claim.VenueID, claim.ID, reviewedBy)
+		if err != nil {
+			return fmt.Errorf("failed to reject other claims: %w", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE venue_claims
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $1`,
+		id, status, reviewedBy)
+	if err != nil {
+		return fmt.Errorf("failed to review claim: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit claim review: %w", err)
+	}
+
+	return nil
+}
+
+func (r *venueRepository) AddFavorite(ctx context.Context, userID, venueID uuid.UUID) error {
+	query := `
+		INSERT INTO user_favorite_venues (user_id, venue_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, venue_id) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, venueID); err != nil {
+		return fmt.Errorf("failed to add favorite: %w", err)
+	}
+
+	return nil
+}
+
+func (r *venueRepository) RemoveFavorite(ctx context.Context, userID, venueID uuid.UUID) error {
+	query := `DELETE FROM user_favorite_venues WHERE user_id = $1 AND venue_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, userID, venueID); err != nil {
+		return fmt.Errorf("failed to remove favorite: %w", err)
+	}
+
+	return nil
+}
+
+func (r *venueRepository) ListFavorites(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Venue, error) {
+	query := `
+		SELECT v.* FROM venues v
+		JOIN user_favorite_venues f ON f.venue_id = v.id
+		WHERE f.user_id = $1 AND v.deleted_at IS NULL
+		ORDER BY f.created_at DESC, v.id
+		LIMIT $2 OFFSET $3`
+
+	venues := []models.Venue{}
+	if err := r.db.SelectContext(ctx, &venues, query, userID, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list favorite venues: %w", err)
+	}
+
+	for i := range venues {
+		tags, err := r.GetTags(ctx, venues[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		venues[i].Tags = tags
+	}
+
+	return venues, nil
+}
+
+func (r *venueRepository) CountFavorites(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*) FROM user_favorite_venues f
+		JOIN venues v ON v.id = f.venue_id
+		WHERE f.user_id = $1 AND v.deleted_at IS NULL`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count favorite venues: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *venueRepository) GetFavoritedVenueIDs(ctx context.Context, userID uuid.UUID, venueIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	favorited := make(map[uuid.UUID]bool)
+	if len(venueIDs) == 0 {
+		return favorited, nil
+	}
+
+	ids := []uuid.UUID{}
+	query := `SELECT venue_id FROM user_favorite_venues WHERE user_id = $1 AND venue_id = ANY($2)`
+	if err := r.db.SelectContext(ctx, &ids, query, userID, pq.Array(venueIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get favorite venues: %w", err)
+	}
+
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
+
 func (r *venueRepository) UpdateVenueRating(ctx context.Context, venueID uuid.UUID) error {
+	return updateVenueRating(ctx, r.db, venueID)
+}
+
+func updateVenueRating(ctx context.Context, exec sqlx.ExecerContext, venueID uuid.UUID) error {
 	query := `
 		UPDATE venues 
 		SET 
@@ -640,7 +1224,7 @@ func (r *venueRepository) UpdateVenueRating(ctx context.Context, venueID uuid.UU
 			updated_at = NOW()
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, venueID)
+	result, err := exec.ExecContext(ctx, query, venueID)
 	if err != nil {
 		return fmt.Errorf("failed to update venue rating: %w", err)
 	}
@@ -713,3 +1297,96 @@ func (r *venueRepository) UpdateFacilities(ctx context.Context, venueID uuid.UUI
 
 	return nil
 }
+
+func (r *venueRepository) GetTags(ctx context.Context, venueID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT tag
+		FROM venue_tags
+		WHERE venue_id = $1
+		ORDER BY tag`
+
+	tags := []string{}
+	err := r.db.SelectContext(ctx, &tags, query, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (r *venueRepository) SetTags(ctx context.Context, venueID uuid.UUID, tags []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM venue_tags WHERE venue_id = $1`, venueID)
+	if err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+
+	if len(tags) > 0 {
+		insertQuery := `
+			INSERT INTO venue_tags (venue_id, tag)
+			SELECT $1, UNNEST($2::text[])
+			ON CONFLICT DO NOTHING`
+
+		_, err = tx.ExecContext(ctx, insertQuery, venueID, pq.Array(tags))
+		if err != nil {
+			return fmt.Errorf("failed to add tags: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *venueRepository) RemoveTag(ctx context.Context, venueID uuid.UUID, tag string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM venue_tags WHERE venue_id = $1 AND tag = $2`, venueID, tag)
+	if err != nil {
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return interfaces.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// venueTagsColumn aggregates a venue's tags into a JSON array column
+const venueTagsColumn = `COALESCE(
+			(SELECT json_agg(vt.tag ORDER BY vt.tag) FROM venue_tags vt WHERE vt.venue_id = v.id), '[]'
+		) AS tags`
+
+// venueFeaturedExpr is true for venues with an active (unexpired) promotion
+const venueFeaturedExpr = `(v.featured AND (v.featured_until IS NULL OR v.featured_until > NOW()))`
+
+// venueTagCondition builds the WHERE fragment for a tag filter, using argIndex
+// as its first placeholder. It returns an empty condition when no tags are set.
+func venueTagCondition(filter interfaces.VenueTagFilter, argIndex int) (string, []interface{}) {
+	if len(filter.Tags) == 0 {
+		return "", nil
+	}
+
+	if filter.MatchAll {
+		return fmt.Sprintf(
+			"AND v.id IN (SELECT venue_id FROM venue_tags WHERE tag = ANY($%d) GROUP BY venue_id HAVING COUNT(DISTINCT tag) = $%d)",
+			argIndex, argIndex+1,
+		), []interface{}{pq.Array(filter.Tags), len(filter.Tags)}
+	}
+
+	return fmt.Sprintf(
+		"AND EXISTS (SELECT 1 FROM venue_tags vt2 WHERE vt2.venue_id = v.id AND vt2.tag = ANY($%d))",
+		argIndex,
+	), []interface{}{pq.Array(filter.Tags)}
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// testDB connects to TEST_DATABASE_URL, a migrated database the tests may write
+// to, and skips the test when it isn't set
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestAddReviewConcurrentReviewsKeepRatingInSync(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	userRepo := NewUserRepository(db)
+	venueRepo := NewVenueRepository(db)
+
+	const reviewers = 12
+	userIDs := make([]uuid.UUID, reviewers)
+	for i := range userIDs {
+		user := &models.User{
+			Email:     uuid.NewString() + "@example.com",
+			FirstName: "Reviewer",
+			LastName:  "Test",
+			PlayLevel: models.PlayerLevelIntermediate,
+		}
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		userIDs[i] = user.ID
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM users WHERE id = ANY($1)`, pq.Array(userIDs))
+	})
+
+	now := time.Now()
+	venue := &models.Venue{
+		Name:      "Review Race Hall " + uuid.NewString(),
+		Location:  "Bangkok",
+		OpenRange: models.NullRawMessage{RawMessage: json.RawMessage(`[]`), Valid: true},
+		Rules:     models.NullRawMessage{RawMessage: json.RawMessage(`[]`), Valid: true},
+		Status:    models.VenueStatusActive,
+		OwnerID:   userIDs[0],
+		Timezone:  "Asia/Bangkok",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := venueRepo.Create(ctx, venue); err != nil {
+		t.Fatalf("create venue: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM venue_reviews WHERE venue_id = $1`, venue.ID)
+		db.Exec(`DELETE FROM venues WHERE id = $1`, venue.ID)
+	})
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i, userID := range userIDs {
+		wg.Add(1)
+		go func(userID uuid.UUID, rating int) {
+			defer wg.Done()
+			<-start
+			review := &models.VenueReview{
+				ID:        uuid.New(),
+				VenueID:   venue.ID,
+				UserID:    userID,
+				Rating:    rating,
+				CreatedAt: time.Now(),
+			}
+			if err := venueRepo.AddReview(ctx, review, 0); err != nil {
+				t.Errorf("add review: %v", err)
+			}
+		}(userID, i%5+1)
+	}
+	close(start)
+	wg.Wait()
+
+	var got struct {
+		Rating       float64 `db:"rating"`
+		TotalReviews int     `db:"total_reviews"`
+		WantRating   float64 `db:"want_rating"`
+		WantTotal    int     `db:"want_total"`
+	}
+	query := `
+		SELECT v.rating, v.total_reviews,
+			COALESCE(AVG(vr.rating)::NUMERIC(3,2), 0) AS want_rating,
+			COUNT(vr.id) AS want_total
+		FROM venues v
+		LEFT JOIN venue_reviews vr ON vr.venue_id = v.id
+		WHERE v.id = $1
+		GROUP BY v.id`
+	if err := db.GetContext(ctx, &got, query, venue.ID); err != nil {
+		t.Fatalf("get rating: %v", err)
+	}
+
+	if got.WantTotal != reviewers {
+		t.Fatalf("reviews = %d, want %d", got.WantTotal, reviewers)
+	}
+	if got.TotalReviews != got.WantTotal {
+		t.Errorf("total_reviews = %d, want COUNT(*) = %d", got.TotalReviews, got.WantTotal)
+	}
+	if got.Rating != got.WantRating {
+		t.Errorf("rating = %.2f, want AVG(rating) = %.2f", got.Rating, got.WantRating)
+	}
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) interfaces.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.VenueWebhook) error {
+	query := `
+		INSERT INTO venue_webhooks (
+			id, venue_id, url, secret, created_at
+		) VALUES (
+			:id, :venue_id, :url, :secret, :created_at
+		)`
+
+	_, err := r.db.NamedExecContext(ctx, query, webhook)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) ListByVenue(ctx context.Context, venueID uuid.UUID) ([]models.VenueWebhook, error) {
+	query := `
+		SELECT *
+		FROM venue_webhooks
+		WHERE venue_id = $1
+		ORDER BY created_at`
+
+	webhooks := []models.VenueWebhook{}
+	if err := r.db.SelectContext(ctx, &webhooks, query, venueID); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id, venueID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM venue_webhooks WHERE id = $1 AND venue_id = $2`, id, venueID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+
+	return nil
+}
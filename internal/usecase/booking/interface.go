@@ -14,14 +14,26 @@ type UseCase interface {
 	CreateBooking(ctx context.Context, userID uuid.UUID, req requests.CreateBookingRequest) (*responses.BookingResponse, error)
 	GetBooking(ctx context.Context, id uuid.UUID) (*responses.BookingResponse, error)
 	ListBookings(ctx context.Context, userID uuid.UUID, req requests.ListBookingsRequest) (*responses.BookingListResponse, error)
-	UpdateBooking(ctx context.Context, id uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error)
+	UpdateBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error)
 	CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
 	GetUserBookings(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.BookingResponse, error)
+	ListUserBookings(ctx context.Context, userID uuid.UUID, req requests.UserBookingsRequest) ([]responses.BookingResponse, error)
 	CheckAvailability(ctx context.Context, req requests.CheckAvailabilityRequest) (*responses.CourtAvailabilityResponse, error)
 	GetPayment(ctx context.Context, id uuid.UUID) (*responses.PaymentResponse, error)
 	CreatePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.CreatePaymentRequest) (*responses.PaymentResponse, error)
 	UpdatePayment(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdatePaymentRequest) (*responses.PaymentResponse, error)
 	ChangeCourtStatus(ctx context.Context) error
+	// ExpireHolds cancels unpaid bookings whose hold ran out and returns how many were cancelled
+	ExpireHolds(ctx context.Context) (int, error)
+	GetReceipt(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]byte, error)
+	// AdminListBookings and AdminCancelBooking are for support staff and skip ownership checks
+	AdminListBookings(ctx context.Context, req requests.AdminListBookingsRequest) (*responses.BookingListResponse, error)
+	AdminCancelBooking(ctx context.Context, id uuid.UUID, adminID uuid.UUID) error
+}
+
+// ReceiptRenderer turns a titled list of text lines into a printable document
+type ReceiptRenderer interface {
+	Render(title string, lines []string) ([]byte, error)
 }
 
 var (
@@ -33,6 +45,10 @@ var (
 
 	ErrPaymentRequired = errors.New("payment required")
 
+	ErrBookingExpired = errors.New("booking hold expired")
+
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
 	ErrBookingNotFound = errors.New("booking not found") // Added this line
 
 )
@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
+	"badbuddy/internal/infrastructure/metrics"
 	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/webhook"
 
 	"github.com/google/uuid"
 )
@@ -20,6 +23,12 @@ type useCase struct {
 	courtRepo   interfaces.CourtRepository
 	venueRepo   interfaces.VenueRepository
 	userRepo    interfaces.UserRepository
+
+	receiptRenderer ReceiptRenderer
+	webhooks        webhook.UseCase
+
+	// holdDuration is how long a pending booking keeps its slot without a payment
+	holdDuration time.Duration
 }
 
 func NewBookingUseCase(
@@ -27,12 +36,18 @@ func NewBookingUseCase(
 	courtRepo interfaces.CourtRepository,
 	venueRepo interfaces.VenueRepository,
 	userRepo interfaces.UserRepository,
+	receiptRenderer ReceiptRenderer,
+	webhooks webhook.UseCase,
+	holdDuration time.Duration,
 ) UseCase {
 	return &useCase{
-		bookingRepo: bookingRepo,
-		courtRepo:   courtRepo,
-		venueRepo:   venueRepo,
-		userRepo:    userRepo,
+		bookingRepo:     bookingRepo,
+		courtRepo:       courtRepo,
+		venueRepo:       venueRepo,
+		userRepo:        userRepo,
+		receiptRenderer: receiptRenderer,
+		webhooks:        webhooks,
+		holdDuration:    holdDuration,
 	}
 }
 
@@ -73,30 +88,29 @@ func (uc *useCase) CreateBooking(ctx context.Context, userID uuid.UUID, req requ
 		return nil, fmt.Errorf("invalid end time format: %w", err)
 	}
 
-	// Check venue operating hours
-	venueDetails := &models.Venue{
-		ID:        venue.ID,
-		Name:      venue.Name,
-		Status:    venue.Status,
-		OpenRange: venue.OpenRange,
+	playerCount := models.DefaultBookingPlayerCount
+	if req.PlayerCount != 0 {
+		playerCount = req.PlayerCount
 	}
-	if err := uc.isVenueOpenForBooking(venueDetails, date, startTime, endTime); err != nil {
-		return nil, err
+	if playerCount < 1 {
+		return nil, fmt.Errorf("%w: player count must be at least 1", ErrValidation)
 	}
-	// Check availability
-	available, err := uc.bookingRepo.CheckCourtAvailability(ctx, courtID, date, startTime, endTime)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check availability: %w", err)
+	if court.MaxPlayers > 0 && playerCount > court.MaxPlayers {
+		return nil, fmt.Errorf("%w: %s holds at most %d players", ErrValidation, court.Name, court.MaxPlayers)
 	}
-	if !available {
-		return nil, fmt.Errorf("court is not available for the selected time slot")
+
+	// Check the booking window, duration and venue operating hours
+	if err := uc.validateBookingTime(date, startTime, endTime, &venue.Venue); err != nil {
+		return nil, err
 	}
 	// Calculate duration and total amount
 	duration := endTime.Sub(startTime)
 	hours := duration.Hours()
 	totalAmount := hours * court.PricePerHour
 
-	// Create booking
+	// Create booking, holding the slot until it is paid for
+	now := time.Now()
+	expiresAt := now.Add(uc.holdDuration)
 	booking := &models.CourtBooking{
 		ID:          uuid.New(),
 		CourtID:     courtID,
@@ -105,17 +119,25 @@ func (uc *useCase) CreateBooking(ctx context.Context, userID uuid.UUID, req requ
 		StartTime:   startTime,
 		EndTime:     endTime,
 		TotalAmount: totalAmount,
+		PlayerCount: playerCount,
 		Status:      models.BookingStatusPending,
 		Notes:       req.Notes,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   &expiresAt,
 	}
 	if err := booking.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid booking: %w", err)
 	}
-	if err := uc.bookingRepo.Create(ctx, booking); err != nil {
+	// Check availability and insert as one step, serialized per court and date
+	created, err := uc.bookingRepo.CreateIfAvailable(ctx, booking)
+	if err != nil {
 		return nil, fmt.Errorf("failed to create booking: %w", err)
 	}
+	if !created {
+		return nil, fmt.Errorf("court is not available for the selected time slot")
+	}
+	metrics.BookingsCreated.Inc()
 
 	// Get complete booking details
 	bookingDetail, err := uc.bookingRepo.GetByID(ctx, booking.ID)
@@ -123,6 +145,8 @@ func (uc *useCase) CreateBooking(ctx context.Context, userID uuid.UUID, req requ
 		return nil, fmt.Errorf("failed to get booking details: %w", err)
 	}
 
+	uc.webhooks.Dispatch(court.VenueID, models.WebhookEventBookingCreated, bookingDetail.ToResponse())
+
 	return bookingDetail.ToResponse(), nil
 }
 
@@ -173,6 +197,14 @@ func (uc *useCase) ListBookings(ctx context.Context, userID uuid.UUID, req reque
 		filters["status"] = models.BookingStatus(req.Status)
 	}
 
+	if req.PaymentStatus != "" {
+		paymentStatus, err := parsePaymentStatus(req.PaymentStatus)
+		if err != nil {
+			return nil, err
+		}
+		filters["payment_status"] = paymentStatus
+	}
+
 	// Set default limit and offset
 	limit := 10
 	if req.Limit > 0 && req.Limit <= 100 {
@@ -210,35 +242,195 @@ func (uc *useCase) ListBookings(ctx context.Context, userID uuid.UUID, req reque
 	}, nil
 }
 
-func (uc *useCase) UpdateBooking(ctx context.Context, id uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error) {
+// UpdateBooking lets the booker edit their notes and player count, and the
+// venue owner edit the notes and move the booking to a new status
+func (uc *useCase) UpdateBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID, req requests.UpdateBookingRequest) (*responses.BookingResponse, error) {
 	booking, err := uc.bookingRepo.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("booking not found: %w", err)
+		return nil, ErrBookingNotFound
 	}
 
-	if booking.Status == models.BookingStatusCancelled {
-		return nil, fmt.Errorf("cannot update cancelled booking")
+	court, err := uc.courtRepo.GetCourtWithVenueByID(ctx, booking.CourtID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get court: %w", err)
+	}
+	isOwner, err := uc.isVenueOwner(ctx, court.VenueID, userID)
+	if err != nil {
+		return nil, err
 	}
+	isBooker := booking.UserID == userID
 
-	if booking.Status == models.BookingStatusConfirmed {
-		return nil, fmt.Errorf("cannot update confirm booking")
+	if !isBooker && !isOwner {
+		return nil, ErrUnauthorized
+	}
+	if req.Status != "" && !isOwner {
+		return nil, fmt.Errorf("%w: only the venue owner can change a booking's status", ErrUnauthorized)
+	}
+	if req.PlayerCount != nil && !isBooker {
+		return nil, fmt.Errorf("%w: only the person who booked can change the player count", ErrUnauthorized)
 	}
 
-	if req.Status != "" {
-		booking.Status = models.BookingStatus(req.Status)
+	if booking.Status == models.BookingStatusCancelled {
+		return nil, fmt.Errorf("%w: a cancelled booking can't be changed", ErrInvalidStatusTransition)
+	}
+	status := models.BookingStatus(req.Status)
+	if req.Status != "" && !booking.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: a %s booking can't become %s", ErrInvalidStatusTransition, booking.Status, status)
+	}
+
+	if req.PlayerCount != nil {
+		if *req.PlayerCount < 1 {
+			return nil, fmt.Errorf("%w: player count must be at least 1", ErrValidation)
+		}
+		if court.MaxPlayers > 0 && *req.PlayerCount > court.MaxPlayers {
+			return nil, fmt.Errorf("%w: %s holds at most %d players", ErrValidation, court.Name, court.MaxPlayers)
+		}
+		booking.PlayerCount = *req.PlayerCount
 	}
 
 	if req.Notes != nil {
 		booking.Notes = req.Notes
 	}
 
+	// Cancelling goes through the cancel path below, which also refunds
+	if status == models.BookingStatusConfirmed {
+		booking.Status = status
+	}
+
 	booking.UpdatedAt = time.Now()
 
 	if err := uc.bookingRepo.Update(ctx, booking); err != nil {
 		return nil, fmt.Errorf("failed to update booking: %w", err)
 	}
 
-	return booking.ToResponse(), nil
+	switch status {
+	case models.BookingStatusConfirmed:
+		uc.publishBookingEvent(ctx, id, models.WebhookEventBookingConfirmed)
+	case models.BookingStatusCancelled:
+		if err := uc.cancel(ctx, booking); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := uc.bookingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking details: %w", err)
+	}
+
+	return updated.ToResponse(), nil
+}
+
+func (uc *useCase) AdminListBookings(ctx context.Context, req requests.AdminListBookingsRequest) (*responses.BookingListResponse, error) {
+	filters := make(map[string]interface{})
+
+	ids := map[string]string{
+		"court_id": req.CourtID,
+		"venue_id": req.VenueID,
+		"user_id":  req.UserID,
+	}
+	for key, value := range ids {
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, ErrValidation
+		}
+		filters[key] = id
+	}
+
+	dates := map[string]string{
+		"date_from": req.DateFrom,
+		"date_to":   req.DateTo,
+	}
+	for key, value := range dates {
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, ErrValidation
+		}
+		filters[key] = date
+	}
+
+	if req.Status != "" {
+		filters["status"] = models.BookingStatus(req.Status)
+	}
+
+	if req.PaymentStatus != "" {
+		paymentStatus, err := parsePaymentStatus(req.PaymentStatus)
+		if err != nil {
+			return nil, err
+		}
+		filters["payment_status"] = paymentStatus
+	}
+
+	limit := 10
+	if req.Limit > 0 && req.Limit <= 100 {
+		limit = req.Limit
+	}
+
+	offset := 0
+	if req.Offset > 0 {
+		offset = req.Offset
+	}
+
+	total, err := uc.bookingRepo.CountAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	bookings, err := uc.bookingRepo.ListAll(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookings: %w", err)
+	}
+
+	bookingResponses := make([]responses.BookingResponse, len(bookings))
+	for i, booking := range bookings {
+		bookingResponses[i] = *booking.ToResponse()
+	}
+
+	return &responses.BookingListResponse{
+		Bookings: bookingResponses,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+// AdminCancelBooking cancels any booking that isn't already cancelled, including
+// confirmed ones, and refunds a completed payment
+func (uc *useCase) AdminCancelBooking(ctx context.Context, id uuid.UUID, adminID uuid.UUID) error {
+	booking, err := uc.bookingRepo.GetByID(ctx, id)
+	if err != nil {
+		return ErrBookingNotFound
+	}
+
+	if booking.Status == models.BookingStatusCancelled {
+		return ErrValidation
+	}
+
+	if err := uc.bookingRepo.CancelBooking(ctx, id); err != nil {
+		return fmt.Errorf("failed to cancel booking: %w", err)
+	}
+
+	// Staff cancellations always refund, whatever the refund window
+	if booking.Payment != nil && booking.Payment.Status == models.PaymentStatusCompleted {
+		payment := booking.Payment
+		payment.Status = models.PaymentStatusRefunded
+		payment.UpdatedAt = time.Now()
+
+		if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment status: %w", err)
+		}
+	}
+
+	log.Printf("admin %s cancelled booking %s of user %s", adminID, id, booking.UserID)
+
+	uc.publishBookingEvent(ctx, id, models.WebhookEventBookingCancelled)
+
+	return nil
 }
 
 func (uc *useCase) CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
@@ -260,7 +452,13 @@ func (uc *useCase) CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.
 		return fmt.Errorf("booking cannot be cancelled")
 	}
 
-	if err := uc.bookingRepo.CancelBooking(ctx, id); err != nil {
+	return uc.cancel(ctx, booking)
+}
+
+// cancel cancels the booking, refunds a completed payment and tells the
+// venue's webhooks
+func (uc *useCase) cancel(ctx context.Context, booking *models.CourtBooking) error {
+	if err := uc.bookingRepo.CancelBooking(ctx, booking.ID); err != nil {
 		return fmt.Errorf("failed to cancel booking: %w", err)
 	}
 
@@ -273,8 +471,11 @@ func (uc *useCase) CancelBooking(ctx context.Context, id uuid.UUID, userID uuid.
 		if err := uc.bookingRepo.UpdatePayment(ctx, payment); err != nil {
 			return fmt.Errorf("failed to update payment status: %w", err)
 		}
+		uc.publishBookingEvent(ctx, booking.ID, models.WebhookEventPaymentUpdated)
 	}
 
+	uc.publishBookingEvent(ctx, booking.ID, models.WebhookEventBookingCancelled)
+
 	return nil
 }
 
@@ -292,6 +493,61 @@ func (uc *useCase) GetUserBookings(ctx context.Context, userID uuid.UUID, includ
 	return responses, nil
 }
 
+// ListUserBookings lists the user's upcoming or past bookings. Past bookings are
+// returned newest first unless a sort order is given.
+func (uc *useCase) ListUserBookings(ctx context.Context, userID uuid.UUID, req requests.UserBookingsRequest) ([]responses.BookingResponse, error) {
+	filters := make(map[string]interface{})
+
+	switch req.When {
+	case "", "upcoming":
+		filters["when"] = "upcoming"
+	case "past":
+		filters["when"] = "past"
+	case "all":
+	default:
+		return nil, ErrValidation
+	}
+
+	descending := req.When == "past"
+	switch req.Sort {
+	case "":
+	case "asc":
+		descending = false
+	case "desc":
+		descending = true
+	default:
+		return nil, ErrValidation
+	}
+
+	switch status := models.BookingStatus(req.Status); status {
+	case "":
+	case models.BookingStatusPending, models.BookingStatusConfirmed, models.BookingStatusCancelled:
+		filters["status"] = status
+	default:
+		return nil, ErrValidation
+	}
+
+	if req.VenueID != "" {
+		venueID, err := uuid.Parse(req.VenueID)
+		if err != nil {
+			return nil, ErrValidation
+		}
+		filters["venue_id"] = venueID
+	}
+
+	bookings, err := uc.bookingRepo.ListUserBookings(ctx, userID, filters, descending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user bookings: %w", err)
+	}
+
+	bookingResponses := make([]responses.BookingResponse, len(bookings))
+	for i, booking := range bookings {
+		bookingResponses[i] = *booking.ToResponse()
+	}
+
+	return bookingResponses, nil
+}
+
 func (uc *useCase) CheckAvailability(ctx context.Context, req requests.CheckAvailabilityRequest) (*responses.CourtAvailabilityResponse, error) {
 	courtID, err := uuid.Parse(req.CourtID)
 	if err != nil {
@@ -382,6 +638,10 @@ func (uc *useCase) CreatePayment(ctx context.Context, bookingID uuid.UUID, userI
 		return nil, fmt.Errorf("payment already exists for this booking")
 	}
 
+	if booking.IsHoldExpired(time.Now()) {
+		return nil, ErrBookingExpired
+	}
+
 	if req.Amount != booking.TotalAmount {
 		return nil, fmt.Errorf("payment amount does not match booking amount")
 	}
@@ -411,6 +671,9 @@ func (uc *useCase) CreatePayment(ctx context.Context, bookingID uuid.UUID, userI
 			return nil, fmt.Errorf("failed to update booking status: %w", err)
 		}
 	}
+
+	uc.publishBookingEvent(ctx, bookingID, models.WebhookEventPaymentUpdated)
+
 	return &responses.PaymentResponse{
 		ID:            payment.ID.String(),
 		Amount:        payment.Amount,
@@ -455,6 +718,8 @@ func (uc *useCase) UpdatePayment(ctx context.Context, id uuid.UUID, userID uuid.
 		return nil, fmt.Errorf("failed to update booking status: %w", err)
 	}
 
+	uc.publishBookingEvent(ctx, payment.BookingID, models.WebhookEventPaymentUpdated)
+
 	return &responses.PaymentResponse{
 		ID:            payment.ID.String(),
 		Amount:        payment.Amount,
@@ -467,35 +732,96 @@ func (uc *useCase) UpdatePayment(ctx context.Context, id uuid.UUID, userID uuid.
 }
 
 // Helper methods
+// GetReceipt renders the receipt of a paid booking. It is available to the
+// customer who booked and to the venue owner.
+func (uc *useCase) GetReceipt(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]byte, error) {
+	booking, err := uc.bookingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+
+	if booking.UserID != userID {
+		court, err := uc.courtRepo.GetCourtWithVenueByID(ctx, booking.CourtID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get court: %w", err)
+		}
+		isOwner, err := uc.isVenueOwner(ctx, court.VenueID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isOwner {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	payment := booking.Payment
+	if payment == nil || (payment.Status != models.PaymentStatusCompleted && payment.Status != models.PaymentStatusRefunded) {
+		return nil, ErrPaymentRequired
+	}
+
+	transactionID := "-"
+	if payment.TransactionID != nil && *payment.TransactionID != "" {
+		transactionID = *payment.TransactionID
+	}
+
+	lines := []string{
+		fmt.Sprintf("Receipt no.: %s", payment.ID),
+		fmt.Sprintf("Booking: %s", booking.ID),
+		fmt.Sprintf("Customer: %s", booking.UserName),
+		"",
+		fmt.Sprintf("Venue: %s (%s)", booking.VenueName, booking.VenueLocation),
+		fmt.Sprintf("Court: %s", booking.CourtName),
+		fmt.Sprintf("Date: %s", booking.Date.Format("2006-01-02")),
+		fmt.Sprintf("Time: %s - %s", booking.StartTime.Format("15:04"), booking.EndTime.Format("15:04")),
+		"",
+		fmt.Sprintf("Amount: %.2f THB", payment.Amount),
+		fmt.Sprintf("Payment method: %s", payment.PaymentMethod),
+		fmt.Sprintf("Transaction ID: %s", transactionID),
+		fmt.Sprintf("Paid at: %s", payment.CreatedAt.In(models.LoadTimezone(booking.VenueTimezone)).Format("2006-01-02 15:04")),
+	}
+	if payment.Status == models.PaymentStatusRefunded {
+		lines = append(lines, "Status: refunded")
+	}
+
+	receipt, err := uc.receiptRenderer.Render("BadBuddy booking receipt", lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render receipt: %w", err)
+	}
+
+	return receipt, nil
+}
+
 func (uc *useCase) validateBookingTime(date time.Time, startTime, endTime time.Time, venue *models.Venue) error {
-	now := time.Now()
+	loc := venue.TimeLocation()
+	now := time.Now().In(loc)
 
-	// Check if date is in the future
-	if date.Before(now.Truncate(24 * time.Hour)) {
-		return fmt.Errorf("booking date must be in the future")
+	// Check if date is in the future, judged by the calendar day at the venue
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, date.Location())
+	if date.Before(today) {
+		return fmt.Errorf("%w: booking date must be in the future", ErrValidation)
 	}
 
 	// Check if date is not too far in advance (e.g., 3 months)
 	if date.After(now.AddDate(0, 3, 0)) {
-		return fmt.Errorf("cannot book more than 3 months in advance")
+		return fmt.Errorf("%w: cannot book more than 3 months in advance", ErrValidation)
 	}
 
 	// Create full datetime for comparison
 	bookingStart := time.Date(
 		date.Year(), date.Month(), date.Day(),
-		startTime.Hour(), startTime.Minute(), 0, 0, time.Local)
+		startTime.Hour(), startTime.Minute(), 0, 0, loc)
 	bookingEnd := time.Date(
 		date.Year(), date.Month(), date.Day(),
-		endTime.Hour(), endTime.Minute(), 0, 0, time.Local)
+		endTime.Hour(), endTime.Minute(), 0, 0, loc)
 
 	// Check minimum booking duration (30 minutes)
 	if bookingEnd.Sub(bookingStart) < 30*time.Minute {
-		return fmt.Errorf("booking duration must be at least 30 minutes")
+		return fmt.Errorf("%w: booking duration must be at least 30 minutes", ErrValidation)
 	}
 
 	// Check maximum booking duration (4 hours)
 	if bookingEnd.Sub(bookingStart) > 4*time.Hour {
-		return fmt.Errorf("booking duration cannot exceed 4 hours")
+		return fmt.Errorf("%w: booking duration cannot exceed 4 hours", ErrValidation)
 	}
 
 	// Check venue operating hours
@@ -594,6 +920,16 @@ func (uc *useCase) handlePaymentStatus(ctx context.Context, bookingID uuid.UUID,
 }
 
 // validateRefundEligibility checks if a booking is eligible for refund
+// parsePaymentStatus checks a payment_status filter against the known statuses
+func parsePaymentStatus(value string) (models.PaymentStatus, error) {
+	switch status := models.PaymentStatus(value); status {
+	case models.PaymentStatusPending, models.PaymentStatusCompleted, models.PaymentStatusFailed, models.PaymentStatusRefunded:
+		return status, nil
+	default:
+		return "", ErrValidation
+	}
+}
+
 func (uc *useCase) validateRefundEligibility(booking *models.CourtBooking) error {
 	if booking.Status != models.BookingStatusConfirmed {
 		return fmt.Errorf("booking must be confirmed to be eligible for refund")
@@ -606,7 +942,7 @@ func (uc *useCase) validateRefundEligibility(booking *models.CourtBooking) error
 	// Check cancellation deadline (24 hours before start time)
 	bookingStart := time.Date(
 		booking.Date.Year(), booking.Date.Month(), booking.Date.Day(),
-		booking.StartTime.Hour(), booking.StartTime.Minute(), 0, 0, time.Local)
+		booking.StartTime.Hour(), booking.StartTime.Minute(), 0, 0, models.LoadTimezone(booking.VenueTimezone))
 
 	if time.Now().After(bookingStart.Add(-24 * time.Hour)) {
 		return fmt.Errorf("cancellation deadline has passed (24 hours before start time)")
@@ -642,6 +978,33 @@ func (uc *useCase) processRefund(ctx context.Context, booking *models.CourtBooki
 	return nil
 }
 
+// isVenueOwner reports whether the user owns the venue
+func (uc *useCase) isVenueOwner(ctx context.Context, venueID uuid.UUID, userID uuid.UUID) (bool, error) {
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return venue.OwnerID == userID, nil
+}
+
+// publishBookingEvent sends the booking's current state to the venue's webhooks.
+// Lookup failures are logged and never fail the request that triggered the event.
+func (uc *useCase) publishBookingEvent(ctx context.Context, bookingID uuid.UUID, event models.WebhookEvent) {
+	booking, err := uc.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		log.Printf("failed to load booking %s for %s webhook: %v", bookingID, event, err)
+		return
+	}
+
+	court, err := uc.courtRepo.GetCourtWithVenueByID(ctx, booking.CourtID)
+	if err != nil {
+		log.Printf("failed to load court %s for %s webhook: %v", booking.CourtID, event, err)
+		return
+	}
+
+	uc.webhooks.Dispatch(court.VenueID, event, booking.ToResponse())
+}
+
 // Helper function to create pointer to time
 func toPtr(t time.Time) *time.Time {
 	return &t
@@ -742,10 +1105,23 @@ func (uc *useCase) isVenueOpenForBooking(venue *models.Venue, date time.Time, st
 	return nil
 }
 
+func (uc *useCase) ExpireHolds(ctx context.Context) (int, error) {
+	ids, err := uc.bookingRepo.CancelExpiredHolds(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		uc.publishBookingEvent(ctx, id, models.WebhookEventBookingCancelled)
+	}
+
+	return len(ids), nil
+}
+
 // cronjob for all bookings if time.now in range of date , start time and end time then change court status to occupied if dont have any booking in that time slot status will be available
 func (uc *useCase) ChangeCourtStatus(ctx context.Context) error {
 	filters := make(map[string]interface{})
-	filters["status"] = string(models.BookingStatusConfirmed)
+	filters["status"] = models.BookingStatusConfirmed
 	filters["date"] = time.Now().Format("2006-01-02")
 
 	// Get all confirmed bookings for today
@@ -754,14 +1130,14 @@ func (uc *useCase) ChangeCourtStatus(ctx context.Context) error {
 		return fmt.Errorf("failed to get all bookings: %w", err)
 	}
 
-	var thailandTZ = time.FixedZone("ICT", 7*3600)
-	currentTime := time.Now().In(thailandTZ)
+	currentTime := time.Now()
 
 	// Track courts with active bookings to avoid setting them to available later
 	occupiedCourts := make(map[uuid.UUID]bool)
 
 	for _, booking := range bookings {
-		// Convert booking times to ICT timezone to match currentTime
+		// Booking times are wall-clock times at the venue
+		venueTZ := models.LoadTimezone(booking.VenueTimezone)
 		startTime := time.Date(
 			booking.Date.Year(),
 			booking.Date.Month(),
@@ -769,7 +1145,7 @@ func (uc *useCase) ChangeCourtStatus(ctx context.Context) error {
 			booking.StartTime.Hour(),
 			booking.StartTime.Minute(),
 			0, 0,
-			thailandTZ, // Use ICT timezone
+			venueTZ,
 		)
 
 		endTime := time.Date(
@@ -779,7 +1155,7 @@ func (uc *useCase) ChangeCourtStatus(ctx context.Context) error {
 			booking.EndTime.Hour(),
 			booking.EndTime.Minute(),
 			0, 0,
-			thailandTZ, // Use ICT timezone
+			venueTZ,
 		)
 
 		if currentTime.After(startTime) && currentTime.Before(endTime) {
@@ -0,0 +1,96 @@
+package booking
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/memory"
+	"badbuddy/internal/usecase/webhook"
+
+	"github.com/google/uuid"
+)
+
+// stubWebhooks drops every event and leaves the rest of the interface unimplemented
+type stubWebhooks struct {
+	webhook.UseCase
+}
+
+func (stubWebhooks) Dispatch(venueID uuid.UUID, event models.WebhookEvent, data interface{}) {}
+
+func TestCreateBookingConcurrentRequestsBookSlotOnce(t *testing.T) {
+	store := memory.NewStore()
+
+	var openRange []responses.OpenRangeResponse
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		openRange = append(openRange, responses.OpenRangeResponse{
+			Day:       strings.ToLower(day.String()),
+			IsOpen:    true,
+			OpenTime:  time.Date(0, 1, 1, 6, 0, 0, 0, time.UTC),
+			CloseTime: time.Date(0, 1, 1, 22, 0, 0, 0, time.UTC),
+		})
+	}
+	raw, err := json.Marshal(openRange)
+	if err != nil {
+		t.Fatalf("marshal open range: %v", err)
+	}
+
+	venueID, courtID := uuid.New(), uuid.New()
+	store.PutVenue(models.Venue{
+		ID:        venueID,
+		Name:      "Test Hall",
+		Status:    models.VenueStatusActive,
+		OpenRange: models.NullRawMessage{RawMessage: raw, Valid: true},
+	})
+	store.PutCourt(models.Court{ID: courtID, VenueID: venueID, Name: "Court 1", PricePerHour: 200, MaxPlayers: 4, Status: models.CourtStatusAvailable})
+
+	uc := NewBookingUseCase(memory.NewBookingRepository(store), memory.NewCourtRepository(store), memory.NewVenueRepository(store),
+		memory.NewUserRepository(store), nil, stubWebhooks{}, 15*time.Minute)
+
+	req := requests.CreateBookingRequest{
+		CourtID:   courtID.String(),
+		Date:      time.Now().AddDate(0, 0, 2).Format("2006-01-02"),
+		StartTime: "18:00",
+		EndTime:   "20:00",
+	}
+
+	const bookers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+	start := make(chan struct{})
+	for i := 0; i < bookers; i++ {
+		userID := uuid.New()
+		store.PutUser(models.User{ID: userID, FirstName: "Booker", Status: models.UserStatusActive})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := uc.CreateBooking(context.Background(), userID, req)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case !strings.Contains(err.Error(), "not available"):
+				t.Errorf("create booking: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if created != 1 {
+		t.Fatalf("created bookings = %d, want 1", created)
+	}
+}
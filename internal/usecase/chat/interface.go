@@ -15,13 +15,21 @@ type UseCase interface {
 
 	DeleteMessage(ctx context.Context, chatID uuid.UUID, messageID uuid.UUID, userID uuid.UUID) error
 
-	UpdateMessage(ctx context.Context, chatID uuid.UUID, messageID uuid.UUID, userID uuid.UUID, req requests.SendAndUpdateMessageRequest) error
+	UpdateMessage(ctx context.Context, chatID uuid.UUID, messageID uuid.UUID, userID uuid.UUID, req requests.SendAndUpdateMessageRequest) (string, error)
 
-	GetChats(ctx context.Context, userID uuid.UUID) (*responses.ChatListResponse, error)
+	GetChats(ctx context.Context, userID uuid.UUID, limit int, offset int) (*responses.ChatListResponse, error)
 
 	GetUsersInChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) (*responses.UserListResponse, error)
 
 	GetDirectChat(ctx context.Context, userID uuid.UUID, otherUserUUID uuid.UUID, limit int, offset int) (*responses.ChatMassageListResponse, error)
 
 	GetChatMessageOfSession(ctx context.Context, sessionID uuid.UUID, limit int, offset int, userID uuid.UUID) (*responses.ChatMassageListResponse, error)
+
+	PinMessage(ctx context.Context, chatID uuid.UUID, messageID uuid.UUID, userID uuid.UUID, pinned bool) error
+
+	GetPinnedMessages(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) (*responses.ChatMassageListResponse, error)
+
+	DeleteChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error
+
+	MuteChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, muted bool) error
 }
\ No newline at end of file
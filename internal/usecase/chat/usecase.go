@@ -4,9 +4,17 @@ import (
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
+	"badbuddy/internal/infrastructure/media"
 	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/notification"
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -17,17 +25,34 @@ var (
 	ErrValidation = errors.New("validation error")
 
 	ErrChatNotFound = errors.New("chat not found")
+
+	ErrMessageNotFound = errors.New("message not found")
+)
+
+// Page sizes for GetChats
+const (
+	defaultChatsLimit = 20
+	maxChatsLimit     = 100
+
+	// messagePreviewLength caps a last-message preview in characters
+	messagePreviewLength = 80
 )
 
 type useCase struct {
-	chatRepo interfaces.ChatRepository
-	userRepo interfaces.UserRepository
+	chatRepo            interfaces.ChatRepository
+	userRepo            interfaces.UserRepository
+	notificationUseCase notification.UseCase
+	maxMessageLength    int
+	imagePolicy         media.Policy
 }
 
-func NewChatUseCase(chatRepo interfaces.ChatRepository, userRepo interfaces.UserRepository) UseCase {
+func NewChatUseCase(chatRepo interfaces.ChatRepository, userRepo interfaces.UserRepository, notificationUseCase notification.UseCase, maxMessageLength int, imagePolicy media.Policy) UseCase {
 	return &useCase{
-		chatRepo: chatRepo,
-		userRepo: userRepo,
+		chatRepo:            chatRepo,
+		userRepo:            userRepo,
+		notificationUseCase: notificationUseCase,
+		maxMessageLength:    maxMessageLength,
+		imagePolicy:         imagePolicy,
 	}
 }
 
@@ -42,6 +67,9 @@ func (uc *useCase) GetChatMessageByID(ctx context.Context, chatID uuid.UUID, lim
 
 	chat, err := uc.chatRepo.GetChatMessageByID(ctx, chatID, limit, offset)
 
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrChatNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +98,7 @@ func (uc *useCase) GetChatMessageByID(ctx context.Context, chatID uuid.UUID, lim
 				LastActiveAt: m.LastActiveAt,
 			},
 			Message:       m.Content,
+			IsPinned:      m.IsPinned,
 			Timestamp:     m.CreatedAt,
 			EditTimeStamp: m.UpdatedAt,
 		})
@@ -84,29 +113,38 @@ func (uc *useCase) GetChatMessageByID(ctx context.Context, chatID uuid.UUID, lim
 }
 
 func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, req requests.SendAndUpdateMessageRequest) (*responses.ChatMassageResponse, error) {
-	if req.Message == "" {
-		return nil, ErrValidation
+	messageType := models.MessageType(req.Type)
+	if messageType == "" {
+		messageType = models.MessageTypeText
+	}
+	if messageType != models.MessageTypeText && messageType != models.MessageTypeImage {
+		return nil, fmt.Errorf("%w: type must be text or image", ErrValidation)
 	}
 
-	// isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// if !isPartOfChat {
-	// 	return nil, ErrUnauthorized
-	// }
+	content, err := uc.validateContent(messageType, req.Message)
+	if err != nil {
+		return nil, err
+	}
 
-	_, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	_, err = uc.chatRepo.GetChatByID(ctx, chatID)
 	if err != nil {
 		return nil, ErrChatNotFound
 	}
 
+	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if !isPartOfChat {
+		return nil, ErrUnauthorized
+	}
+
 	message := models.Message{
 		ID:       uuid.New(),
 		ChatID:   chatID,
 		SenderID: userID,
-		Type:     models.MessageTypeText,
-		Content:  req.Message,
+		Type:     messageType,
+		Content:  content,
 		Status:   models.MessageStatusSent,
 	}
 
@@ -115,6 +153,8 @@ func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, re
 		return nil, err
 	}
 
+	uc.notifyMembers(ctx, messageReturn)
+
 	chatMessage := responses.ChatMassageResponse{
 		ID:     messageReturn.ID.String(),
 		ChatID: messageReturn.ChatID.String(),
@@ -131,6 +171,7 @@ func (uc *useCase) SendMessage(ctx context.Context, userID, chatID uuid.UUID, re
 			LastActiveAt: messageReturn.LastActiveAt,
 		},
 		Message:       messageReturn.Content,
+		IsPinned:      messageReturn.IsPinned,
 		Timestamp:     messageReturn.CreatedAt,
 		EditTimeStamp: messageReturn.UpdatedAt,
 	}
@@ -176,52 +217,79 @@ func (uc *useCase) DeleteMessage(ctx context.Context, chatID, messageID, userID
 	return nil
 }
 
-func (uc *useCase) UpdateMessage(ctx context.Context, chatID, messageID, userID uuid.UUID, req requests.SendAndUpdateMessageRequest) error {
+// UpdateMessage edits a message's content and returns it as stored
+func (uc *useCase) UpdateMessage(ctx context.Context, chatID, messageID, userID uuid.UUID, req requests.SendAndUpdateMessageRequest) (string, error) {
 	isUserIsSerder, err := uc.chatRepo.IsUserIsSender(ctx, userID, messageID)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if !isUserIsSerder {
-		return ErrUnauthorized
+		return "", ErrUnauthorized
 	}
 
 	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if !isPartOfChat {
-		return ErrUnauthorized
+		return "", ErrUnauthorized
 	}
 
 	message, err := uc.chatRepo.GetChatMessageByID(ctx, chatID, 1, 0)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if len(*message) == 0 {
-		return ErrChatNotFound
+		return "", ErrChatNotFound
 	}
 
 	if (*message)[0].SenderID != userID {
-		return ErrUnauthorized
+		return "", ErrUnauthorized
+	}
+
+	stored, err := uc.chatRepo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return "", ErrMessageNotFound
+	}
+
+	content, err := uc.validateContent(stored.Type, req.Message)
+	if err != nil {
+		return "", err
 	}
 
 	messageToUpdate := models.Message{
 		ID:      messageID,
-		Content: req.Message,
+		Content: content,
 	}
 
 	err = uc.chatRepo.UpdateChatMessage(ctx, &messageToUpdate)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return content, nil
 }
 
-func (uc *useCase) GetChats(ctx context.Context, userID uuid.UUID) (*responses.ChatListResponse, error) {
-	chats, err := uc.chatRepo.GetChats(ctx, userID)
+// GetChats pages through the user's inbox, most recent message first
+func (uc *useCase) GetChats(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.ChatListResponse, error) {
+	if limit <= 0 {
+		limit = defaultChatsLimit
+	}
+	if limit > maxChatsLimit {
+		limit = maxChatsLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	total, err := uc.chatRepo.CountChats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	chats, err := uc.chatRepo.GetChats(ctx, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -232,40 +300,49 @@ func (uc *useCase) GetChats(ctx context.Context, userID uuid.UUID) (*responses.C
 		chatList = append(chatList, responses.ChatResponse{
 			ID:   c.ID.String(),
 			Type: string(c.Type),
+			Muted: c.Muted,
 			SessionID:  func() string { if c.SessionID == nil { return "" } else { return c.SessionID.String() } }(),
-			LastMessage: func() *responses.ChatMassageResponse {
-				if c.LastMessage == nil {
-					return nil
-				}
-				return &responses.ChatMassageResponse{
-					ID:     c.LastMessage.ID.String(),
-					ChatID: c.LastMessage.ChatID.String(),
-					Autor: responses.UserChatResponse{
-						ID:           c.LastMessage.SenderID.String(),
-						Email:        c.LastMessage.Email,
-						FirstName:    c.LastMessage.FirstName,
-						LastName:     c.LastMessage.LastName,
-						Phone:        c.LastMessage.Phone,
-						PlayLevel:    string(c.LastMessage.PlayLevel),
-						Location:     *c.LastMessage.Location,
-						Bio:          *c.LastMessage.Bio,
-						AvatarURL:    *c.LastMessage.AvatarURL,
-						LastActiveAt: c.LastMessage.LastActiveAt,
-					},
-					Message:       c.LastMessage.Content,
-					Timestamp:     c.LastMessage.CreatedAt,
-					EditTimeStamp: c.LastMessage.UpdatedAt,
-				}
-			}(),
+			LastMessage: toMessagePreview(c.LastMessage, userID),
 			Users: convertToUserChatResponse(c.Users),
 		})
 	}
 
 	return &responses.ChatListResponse{
-		Chats: chatList,
+		Chats:   chatList,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: offset+len(chatList) < total,
 	}, nil
 }
 
+// toMessagePreview shortens a chat's last message for the inbox. Image
+// messages show a placeholder instead of their URL.
+func toMessagePreview(m *models.Message, userID uuid.UUID) *responses.MessagePreviewResponse {
+	if m == nil {
+		return nil
+	}
+
+	preview := m.Content
+	if m.Type == models.MessageTypeImage {
+		preview = "📷 Photo"
+	} else if runes := []rune(strings.Join(strings.Fields(preview), " ")); len(runes) > messagePreviewLength {
+		preview = string(runes[:messagePreviewLength]) + "…"
+	} else {
+		preview = string(runes)
+	}
+
+	return &responses.MessagePreviewResponse{
+		ID:         m.ID.String(),
+		Type:       string(m.Type),
+		Preview:    preview,
+		SenderID:   m.SenderID.String(),
+		SenderName: strings.TrimSpace(m.FirstName + " " + m.LastName),
+		Timestamp:  m.CreatedAt,
+		Unread:     m.SenderID != userID && m.Status == models.MessageStatusSent,
+	}
+}
+
 func (uc *useCase) GetUsersInChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) (*responses.UserListResponse, error) {
 	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
 	if err != nil {
@@ -293,6 +370,9 @@ func (uc *useCase) GetDirectChat(ctx context.Context, userID uuid.UUID, otherUse
 	if !isOtherUserExist {
 		return nil, ErrValidation
 	}
+	if otherUserUUID == userID {
+		return nil, fmt.Errorf("%w: cannot start a direct chat with yourself", ErrValidation)
+	}
 
 
 	chat_id, err := uc.chatRepo.GetDirectChatID(ctx, userID, otherUserUUID)
@@ -314,6 +394,9 @@ func (uc *useCase) GetChatMessageOfSession(ctx context.Context, sessionID uuid.U
 	// }
 
 	chat_id, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrChatNotFound
+	}
 	if err != nil || chat_id == uuid.Nil {
 		return nil, err
 	}
@@ -321,6 +404,178 @@ func (uc *useCase) GetChatMessageOfSession(ctx context.Context, sessionID uuid.U
 	return uc.GetChatMessageByID(ctx, chat_id, limit, offset, userID)
 }
 
+// PinMessage pins or unpins a message
+func (uc *useCase) PinMessage(ctx context.Context, chatID, messageID, userID uuid.UUID, pinned bool) error {
+	if err := uc.canModerate(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	message, err := uc.chatRepo.GetMessageByID(ctx, messageID)
+	if err != nil || message.ChatID != chatID || message.DeletedAt != nil {
+		return ErrMessageNotFound
+	}
+
+	return uc.chatRepo.SetMessagePinned(ctx, messageID, pinned)
+}
+
+// validateContent normalizes a message and checks it fits the message type:
+// text is trimmed, stripped of control characters other than newlines and
+// tabs, and capped at maxMessageLength characters; an image must be an
+// http(s) URL to a common image file type.
+func (uc *useCase) validateContent(messageType models.MessageType, content string) (string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, content)
+	content = strings.TrimSpace(content)
+
+	if content == "" {
+		return "", fmt.Errorf("%w: message cannot be empty", ErrValidation)
+	}
+	if uc.maxMessageLength > 0 && utf8.RuneCountInString(content) > uc.maxMessageLength {
+		return "", fmt.Errorf("%w: message must be at most %d characters", ErrValidation, uc.maxMessageLength)
+	}
+
+	if messageType == models.MessageTypeImage {
+		if err := uc.imagePolicy.CheckURL(content); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+
+	return content, nil
+}
+
+// MuteChat turns the user's notifications for the chat off or back on
+// without leaving it
+func (uc *useCase) MuteChat(ctx context.Context, chatID, userID uuid.UUID, muted bool) error {
+	if _, err := uc.chatRepo.GetChatByID(ctx, chatID); err != nil {
+		return ErrChatNotFound
+	}
+
+	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !isPartOfChat {
+		return ErrUnauthorized
+	}
+
+	return uc.chatRepo.SetChatMuted(ctx, userID, chatID, muted)
+}
+
+// notifyMembers tells the chat's other members about a new message, skipping
+// those who muted it. It is best-effort; the message is already saved.
+func (uc *useCase) notifyMembers(ctx context.Context, message *models.Message) {
+	userIDs, err := uc.chatRepo.GetUnmutedUserIDs(ctx, message.ChatID)
+	if err != nil {
+		log.Printf("failed to get members of chat %s: %v", message.ChatID, err)
+		return
+	}
+
+	title := fmt.Sprintf("New message from %s %s", message.FirstName, message.LastName)
+	data := map[string]interface{}{
+		"chat_id":    message.ChatID,
+		"message_id": message.ID,
+	}
+
+	for _, userID := range userIDs {
+		if userID == message.SenderID {
+			continue
+		}
+		if err := uc.notificationUseCase.Notify(ctx, userID, models.NotificationTypeChatMessage, title, message.Content, data); err != nil {
+			log.Printf("failed to notify user %s: %v", userID, err)
+		}
+	}
+}
+
+// DeleteChat soft-deletes the chat and its messages, hiding it from every member
+func (uc *useCase) DeleteChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	if err := uc.canModerate(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	return uc.chatRepo.DeleteChat(ctx, chatID)
+}
+
+// canModerate checks the user may pin messages in or delete the chat. Either
+// member of a direct chat may; group and session chats only let their admins
+// or the session host.
+func (uc *useCase) canModerate(ctx context.Context, chatID, userID uuid.UUID) error {
+	chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	if err != nil {
+		return ErrChatNotFound
+	}
+
+	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !isPartOfChat {
+		return ErrUnauthorized
+	}
+
+	if chat.Type == models.ChatTypeDirect {
+		return nil
+	}
+
+	isAdmin, err := uc.chatRepo.IsUserChatAdmin(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrUnauthorized
+	}
+
+	return nil
+}
+
+func (uc *useCase) GetPinnedMessages(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) (*responses.ChatMassageListResponse, error) {
+	isPartOfChat, err := uc.chatRepo.IsUserPartOfChat(ctx, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if !isPartOfChat {
+		return nil, ErrUnauthorized
+	}
+
+	messages, err := uc.chatRepo.GetPinnedMessages(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	chatMassage := []responses.ChatMassageResponse{}
+	for _, m := range messages {
+		chatMassage = append(chatMassage, responses.ChatMassageResponse{
+			ID:     m.ID.String(),
+			ChatID: m.ChatID.String(),
+			Autor: responses.UserChatResponse{
+				ID:           m.SenderID.String(),
+				Email:        m.Email,
+				FirstName:    m.FirstName,
+				LastName:     m.LastName,
+				Phone:        m.Phone,
+				PlayLevel:    m.PlayLevel,
+				Location:     stringValue(m.Location),
+				Bio:          stringValue(m.Bio),
+				AvatarURL:    stringValue(m.AvatarURL),
+				LastActiveAt: m.LastActiveAt,
+			},
+			Message:       m.Content,
+			IsPinned:      m.IsPinned,
+			Timestamp:     m.CreatedAt,
+			EditTimeStamp: m.UpdatedAt,
+		})
+	}
+
+	return &responses.ChatMassageListResponse{
+		ChatID:      chatID.String(),
+		ChatMassage: chatMassage,
+	}, nil
+}
+
 func convertToUserListResponse(users []models.User) []responses.UserChatResponse {
 	userResponses := []responses.UserChatResponse{}
 
@@ -362,3 +617,11 @@ func convertToUserChatResponse(users []models.User) []responses.UserChatResponse
 
 	return userResponses
 }
+
+// stringValue returns "" for profile fields the sender never filled in
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
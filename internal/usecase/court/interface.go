@@ -4,6 +4,8 @@ import (
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,9 +13,21 @@ import (
 type UseCase interface {
 	CreateCourt(ctx context.Context, req requests.CreateCourtRequest) (*responses.CourtResponse, error)
 	GetCourt(ctx context.Context, id uuid.UUID) (*responses.CourtResponse, error)
+	GetCourtDetail(ctx context.Context, id uuid.UUID) (*responses.CourtDetailResponse, error)
 	UpdateCourt(ctx context.Context, id uuid.UUID, req requests.UpdateCourtRequest) (*responses.CourtResponse, error)
 	DeleteCourt(ctx context.Context, id uuid.UUID) error
 	ListCourts(ctx context.Context, req requests.ListCourtsRequest) (*responses.CourtListResponse, error)
 	GetVenueCourts(ctx context.Context, venueID uuid.UUID) ([]responses.CourtResponse, error)
 	UpdateCourtStatus(ctx context.Context, id uuid.UUID, status string) error
+	GetCourtBookings(ctx context.Context, courtID, ownerID uuid.UUID, date time.Time) ([]responses.BookingResponse, error)
+	RestoreCourt(ctx context.Context, courtID, userID uuid.UUID) (*responses.CourtResponse, error)
+	CheckAvailabilityBatch(ctx context.Context, req requests.BatchCourtAvailabilityRequest) (*responses.BatchCourtAvailabilityResponse, error)
 }
+
+var (
+	ErrCourtNotFound = errors.New("court not found")
+
+	ErrForbidden = errors.New("forbidden")
+
+	ErrValidation = errors.New("validation error")
+)
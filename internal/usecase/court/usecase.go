@@ -2,7 +2,9 @@ package court
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
@@ -13,21 +15,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// maxBatchAvailabilityCourts caps how many courts one availability check covers
+const maxBatchAvailabilityCourts = 50
+
 type useCase struct {
 	courtRepo   interfaces.CourtRepository
 	venueRepo   interfaces.VenueRepository
 	bookingRepo interfaces.BookingRepository
+	userRepo    interfaces.UserRepository
 }
 
 func NewCourtUseCase(
 	courtRepo interfaces.CourtRepository,
 	venueRepo interfaces.VenueRepository,
 	bookingRepo interfaces.BookingRepository,
+	userRepo interfaces.UserRepository,
 ) UseCase {
 	return &useCase{
 		courtRepo:   courtRepo,
 		venueRepo:   venueRepo,
 		bookingRepo: bookingRepo,
+		userRepo:    userRepo,
 	}
 }
 
@@ -47,12 +55,21 @@ func (uc *useCase) CreateCourt(ctx context.Context, req requests.CreateCourtRequ
 		return nil, fmt.Errorf("cannot create court for inactive venue")
 	}
 
+	maxPlayers := models.DefaultCourtMaxPlayers
+	if req.MaxPlayers != 0 {
+		if req.MaxPlayers < 1 {
+			return nil, fmt.Errorf("max players must be at least 1")
+		}
+		maxPlayers = req.MaxPlayers
+	}
+
 	court := &models.Court{
 		ID:           uuid.New(),
 		VenueID:      venueID,
 		Name:         req.Name,
 		Description:  req.Description,
 		PricePerHour: req.PricePerHour,
+		MaxPlayers:   maxPlayers,
 		Status:       models.CourtStatusAvailable,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
@@ -80,23 +97,77 @@ func (uc *useCase) GetCourt(ctx context.Context, id uuid.UUID) (*responses.Court
 	return uc.toCourtResponse(court), nil
 }
 
+// GetCourtDetail returns a court with its venue's name, location and opening
+// hours for today in the venue's timezone
+func (uc *useCase) GetCourtDetail(ctx context.Context, id uuid.UUID) (*responses.CourtDetailResponse, error) {
+	court, err := uc.courtRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCourtNotFound, err)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	var openRanges []responses.OpenRangeResponse
+	if err := json.Unmarshal(venue.OpenRange.RawMessage, &openRanges); err != nil {
+		return nil, fmt.Errorf("failed to read venue opening hours: %w", err)
+	}
+
+	today := time.Now().In(venue.TimeLocation()).Weekday().String()
+	todayHours := responses.OpenRangeResponse{Day: strings.ToLower(today)}
+	for _, openRange := range openRanges {
+		if strings.EqualFold(openRange.Day, today) {
+			todayHours = openRange
+			break
+		}
+	}
+
+	return &responses.CourtDetailResponse{
+		CourtResponse: *uc.toCourtResponse(court),
+		Venue: responses.CourtVenueResponse{
+			ID:         venue.ID.String(),
+			Name:       venue.Name,
+			Location:   venue.Location,
+			Timezone:   venue.TimeLocation().String(),
+			TodayHours: todayHours,
+		},
+	}, nil
+}
+
 func (uc *useCase) UpdateCourt(ctx context.Context, id uuid.UUID, req requests.UpdateCourtRequest) (*responses.CourtResponse, error) {
 	court, err := uc.courtRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("court not found: %w", err)
 	}
 
-	if req.Name != "" {
-		court.Name = req.Name
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, fmt.Errorf("court name cannot be empty")
+		}
+		court.Name = *req.Name
+	}
+	if req.Description != nil {
+		court.Description = *req.Description
 	}
-	if req.Description != "" {
-		court.Description = req.Description
+	if req.PricePerHour != nil {
+		if *req.PricePerHour <= 0 {
+			return nil, fmt.Errorf("price per hour must be greater than 0")
+		}
+		court.PricePerHour = *req.PricePerHour
 	}
-	if req.PricePerHour > 0 {
-		court.PricePerHour = req.PricePerHour
+	if req.MaxPlayers != nil {
+		if *req.MaxPlayers < 1 {
+			return nil, fmt.Errorf("max players must be at least 1")
+		}
+		court.MaxPlayers = *req.MaxPlayers
 	}
-	if req.Status != "" {
-		court.Status = models.CourtStatus(req.Status)
+	if req.Status != nil {
+		if !isValidCourtStatus(*req.Status) {
+			return nil, fmt.Errorf("invalid court status: %s", *req.Status)
+		}
+		court.Status = models.CourtStatus(*req.Status)
 	}
 
 	court.UpdatedAt = time.Now()
@@ -129,6 +200,152 @@ func (uc *useCase) DeleteCourt(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// GetCourtBookings returns a court's bookings on date. Only the venue owner may
+// see them since they reveal who booked.
+func (uc *useCase) GetCourtBookings(ctx context.Context, courtID, ownerID uuid.UUID, date time.Time) ([]responses.BookingResponse, error) {
+	court, err := uc.courtRepo.GetByID(ctx, courtID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCourtNotFound, err)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, court.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if venue.OwnerID != ownerID {
+		return nil, fmt.Errorf("%w: only the venue owner can view court bookings", ErrForbidden)
+	}
+
+	bookings, err := uc.bookingRepo.GetCourtBookings(ctx, courtID, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get court bookings: %w", err)
+	}
+
+	bookingResponses := make([]responses.BookingResponse, len(bookings))
+	for i, booking := range bookings {
+		bookingResponses[i] = *booking.ToResponse()
+	}
+
+	return bookingResponses, nil
+}
+
+// RestoreCourt undoes a soft delete. Only the venue owner or an admin may restore
+// a court, and its venue must not be deleted itself.
+func (uc *useCase) RestoreCourt(ctx context.Context, courtID, userID uuid.UUID) (*responses.CourtResponse, error) {
+	court, err := uc.courtRepo.GetByIDIncludingDeleted(ctx, courtID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCourtNotFound, err)
+	}
+
+	venue, err := uc.venueRepo.GetByIDIncludingDeleted(ctx, court.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	if venue.OwnerID != userID {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Role != string(models.UserRoleAdmin) {
+			return nil, fmt.Errorf("%w: only the venue owner can restore courts", ErrForbidden)
+		}
+	}
+
+	if court.DeletedAt == nil {
+		return nil, fmt.Errorf("%w: court is not deleted", ErrValidation)
+	}
+
+	if venue.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: venue is deleted, restore the venue first", ErrValidation)
+	}
+
+	if err := uc.courtRepo.Restore(ctx, courtID); err != nil {
+		return nil, fmt.Errorf("failed to restore court: %w", err)
+	}
+
+	court.DeletedAt = nil
+	return uc.toCourtResponse(court), nil
+}
+
+// CheckAvailabilityBatch checks the courts for the same window in one call, so a
+// booking grid doesn't need a request per court. A court under maintenance is never available.
+func (uc *useCase) CheckAvailabilityBatch(ctx context.Context, req requests.BatchCourtAvailabilityRequest) (*responses.BatchCourtAvailabilityResponse, error) {
+	if len(req.CourtIDs) == 0 {
+		return nil, fmt.Errorf("%w: court_ids is required", ErrValidation)
+	}
+	if len(req.CourtIDs) > maxBatchAvailabilityCourts {
+		return nil, fmt.Errorf("%w: at most %d courts can be checked at once", ErrValidation, maxBatchAvailabilityCourts)
+	}
+
+	date, startTime, endTime, err := parseAvailabilityWindow(req.Date, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	courtIDs := make([]uuid.UUID, 0, len(req.CourtIDs))
+	seen := make(map[uuid.UUID]bool)
+	for _, rawID := range req.CourtIDs {
+		courtID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid court ID %q", ErrValidation, rawID)
+		}
+		if !seen[courtID] {
+			seen[courtID] = true
+			courtIDs = append(courtIDs, courtID)
+		}
+	}
+
+	response := &responses.BatchCourtAvailabilityResponse{
+		Date:      date.Format("2006-01-02"),
+		StartTime: startTime.Format("15:04"),
+		EndTime:   endTime.Format("15:04"),
+		Courts:    make([]responses.CourtAvailabilityResponse, 0, len(courtIDs)),
+	}
+
+	for _, courtID := range courtIDs {
+		court, err := uc.courtRepo.GetByID(ctx, courtID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCourtNotFound, err)
+		}
+
+		available := court.Status != models.CourtStatusMaintenance
+		if available {
+			available, err = uc.bookingRepo.CheckCourtAvailability(ctx, courtID, date, startTime, endTime)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check availability: %w", err)
+			}
+		}
+
+		bookings, err := uc.bookingRepo.GetCourtBookings(ctx, courtID, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get court bookings: %w", err)
+		}
+
+		conflicts := make([]responses.BookingSlot, 0)
+		for _, booking := range bookings {
+			if booking.Status != models.BookingStatusCancelled {
+				conflicts = append(conflicts, responses.BookingSlot{
+					StartTime: booking.StartTime.Format("15:04"),
+					EndTime:   booking.EndTime.Format("15:04"),
+					Status:    string(booking.Status),
+				})
+			}
+		}
+
+		response.Courts = append(response.Courts, responses.CourtAvailabilityResponse{
+			CourtID:   courtID.String(),
+			CourtName: court.Name,
+			Date:      response.Date,
+			Available: available,
+			Conflicts: conflicts,
+		})
+	}
+
+	return response, nil
+}
+
 func (uc *useCase) ListCourts(ctx context.Context, req requests.ListCourtsRequest) (*responses.CourtListResponse, error) {
 	filters := make(map[string]interface{})
 
@@ -156,6 +373,12 @@ func (uc *useCase) ListCourts(ctx context.Context, req requests.ListCourtsReques
 		filters["price_max"] = req.PriceMax
 	}
 
+	if req.Date != "" || req.StartTime != "" || req.EndTime != "" {
+		if err := addAvailabilityFilter(filters, req.Date, req.StartTime, req.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get total count
 	total, err := uc.courtRepo.Count(ctx, filters)
 	if err != nil {
@@ -255,10 +478,50 @@ func (uc *useCase) toCourtResponse(court *models.Court) *responses.CourtResponse
 		Name:         court.Name,
 		Description:  description,
 		PricePerHour: court.PricePerHour,
+		MaxPlayers:   court.MaxPlayers,
 		Status:       string(court.Status),
 	}
 }
 
+// addAvailabilityFilter limits a court listing to courts with no booking or
+// session overlapping the window
+func addAvailabilityFilter(filters map[string]interface{}, date, start, end string) error {
+	day, startTime, endTime, err := parseAvailabilityWindow(date, start, end)
+	if err != nil {
+		return err
+	}
+
+	filters["available_date"] = day
+	filters["available_from"] = startTime
+	filters["available_to"] = endTime
+	return nil
+}
+
+// parseAvailabilityWindow reads a YYYY-MM-DD date and an HH:MM start and end on it
+func parseAvailabilityWindow(date, start, end string) (time.Time, time.Time, time.Time, error) {
+	if date == "" || start == "" || end == "" {
+		return time.Time{}, time.Time{}, time.Time{}, fmt.Errorf("%w: date, start_time and end_time must be given together", ErrValidation)
+	}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, time.Time{}, time.Time{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrValidation)
+	}
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return time.Time{}, time.Time{}, time.Time{}, fmt.Errorf("%w: start_time must be HH:MM", ErrValidation)
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil {
+		return time.Time{}, time.Time{}, time.Time{}, fmt.Errorf("%w: end_time must be HH:MM", ErrValidation)
+	}
+	if !endTime.After(startTime) {
+		return time.Time{}, time.Time{}, time.Time{}, fmt.Errorf("%w: end_time must be after start_time", ErrValidation)
+	}
+
+	return day, startTime, endTime, nil
+}
+
 func isValidCourtStatus(status string) bool {
 	validStatuses := map[string]bool{
 		string(models.CourtStatusAvailable):   true,
@@ -0,0 +1,19 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+type UseCase interface {
+	// ExportUserData writes everything stored about the user to w as one JSON
+	// document, section by section, so large histories are never held in memory
+	ExportUserData(ctx context.Context, userID uuid.UUID, w io.Writer) error
+}
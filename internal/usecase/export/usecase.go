@@ -0,0 +1,179 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/booking"
+	"badbuddy/internal/usecase/session"
+	"badbuddy/internal/usecase/user"
+
+	"github.com/google/uuid"
+)
+
+// exportBatchSize is how many reviews or messages are loaded per query
+const exportBatchSize = 500
+
+type useCase struct {
+	userUseCase    user.UseCase
+	sessionUseCase session.UseCase
+	bookingUseCase booking.UseCase
+	venueRepo      interfaces.VenueRepository
+	chatRepo       interfaces.ChatRepository
+}
+
+func NewExportUseCase(
+	userUseCase user.UseCase,
+	sessionUseCase session.UseCase,
+	bookingUseCase booking.UseCase,
+	venueRepo interfaces.VenueRepository,
+	chatRepo interfaces.ChatRepository,
+) UseCase {
+	return &useCase{
+		userUseCase:    userUseCase,
+		sessionUseCase: sessionUseCase,
+		bookingUseCase: bookingUseCase,
+		venueRepo:      venueRepo,
+		chatRepo:       chatRepo,
+	}
+}
+
+func (uc *useCase) ExportUserData(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	profile, err := uc.userUseCase.GetProfile(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUserNotFound, err)
+	}
+
+	out := &jsonStream{w: w}
+	out.raw(`{"exported_at":`)
+	out.value(time.Now().Format(time.RFC3339))
+	out.raw(`,"profile":`)
+	out.value(profile)
+
+	hosted, err := uc.sessionUseCase.GetMyHostedSessions(ctx, userID, true)
+	if err != nil {
+		return fmt.Errorf("failed to export hosted sessions: %w", err)
+	}
+	out.raw(`,"hosted_sessions":`)
+	out.value(hosted)
+
+	joined, err := uc.sessionUseCase.GetMyJoinedSessions(ctx, userID, true)
+	if err != nil {
+		return fmt.Errorf("failed to export joined sessions: %w", err)
+	}
+	out.raw(`,"joined_sessions":`)
+	out.value(joined)
+
+	bookings, err := uc.bookingUseCase.GetUserBookings(ctx, userID, true)
+	if err != nil {
+		return fmt.Errorf("failed to export bookings: %w", err)
+	}
+	out.raw(`,"bookings":`)
+	out.value(bookings)
+
+	out.raw(`,"reviews":`)
+	err = out.batches(func(limit, offset int) ([]interface{}, error) {
+		reviews, err := uc.venueRepo.GetUserReviews(ctx, userID, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]interface{}, len(reviews))
+		for i, review := range reviews {
+			items[i] = responses.ExportedReviewResponse{
+				ID:        review.ID.String(),
+				VenueID:   review.VenueID.String(),
+				Rating:    review.Rating,
+				Comment:   review.Comment,
+				CreatedAt: review.CreatedAt.Format(time.RFC3339),
+				UpdatedAt: review.UpdateAt.Format(time.RFC3339),
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export reviews: %w", err)
+	}
+
+	out.raw(`,"messages":`)
+	err = out.batches(func(limit, offset int) ([]interface{}, error) {
+		messages, err := uc.chatRepo.GetUserMessages(ctx, userID, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]interface{}, len(messages))
+		for i, message := range messages {
+			items[i] = responses.ExportedMessageResponse{
+				ID:        message.ID.String(),
+				ChatID:    message.ChatID.String(),
+				Type:      string(message.Type),
+				Content:   message.Content,
+				CreatedAt: message.CreatedAt.Format(time.RFC3339),
+				UpdatedAt: message.UpdatedAt.Format(time.RFC3339),
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export messages: %w", err)
+	}
+
+	out.raw("}")
+	return out.err
+}
+
+// jsonStream writes a JSON document piece by piece and keeps the first write error
+type jsonStream struct {
+	w   io.Writer
+	err error
+}
+
+func (s *jsonStream) raw(text string) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = io.WriteString(s.w, text)
+}
+
+func (s *jsonStream) value(v interface{}) {
+	if s.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.err = err
+		return
+	}
+	_, s.err = s.w.Write(data)
+}
+
+// batches writes a JSON array, fetching exportBatchSize items at a time until
+// a short page comes back
+func (s *jsonStream) batches(fetch func(limit, offset int) ([]interface{}, error)) error {
+	s.raw("[")
+	for offset := 0; s.err == nil; offset += exportBatchSize {
+		items, err := fetch(exportBatchSize, offset)
+		if err != nil {
+			return err
+		}
+
+		for i, item := range items {
+			if offset+i > 0 {
+				s.raw(",")
+			}
+			s.value(item)
+		}
+
+		if len(items) < exportBatchSize {
+			break
+		}
+	}
+	s.raw("]")
+	return s.err
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"sync"
+
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many undelivered notifications a slow subscriber may
+// queue before further ones are dropped for it (they remain in the database).
+const subscriberBuffer = 16
+
+// Broker is an in-process pub/sub that fans new notifications out to the
+// streams a user currently has open.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan responses.NotificationResponse]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[uuid.UUID]map[chan responses.NotificationResponse]struct{}),
+	}
+}
+
+// Subscribe registers a stream for userID. The returned function must be called
+// to unsubscribe once the stream is closed.
+func (b *Broker) Subscribe(userID uuid.UUID) (<-chan responses.NotificationResponse, func()) {
+	ch := make(chan responses.NotificationResponse, subscriberBuffer)
+
+	b.mu.Lock()
+	if _, ok := b.subscribers[userID]; !ok {
+		b.subscribers[userID] = make(map[chan responses.NotificationResponse]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[userID][ch]; !ok {
+			return
+		}
+		delete(b.subscribers[userID], ch)
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+		close(ch)
+	}
+}
+
+// Publish delivers a notification to every open stream of userID without blocking
+func (b *Broker) Publish(userID uuid.UUID, notification responses.NotificationResponse) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[userID] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// SMSSender sends a text message to an E.164 phone number
+type SMSSender interface {
+	Send(ctx context.Context, to, body string) error
+}
+
+type UseCase interface {
+	Notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title, body string, data interface{}) error
+	ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]responses.NotificationResponse, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (*responses.UnreadCountResponse, error)
+	MarkAsRead(ctx context.Context, id, userID uuid.UUID) error
+	Subscribe(userID uuid.UUID) (<-chan responses.NotificationResponse, func())
+	MarkAllAsRead(ctx context.Context, userID uuid.UUID) (*responses.MarkAllReadResponse, error)
+	// SendReminders texts players and bookers whose session or booking starts
+	// within lead. Each gets at most one reminder per session or booking.
+	SendReminders(ctx context.Context, lead time.Duration) (int, error)
+}
@@ -0,0 +1,176 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type useCase struct {
+	notificationRepo interfaces.NotificationRepository
+	broker           *Broker
+	smsSender        SMSSender
+}
+
+// NewNotificationUseCase sends SMS reminders through smsSender. With a nil
+// smsSender no reminders are sent.
+func NewNotificationUseCase(notificationRepo interfaces.NotificationRepository, broker *Broker, smsSender SMSSender) UseCase {
+	return &useCase{
+		notificationRepo: notificationRepo,
+		broker:           broker,
+		smsSender:        smsSender,
+	}
+}
+
+// Notify stores a notification for a user. data is optional and is stored as JSON
+// so clients can deep-link (e.g. {"session_id": "..."}).
+func (uc *useCase) Notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title, body string, data interface{}) error {
+	notification := &models.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		CreatedAt: time.Now(),
+	}
+
+	if body != "" {
+		notification.Body = &body
+	}
+
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification data: %w", err)
+		}
+		notification.Data = models.NullRawMessage{RawMessage: raw, Valid: true}
+	}
+
+	if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	uc.broker.Publish(userID, toNotificationResponse(notification))
+
+	return nil
+}
+
+func (uc *useCase) Subscribe(userID uuid.UUID) (<-chan responses.NotificationResponse, func()) {
+	return uc.broker.Subscribe(userID)
+}
+
+func (uc *useCase) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]responses.NotificationResponse, error) {
+	notifications, err := uc.notificationRepo.ListByUser(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notificationResponses := make([]responses.NotificationResponse, len(notifications))
+	for i := range notifications {
+		notificationResponses[i] = toNotificationResponse(&notifications[i])
+	}
+
+	return notificationResponses, nil
+}
+
+func (uc *useCase) GetUnreadCount(ctx context.Context, userID uuid.UUID) (*responses.UnreadCountResponse, error) {
+	count, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unread count: %w", err)
+	}
+
+	return &responses.UnreadCountResponse{
+		UnreadCount: count,
+	}, nil
+}
+
+func (uc *useCase) MarkAsRead(ctx context.Context, id, userID uuid.UUID) error {
+	if err := uc.notificationRepo.MarkRead(ctx, id, userID); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotificationNotFound, err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) MarkAllAsRead(ctx context.Context, userID uuid.UUID) (*responses.MarkAllReadResponse, error) {
+	marked, err := uc.notificationRepo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+
+	return &responses.MarkAllReadResponse{
+		Marked: int(marked),
+	}, nil
+}
+
+func (uc *useCase) SendReminders(ctx context.Context, lead time.Duration) (int, error) {
+	if uc.smsSender == nil {
+		return 0, nil
+	}
+
+	reminders, err := uc.notificationRepo.ListDueReminders(ctx, time.Now().Add(lead))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due reminders: %w", err)
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		// Claim the reminder before sending so a second instance running the
+		// same job can't text the player twice
+		claimed, err := uc.notificationRepo.MarkReminderSent(ctx, reminder.TargetID, reminder.UserID)
+		if err != nil {
+			return sent, fmt.Errorf("failed to record reminder: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := uc.smsSender.Send(ctx, reminder.Phone, reminderText(reminder)); err != nil {
+			log.Printf("failed to send reminder to user %s: %v", reminder.UserID, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// reminderText shows the start time in the venue's time zone
+func reminderText(reminder models.Reminder) string {
+	startsAt := reminder.StartsAt
+	if location, err := time.LoadLocation(reminder.Timezone); err == nil {
+		startsAt = startsAt.In(location)
+	}
+	when := startsAt.Format("Mon 2 Jan 15:04")
+
+	if reminder.Kind == models.ReminderKindBooking {
+		return fmt.Sprintf("BadBuddy reminder: your booking of %s at %s starts %s", reminder.Title, reminder.VenueName, when)
+	}
+	return fmt.Sprintf("BadBuddy reminder: %s at %s starts %s", reminder.Title, reminder.VenueName, when)
+}
+
+func toNotificationResponse(notification *models.Notification) responses.NotificationResponse {
+	response := responses.NotificationResponse{
+		ID:        notification.ID.String(),
+		Type:      string(notification.Type),
+		Title:     notification.Title,
+		Data:      notification.Data.RawMessage,
+		CreatedAt: notification.CreatedAt.Format(time.RFC3339),
+	}
+
+	if notification.Body != nil {
+		response.Body = *notification.Body
+	}
+	if notification.ReadAt != nil {
+		response.ReadAt = notification.ReadAt.Format(time.RFC3339)
+	}
+
+	return response
+}
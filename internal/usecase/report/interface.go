@@ -0,0 +1,30 @@
+package report
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrValidation = errors.New("validation error")
+
+	ErrReportNotFound = errors.New("report not found")
+
+	ErrTargetNotFound = errors.New("reported content not found")
+
+	ErrAlreadyReported = errors.New("you have already reported this")
+
+	ErrTooManyReports = errors.New("too many reports, please try again later")
+)
+
+type UseCase interface {
+	CreateReport(ctx context.Context, reporterID uuid.UUID, req requests.CreateReportRequest) (*responses.ReportResponse, error)
+	// ListReports and ResolveReport are for admins working the moderation queue
+	ListReports(ctx context.Context, req requests.ListReportsRequest) (*responses.ReportListResponse, error)
+	ResolveReport(ctx context.Context, id, adminID uuid.UUID, req requests.ResolveReportRequest) (*responses.ReportResponse, error)
+}
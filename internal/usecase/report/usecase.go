@@ -0,0 +1,209 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// A user may file at most maxReportsPerWindow reports per reportWindow
+	maxReportsPerWindow = 10
+	reportWindow        = 24 * time.Hour
+
+	maxReasonLength = 1000
+
+	defaultReportLimit = 20
+	maxReportLimit     = 100
+)
+
+type useCase struct {
+	reportRepo interfaces.ReportRepository
+}
+
+func NewReportUseCase(reportRepo interfaces.ReportRepository) UseCase {
+	return &useCase{
+		reportRepo: reportRepo,
+	}
+}
+
+func (uc *useCase) CreateReport(ctx context.Context, reporterID uuid.UUID, req requests.CreateReportRequest) (*responses.ReportResponse, error) {
+	targetType, err := parseTargetType(req.TargetType)
+	if err != nil {
+		return nil, err
+	}
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid target ID", ErrValidation)
+	}
+	if targetType == models.ReportTargetUser && targetID == reporterID {
+		return nil, fmt.Errorf("%w: you can't report yourself", ErrValidation)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(reason) > maxReasonLength {
+		return nil, fmt.Errorf("%w: the reason can be at most %d characters", ErrValidation, maxReasonLength)
+	}
+
+	recent, err := uc.reportRepo.CountByReporterSince(ctx, reporterID, time.Now().Add(-reportWindow))
+	if err != nil {
+		return nil, err
+	}
+	if recent >= maxReportsPerWindow {
+		return nil, ErrTooManyReports
+	}
+
+	exists, err := uc.reportRepo.TargetExists(ctx, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrTargetNotFound
+	}
+
+	reported, err := uc.reportRepo.HasOpenReport(ctx, reporterID, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if reported {
+		return nil, ErrAlreadyReported
+	}
+
+	report := &models.Report{
+		ID:         uuid.New(),
+		ReporterID: reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		Status:     models.ReportStatusOpen,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := uc.reportRepo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+
+	response := toReportResponse(report)
+	return &response, nil
+}
+
+// ListReports lists open reports unless another status is asked for, oldest first
+func (uc *useCase) ListReports(ctx context.Context, req requests.ListReportsRequest) (*responses.ReportListResponse, error) {
+	filters := interfaces.ReportFilters{
+		Status: models.ReportStatusOpen,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+
+	switch models.ReportStatus(req.Status) {
+	case "":
+	case models.ReportStatusOpen, models.ReportStatusResolved, models.ReportStatusDismissed:
+		filters.Status = models.ReportStatus(req.Status)
+	default:
+		return nil, fmt.Errorf("%w: status must be open, resolved or dismissed", ErrValidation)
+	}
+
+	if req.TargetType != "" {
+		targetType, err := parseTargetType(req.TargetType)
+		if err != nil {
+			return nil, err
+		}
+		filters.TargetType = targetType
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = defaultReportLimit
+	}
+	if filters.Limit > maxReportLimit {
+		filters.Limit = maxReportLimit
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	reports, err := uc.reportRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.reportRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.ReportListResponse{
+		Reports: make([]responses.ReportResponse, len(reports)),
+		Total:   total,
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
+	}
+	for i := range reports {
+		response.Reports[i] = toReportResponse(&reports[i])
+	}
+
+	return response, nil
+}
+
+// ResolveReport closes an open report as resolved or dismissed
+func (uc *useCase) ResolveReport(ctx context.Context, id, adminID uuid.UUID, req requests.ResolveReportRequest) (*responses.ReportResponse, error) {
+	status := models.ReportStatus(req.Status)
+	if status != models.ReportStatusResolved && status != models.ReportStatusDismissed {
+		return nil, fmt.Errorf("%w: status must be resolved or dismissed", ErrValidation)
+	}
+
+	if err := uc.reportRepo.Resolve(ctx, id, status, adminID); err != nil {
+		return nil, ErrReportNotFound
+	}
+
+	report, err := uc.reportRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+
+	response := toReportResponse(report)
+	return &response, nil
+}
+
+func parseTargetType(targetType string) (models.ReportTargetType, error) {
+	switch models.ReportTargetType(targetType) {
+	case models.ReportTargetSession, models.ReportTargetVenue, models.ReportTargetUser, models.ReportTargetMessage:
+		return models.ReportTargetType(targetType), nil
+	default:
+		return "", fmt.Errorf("%w: target type must be session, venue, user or message", ErrValidation)
+	}
+}
+
+func toReportResponse(report *models.Report) responses.ReportResponse {
+	response := responses.ReportResponse{
+		ID:         report.ID.String(),
+		ReporterID: report.ReporterID.String(),
+		TargetType: string(report.TargetType),
+		TargetID:   report.TargetID.String(),
+		Reason:     report.Reason,
+		Status:     string(report.Status),
+		CreatedAt:  report.CreatedAt.Format(time.RFC3339),
+	}
+
+	if report.ResolvedBy != nil {
+		resolvedBy := report.ResolvedBy.String()
+		response.ResolvedBy = &resolvedBy
+	}
+	if report.ResolvedAt != nil {
+		resolvedAt := report.ResolvedAt.Format(time.RFC3339)
+		response.ResolvedAt = &resolvedAt
+	}
+
+	return response
+}
@@ -0,0 +1,27 @@
+package search
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/responses"
+)
+
+var (
+	ErrValidation = errors.New("validation error")
+)
+
+// Type selects which group a search covers
+type Type string
+
+const (
+	TypeAll      Type = "all"
+	TypeVenues   Type = "venues"
+	TypeSessions Type = "sessions"
+	TypeUsers    Type = "users"
+)
+
+type UseCase interface {
+	// Search runs the query against the selected groups, returning at most limit results per group
+	Search(ctx context.Context, query string, searchType Type, limit int) (*responses.SearchResponse, error)
+}
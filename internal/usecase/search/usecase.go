@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/usecase/session"
+	"badbuddy/internal/usecase/user"
+	"badbuddy/internal/usecase/venue"
+)
+
+// MaxGroupSize caps how many results each group of a search may return
+const MaxGroupSize = 20
+
+// noPriceFilter disables the venue price range, as in the venue search endpoint
+const noPriceFilter = -99
+
+type useCase struct {
+	venueUseCase   venue.UseCase
+	sessionUseCase session.UseCase
+	userUseCase    user.UseCase
+}
+
+func NewSearchUseCase(venueUseCase venue.UseCase, sessionUseCase session.UseCase, userUseCase user.UseCase) UseCase {
+	return &useCase{
+		venueUseCase:   venueUseCase,
+		sessionUseCase: sessionUseCase,
+		userUseCase:    userUseCase,
+	}
+}
+
+func (uc *useCase) Search(ctx context.Context, query string, searchType Type, limit int) (*responses.SearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: q is required", ErrValidation)
+	}
+
+	if searchType == "" {
+		searchType = TypeAll
+	}
+	switch searchType {
+	case TypeAll, TypeVenues, TypeSessions, TypeUsers:
+	default:
+		return nil, fmt.Errorf("%w: type must be one of all, venues, sessions, users", ErrValidation)
+	}
+
+	if limit <= 0 || limit > MaxGroupSize {
+		limit = MaxGroupSize
+	}
+
+	result := &responses.SearchResponse{}
+
+	if searchType == TypeAll || searchType == TypeVenues {
+		venues, err := uc.venueUseCase.SearchVenues(ctx, query, limit, 0, noPriceFilter, noPriceFilter, "", []string{}, nil, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search venues: %w", err)
+		}
+		result.Venues = venues.Venues
+	}
+
+	if searchType == TypeAll || searchType == TypeSessions {
+		sessions, err := uc.sessionUseCase.SearchSessions(ctx, query, map[string]interface{}{}, limit, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search sessions: %w", err)
+		}
+		result.Sessions = sessions.Sessions
+	}
+
+	if searchType == TypeAll || searchType == TypeUsers {
+		users, err := uc.userUseCase.SearchUsers(ctx, query, requests.SearchFilters{Limit: limit})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+		result.Users = users
+	}
+
+	return result, nil
+}
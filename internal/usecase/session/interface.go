@@ -15,12 +15,22 @@ type UseCase interface {
 	GetSession(ctx context.Context, id uuid.UUID) (*responses.SessionResponse, error)
 	ListSessions(ctx context.Context, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error)
 	SearchSessions(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error)
-	JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) error
+	JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) (*responses.JoinSessionResponse, error)
 	LeaveSession(ctx context.Context, sessionID, userID uuid.UUID) error
 	CancelSession(ctx context.Context, sessionID, hostID uuid.UUID) error
+	BulkAddParticipants(ctx context.Context, sessionID, hostID uuid.UUID, req requests.BulkAddParticipantsRequest) (*responses.BulkAddParticipantsResponse, error)
+	BulkCancelSessions(ctx context.Context, hostID uuid.UUID, req requests.BulkCancelSessionsRequest) (*responses.BulkCancelSessionsResponse, error)
+	CheckIn(ctx context.Context, sessionID, callerID uuid.UUID, req requests.CheckInRequest) error
+	Announce(ctx context.Context, sessionID, hostID uuid.UUID, req requests.AnnounceRequest) error
+	CompleteSession(ctx context.Context, sessionID, hostID uuid.UUID, req requests.CompleteSessionRequest) error
+	GetRecommendedSessions(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.SessionListResponse, error)
+	GetSessionsBatch(ctx context.Context, req requests.BatchGetSessionsRequest) ([]responses.SessionResponse, error)
+	GetHostedSessions(ctx context.Context, hostID uuid.UUID, limit, offset int) (*responses.SessionListResponse, error)
 	GetUserSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.SessionResponse, error)
 	ChangeParticipantStatus(ctx context.Context, sessionID, hostID uuid.UUID, req requests.ChangeParticipantStatusRequest) error
 	GetSessionParticipants(ctx context.Context, sessionID uuid.UUID) ([]responses.ParticipantResponse, error)
+	// GetSessionChatID returns the session's chat for its host and confirmed players
+	GetSessionChatID(ctx context.Context, sessionID, userID uuid.UUID) (uuid.UUID, error)
 	GetMyJoinedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.SessionResponse, error)
 	GetMyHostedSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.SessionResponse, error)
 }
@@ -2,16 +2,24 @@ package session
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"math"
+	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
+	"badbuddy/internal/infrastructure/metrics"
 	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/notification"
 
 	"github.com/google/uuid"
 )
@@ -22,23 +30,157 @@ var (
 	ErrValidation = errors.New("validation error")
 
 	ErrSessionNotFound = errors.New("session not found")
+
+	ErrScheduleConflict = errors.New("schedule conflict")
+
+	ErrUserNotFound = errors.New("user not found")
+
+	ErrEmailNotVerified = errors.New("email not verified")
+
+	ErrSessionFull = errors.New("session is full")
+
+	ErrTooManyCheckInAttempts = errors.New("too many check-in attempts, please try again later")
+)
+
+const (
+	// maxCostPerPerson is a sanity cap on the per-player cost of a session
+	maxCostPerPerson = 10000.0
+
+	// Participant limits include the host, who joins as confirmed when the
+	// session is created, so the smallest session is the host and one player
+	minSessionParticipants = 2
+	maxSessionParticipants = 100
+
+	// checkInWindow is how long before the start time participants may check in
+	checkInWindow = time.Hour
+
+	// A caller gets maxCheckInAttempts wrong codes per session within
+	// checkInAttemptWindow before further attempts are refused
+	maxCheckInAttempts   = 5
+	checkInAttemptWindow = 15 * time.Minute
+
+	// Session length limits used when none are configured. They are unrelated to
+	// court booking limits: a session is a game, not a court reservation.
+	defaultMinSessionDuration = 30 * time.Minute
+	defaultMaxSessionDuration = 6 * time.Hour
+
+	// maxBatchSessions caps how many sessions GetSessionsBatch loads at once
+	maxBatchSessions = 50
+
+	// maxAnnouncementLength caps a host announcement in characters
+	maxAnnouncementLength = 1000
+
+	// maxJoinMessageLength caps the note a player sends with a join request
+	maxJoinMessageLength = 500
+
+	// defaultCancellationDeadline is how many hours before the start players may
+	// still leave a session when neither the host nor the config sets it
+	defaultCancellationDeadline = 24
 )
 
+// CourtsPolicy says how many players share a court in each play format. The courts a
+// session needs are always reported to hosts; Enforce also rejects sessions that
+// reserve fewer. Formats missing from PlayersPerCourt are never checked.
+type CourtsPolicy struct {
+	PlayersPerCourt map[models.SessionFormat]int
+	Enforce         bool
+}
+
+// DefaultCourtsPolicy puts two players on a singles court and four on a doubles
+// court, without rejecting sessions that reserve too few
+func DefaultCourtsPolicy() CourtsPolicy {
+	return CourtsPolicy{
+		PlayersPerCourt: map[models.SessionFormat]int{
+			models.SessionFormatSingles: 2,
+			models.SessionFormatDoubles: 4,
+		},
+	}
+}
+
+// CourtsNeeded is how many courts maxParticipants players take in the format, or 0 when unknown
+func (p CourtsPolicy) CourtsNeeded(format models.SessionFormat, maxParticipants int) int {
+	perCourt := p.PlayersPerCourt[format]
+	if perCourt <= 0 {
+		return 0
+	}
+	return (maxParticipants + perCourt - 1) / perCourt
+}
+
 type useCase struct {
 	sessionRepo interfaces.SessionRepository
 	venueRepo   interfaces.VenueRepository
 	chatRepo    interfaces.ChatRepository
+	userRepo    interfaces.UserRepository
+
+	notificationUseCase notification.UseCase
+
+	minDuration time.Duration
+	maxDuration time.Duration
+
+	defaultCancellationDeadlineHours int
+
+	courtsPolicy CourtsPolicy
+
+	checkInMu       sync.Mutex
+	checkInFailures map[checkInKey]checkInFailures
+}
+
+// checkInKey identifies a caller's check-in attempts at one session
+type checkInKey struct {
+	sessionID uuid.UUID
+	callerID  uuid.UUID
 }
 
-func NewSessionUseCase(sessionRepo interfaces.SessionRepository, venueRepo interfaces.VenueRepository, chatRepo interfaces.ChatRepository) UseCase {
+// checkInFailures counts wrong codes since the first one in the current window
+type checkInFailures struct {
+	count int
+	since time.Time
+}
+
+// NewSessionUseCase creates the session use case. minDuration and maxDuration bound
+// how long a session may be; zero values fall back to the defaults.
+// defaultCancellationDeadlineHours applies to sessions that allow cancellation
+// without setting a deadline; negative values fall back to the default.
+// courtsPolicy links max_participants to the courts a session reserves.
+func NewSessionUseCase(sessionRepo interfaces.SessionRepository, venueRepo interfaces.VenueRepository, chatRepo interfaces.ChatRepository, userRepo interfaces.UserRepository, notificationUseCase notification.UseCase, minDuration, maxDuration time.Duration, defaultCancellationDeadlineHours int, courtsPolicy CourtsPolicy) UseCase {
+	if minDuration <= 0 {
+		minDuration = defaultMinSessionDuration
+	}
+	if maxDuration <= 0 || maxDuration < minDuration {
+		maxDuration = defaultMaxSessionDuration
+	}
+	if defaultCancellationDeadlineHours < 0 {
+		defaultCancellationDeadlineHours = defaultCancellationDeadline
+	}
+
 	return &useCase{
 		sessionRepo: sessionRepo,
 		venueRepo:   venueRepo,
 		chatRepo:    chatRepo,
+		userRepo:    userRepo,
+
+		notificationUseCase: notificationUseCase,
+
+		minDuration: minDuration,
+		maxDuration: maxDuration,
+
+		defaultCancellationDeadlineHours: defaultCancellationDeadlineHours,
+
+		courtsPolicy: courtsPolicy,
+
+		checkInFailures: make(map[checkInKey]checkInFailures),
 	}
 }
 
 func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requests.CreateSessionRequest) (*responses.SessionResponse, error) {
+	host, err := uc.userRepo.GetByID(ctx, hostID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if !host.EmailVerified {
+		return nil, fmt.Errorf("%w: verify your email address before hosting a session", ErrEmailNotVerified)
+	}
+
 	// Validate venue exists and is active
 	venue, err := uc.venueRepo.GetByID(ctx, uuid.MustParse(req.VenueID))
 	if err != nil {
@@ -49,6 +191,26 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		return nil, fmt.Errorf("venue is not active")
 	}
 
+	costMode, err := parseCostMode(req.CostMode)
+	if err != nil {
+		return nil, err
+	}
+
+	playFormat, err := parsePlayFormat(req.PlayFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	if costMode == models.SessionCostModeFixed {
+		if err := uc.validateCostPerPerson(req.CostPerPerson); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.validateMaxParticipants(req.MaxParticipants); err != nil {
+		return nil, err
+	}
+
 	// Parse times
 	sessionDate, err := time.Parse("2006-01-02", req.SessionDate)
 	if err != nil {
@@ -60,9 +222,12 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		return nil, fmt.Errorf("invalid start time: %w", err)
 	}
 
-	endTime, err := time.Parse("15:04", req.EndTime)
-	if err != nil {
-		return nil, fmt.Errorf("invalid end time: %w", err)
+	var endTime time.Time
+	if !req.OpenEnded {
+		endTime, err = time.Parse("15:04", req.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end time: %w", err)
+		}
 	}
 
 	// Parse and validate court IDs
@@ -85,6 +250,47 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		return nil, fmt.Errorf("venue is closed on %s", sessionDate.Weekday())
 	}
 
+	if req.OpenEnded {
+		// The courts are held from the start until the venue closes
+		if endTime, err = venueCloseTime(&venue.Venue, sessionDate); err != nil {
+			return nil, err
+		}
+		if !startTime.Before(endTime) {
+			return nil, fmt.Errorf("%w: %s closes at %s", ErrValidation, venue.Name, endTime.Format("15:04"))
+		}
+	} else if err := uc.validateSessionDuration(startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	courtIDs := make([]uuid.UUID, 0, len(req.CourtIDs))
+	for _, id := range req.CourtIDs {
+		courtID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid court ID %q: %w", id, err)
+		}
+		courtIDs = append(courtIDs, courtID)
+	}
+	if err := uc.validateVenueCourts(ctx, venue.ID, venue.Name, courtIDs); err != nil {
+		return nil, err
+	}
+	if err := uc.validateCourtsRequired(playFormat, req.MaxParticipants, len(courtIDs)); err != nil {
+		return nil, err
+	}
+
+	var hourlyRate float64
+	if costMode == models.SessionCostModeSplit {
+		if len(courtIDs) == 0 {
+			return nil, fmt.Errorf("%w: a split cost session needs at least one court", ErrValidation)
+		}
+		for _, court := range venue.Courts {
+			for _, id := range courtIDs {
+				if court.ID == id {
+					hourlyRate += court.PricePerHour
+				}
+			}
+		}
+	}
+
 	// Validate session time including venue operating hours
 	// for _, openRange := range openRanges {
 
@@ -93,6 +299,25 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 	// }
 	// }
 
+	// The host can't run two games at once
+	if err := uc.checkUserScheduleConflict(ctx, hostID, sessionDate, startTime, endTime, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkSessionConflict(ctx, courtIDs, sessionDate, startTime, endTime, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	cancellationDeadlineHours, err := uc.cancellationDeadline(req, sessionDate, startTime, venue.TimeLocation())
+	if err != nil {
+		return nil, err
+	}
+
+	checkInCode, err := generateCheckInCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate check-in code: %w", err)
+	}
+
 	// Create session
 	session := &models.Session{
 		ID:                        uuid.New(),
@@ -103,22 +328,26 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		SessionDate:               sessionDate,
 		StartTime:                 startTime,
 		EndTime:                   endTime,
+		OpenEnded:                 req.OpenEnded,
 		PlayerLevel:               models.PlayerLevel(req.PlayerLevel),
 		MaxParticipants:           req.MaxParticipants,
 		CostPerPerson:             req.CostPerPerson,
+		CostMode:                  costMode,
+		PlayFormat:                playFormat,
 		AllowCancellation:         req.AllowCancellation,
-		CancellationDeadlineHours: &req.CancellationDeadlineHours,
+		CancellationDeadlineHours: cancellationDeadlineHours,
 		IsPublic:                  req.IsPublic,
+		CheckInCode:               &checkInCode,
 		Status:                    models.SessionStatusOpen,
 		CreatedAt:                 time.Now(),
 		UpdatedAt:                 time.Now(),
 	}
 
-	if err := uc.sessionRepo.Create(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+	if costMode == models.SessionCostModeSplit {
+		applyCostSplit(session, hourlyRate)
 	}
 
-	// Add host as confirmed participant
+	// Host joins as a confirmed participant in the same transaction as the session
 	participant := &models.SessionParticipant{
 		ID:        uuid.New(),
 		SessionID: session.ID,
@@ -127,8 +356,8 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		JoinedAt:  time.Now(),
 	}
 
-	if err := uc.sessionRepo.AddParticipant(ctx, participant); err != nil {
-		return nil, fmt.Errorf("failed to add host as participant: %w", err)
+	if err := uc.sessionRepo.CreateWithHost(ctx, session, participant, courtIDs, req.Rules); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
 	chat := models.Chat{
@@ -151,10 +380,18 @@ func (uc *useCase) CreateSession(ctx context.Context, hostID uuid.UUID, req requ
 		return nil, fmt.Errorf("failed to get session details: %w", err)
 	}
 
-	return uc.toSessionResponse(sessionDetail), nil
+	response := uc.toSessionResponse(sessionDetail)
+	response.CheckInCode = checkInCode
+
+	return response, nil
 }
 
 func (uc *useCase) SearchSessions(ctx context.Context, query string, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error) {
+	// An empty query matches everything, so skip the full-text search entirely
+	if strings.TrimSpace(query) == "" {
+		return uc.ListSessions(ctx, filters, limit, offset)
+	}
+
 	sessions, err := uc.sessionRepo.Search(ctx, query, filters, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search sessions: %w", err)
@@ -189,39 +426,102 @@ func (uc *useCase) UpdateSession(ctx context.Context, sessionID uuid.UUID, hostI
 	}
 
 	// Update fields if provided
-	if req.Title != "" {
-		session.Title = req.Title
+	if req.Title != nil {
+		if strings.TrimSpace(*req.Title) == "" {
+			return fmt.Errorf("%w: title cannot be empty", ErrValidation)
+		}
+		session.Title = *req.Title
+	}
+	if req.Description != nil {
+		session.Description = req.Description
 	}
-	if req.Description != "" {
-		session.Description = &req.Description
+	rescheduled, err := uc.reschedule(ctx, session, req)
+	if err != nil {
+		return err
 	}
-	if req.PlayerLevel != "" {
-		if err := uc.validatePlayerLevel(req.PlayerLevel); err != nil {
+	if req.PlayerLevel != nil {
+		if err := uc.validatePlayerLevel(*req.PlayerLevel); err != nil {
 			return err
 		}
-		session.PlayerLevel = models.PlayerLevel(req.PlayerLevel)
+		session.PlayerLevel = models.PlayerLevel(*req.PlayerLevel)
 	}
-	if req.MaxParticipants > 0 {
+	if req.MaxParticipants != nil {
+		if err := uc.validateMaxParticipants(*req.MaxParticipants); err != nil {
+			return err
+		}
 		confirmedCount, _ := uc.countParticipantsByStatus(session.Participants)
-		if err := uc.validateParticipantLimit(confirmedCount, req.MaxParticipants); err != nil {
+		if err := uc.validateParticipantLimit(confirmedCount, *req.MaxParticipants); err != nil {
 			return err
 		}
-		session.MaxParticipants = req.MaxParticipants
+		session.MaxParticipants = *req.MaxParticipants
+
+		// The host's place counts toward the limit like every other confirmed player
+		switch {
+		case session.Status == models.SessionStatusOpen && confirmedCount >= session.MaxParticipants:
+			session.Status = models.SessionStatusFull
+		case session.Status == models.SessionStatusFull && confirmedCount < session.MaxParticipants:
+			session.Status = models.SessionStatusOpen
+		}
 	}
-	if req.CostPerPerson >= 0 {
-		session.CostPerPerson = req.CostPerPerson
+	if req.PlayFormat != nil {
+		playFormat, err := parsePlayFormat(*req.PlayFormat)
+		if err != nil {
+			return err
+		}
+		session.PlayFormat = playFormat
 	}
-	if req.Status != "" {
-		session.Status = models.SessionStatus(req.Status)
+	if req.MaxParticipants != nil || req.PlayFormat != nil {
+		if err := uc.validateCourtsRequired(session.PlayFormat, session.MaxParticipants, len(session.Courts)); err != nil {
+			return err
+		}
+	}
+	if req.CostMode != nil {
+		costMode, err := parseCostMode(*req.CostMode)
+		if err != nil {
+			return err
+		}
+		session.CostMode = costMode
+	}
+	if req.CostPerPerson != nil {
+		if session.CostMode == models.SessionCostModeSplit {
+			return fmt.Errorf("%w: cost per person is calculated from the courts for split cost sessions", ErrValidation)
+		}
+		if err := uc.validateCostPerPerson(*req.CostPerPerson); err != nil {
+			return err
+		}
+		session.CostPerPerson = *req.CostPerPerson
+	}
+	if session.CostMode == models.SessionCostModeSplit {
+		// Court prices or capacity may have changed since the last split
+		hourlyRate, err := uc.sessionRepo.GetCourtsHourlyRate(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if hourlyRate == 0 {
+			return fmt.Errorf("%w: a split cost session needs at least one court", ErrValidation)
+		}
+		applyCostSplit(&session.Session, hourlyRate)
+	} else {
+		session.TotalCourtCost = 0
+	}
+	if req.Status != nil {
+		session.Status = models.SessionStatus(*req.Status)
 	}
 
 	// Update cancellation settings
-	session.AllowCancellation = req.AllowCancellation
-	if req.CancellationDeadlineHours > 0 {
-		session.CancellationDeadlineHours = &req.CancellationDeadlineHours
+	if req.AllowCancellation != nil {
+		session.AllowCancellation = *req.AllowCancellation
+	}
+	if req.CancellationDeadlineHours != nil {
+		if err := validateCancellationDeadline(*req.CancellationDeadlineHours, sessionStartTime(session)); err != nil {
+			return err
+		}
+		session.CancellationDeadlineHours = req.CancellationDeadlineHours
 	}
 
-	session.IsPublic = req.IsPublic
+	if req.IsPublic != nil {
+		session.IsPublic = *req.IsPublic
+	}
 
 	session.UpdatedAt = time.Now()
 
@@ -229,59 +529,808 @@ func (uc *useCase) UpdateSession(ctx context.Context, sessionID uuid.UUID, hostI
 		return fmt.Errorf("failed to update session: %w", err)
 	}
 
+	if rescheduled {
+		uc.notifyRescheduled(ctx, session)
+	}
+
+	return nil
+}
+
+// reschedule moves the session to the date and times in the request, checking
+// the venue's hours and the host's and courts' other sessions again. It
+// reports whether anything changed.
+func (uc *useCase) reschedule(ctx context.Context, session *models.SessionDetail, req requests.UpdateSessionRequest) (bool, error) {
+	if req.SessionDate == nil && req.StartTime == nil && req.EndTime == nil && req.OpenEnded == nil {
+		return false, nil
+	}
+
+	openEnded := session.OpenEnded
+	if req.OpenEnded != nil {
+		openEnded = *req.OpenEnded
+	}
+
+	sessionDate, startTime, endTime := session.SessionDate, session.StartTime, session.EndTime
+	var err error
+	if req.SessionDate != nil {
+		if sessionDate, err = time.Parse("2006-01-02", *req.SessionDate); err != nil {
+			return false, fmt.Errorf("%w: invalid session date", ErrValidation)
+		}
+	}
+	if req.StartTime != nil {
+		if startTime, err = time.Parse("15:04", *req.StartTime); err != nil {
+			return false, fmt.Errorf("%w: invalid start time", ErrValidation)
+		}
+	}
+	switch {
+	case req.EndTime != nil && openEnded:
+		return false, fmt.Errorf("%w: an open-ended session has no end time", ErrValidation)
+	case req.EndTime != nil:
+		if endTime, err = time.Parse("15:04", *req.EndTime); err != nil {
+			return false, fmt.Errorf("%w: invalid end time", ErrValidation)
+		}
+	case session.OpenEnded && !openEnded:
+		return false, fmt.Errorf("%w: set an end time when the session stops being open-ended", ErrValidation)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, session.VenueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get venue: %w", err)
+	}
+	if openEnded {
+		// Follow the venue's closing time on the (new) date
+		if endTime, err = venueCloseTime(&venue.Venue, sessionDate); err != nil {
+			return false, err
+		}
+	}
+
+	if openEnded == session.OpenEnded &&
+		sessionDate.Format("2006-01-02") == session.SessionDate.Format("2006-01-02") &&
+		startTime.Format("15:04") == session.StartTime.Format("15:04") &&
+		endTime.Format("15:04") == session.EndTime.Format("15:04") {
+		return false, nil
+	}
+
+	if openEnded {
+		if !startTime.Before(endTime) {
+			return false, fmt.Errorf("%w: %s closes at %s", ErrValidation, venue.Name, endTime.Format("15:04"))
+		}
+	} else if err := uc.validateSessionDuration(startTime, endTime); err != nil {
+		return false, err
+	}
+	start := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
+		startTime.Hour(), startTime.Minute(), 0, 0, venue.TimeLocation())
+	if !start.After(time.Now()) {
+		return false, fmt.Errorf("%w: a session can only be moved to a time in the future", ErrValidation)
+	}
+	if err := checkVenueHours(&venue.Venue, sessionDate, startTime, endTime); err != nil {
+		return false, err
+	}
+
+	if err := uc.checkUserScheduleConflict(ctx, session.HostID, sessionDate, startTime, endTime, session.ID); err != nil {
+		return false, err
+	}
+	if err := uc.checkCourtsFree(ctx, session, sessionDate, startTime, endTime); err != nil {
+		return false, err
+	}
+
+	session.SessionDate = sessionDate
+	session.StartTime = startTime
+	session.EndTime = endTime
+	session.OpenEnded = openEnded
+	return true, nil
+}
+
+// venueCloseTime returns when the venue closes on the date's weekday, which is
+// where an open-ended session's court reservation ends
+func venueCloseTime(venue *models.Venue, sessionDate time.Time) (time.Time, error) {
+	var openRanges []responses.OpenRangeResponse
+	if err := json.Unmarshal(venue.OpenRange.RawMessage, &openRanges); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read venue opening hours: %w", err)
+	}
+
+	for _, schedule := range openRanges {
+		if !strings.EqualFold(schedule.Day, sessionDate.Weekday().String()) {
+			continue
+		}
+		if !schedule.IsOpen {
+			break
+		}
+		return time.Parse("15:04", schedule.CloseTime.Format("15:04"))
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %s is closed on %s", ErrValidation, venue.Name, sessionDate.Weekday())
+}
+
+// checkVenueHours rejects times outside the venue's opening hours for that weekday
+func checkVenueHours(venue *models.Venue, sessionDate, startTime, endTime time.Time) error {
+	var openRanges []responses.OpenRangeResponse
+	if err := json.Unmarshal(venue.OpenRange.RawMessage, &openRanges); err != nil {
+		return fmt.Errorf("failed to read venue opening hours: %w", err)
+	}
+
+	for _, schedule := range openRanges {
+		if !strings.EqualFold(schedule.Day, sessionDate.Weekday().String()) {
+			continue
+		}
+		if !schedule.IsOpen {
+			break
+		}
+		if startTime.Format("15:04") < schedule.OpenTime.Format("15:04") || endTime.Format("15:04") > schedule.CloseTime.Format("15:04") {
+			return fmt.Errorf("%w: %s is open from %s to %s on %s", ErrValidation, venue.Name,
+				schedule.OpenTime.Format("15:04"), schedule.CloseTime.Format("15:04"), sessionDate.Weekday())
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s is closed on %s", ErrValidation, venue.Name, sessionDate.Weekday())
+}
+
+// checkCourtsFree makes sure no other session holds one of the session's
+// courts at the new time
+func (uc *useCase) checkCourtsFree(ctx context.Context, session *models.SessionDetail, sessionDate, startTime, endTime time.Time) error {
+	current, err := uc.sessionRepo.GetVenueSessionCourts(ctx, session.VenueID, session.SessionDate)
+	if err != nil {
+		return err
+	}
+	var courtIDs []uuid.UUID
+	for _, sc := range current {
+		if sc.SessionID == session.ID {
+			courtIDs = append(courtIDs, sc.CourtID)
+		}
+	}
+
+	return uc.checkSessionConflict(ctx, courtIDs, sessionDate, startTime, endTime, session.ID)
+}
+
+// notifyRescheduled tells the confirmed players the session's new time. It is
+// best-effort: the session has already moved.
+func (uc *useCase) notifyRescheduled(ctx context.Context, session *models.SessionDetail) {
+	participants, err := uc.sessionRepo.GetParticipants(ctx, session.ID)
+	if err != nil {
+		log.Printf("failed to get participants of session %s: %v", session.ID, err)
+		return
+	}
+
+	title := fmt.Sprintf("Session rescheduled: %s", session.Title)
+	content := fmt.Sprintf("%s now takes place on %s, %s - %s", session.Title,
+		session.SessionDate.Format("Mon 2 Jan"), session.StartTime.Format("15:04"), session.EndTime.Format("15:04"))
+	data := map[string]interface{}{
+		"session_id":   session.ID,
+		"session_date": session.SessionDate.Format("2006-01-02"),
+		"start_time":   session.StartTime.Format("15:04"),
+		"end_time":     session.EndTime.Format("15:04"),
+	}
+	for _, p := range participants {
+		if p.Status != models.ParticipantStatusConfirmed || p.UserID == session.HostID {
+			continue
+		}
+		if err := uc.notificationUseCase.Notify(ctx, p.UserID, models.NotificationTypeSessionUpdated, title, content, data); err != nil {
+			log.Printf("failed to notify user %s: %v", p.UserID, err)
+		}
+	}
+}
+
+// validateVenueCourts makes sure every court is a live court of the venue and
+// is listed once, so a session can't reserve another venue's courts
+func (uc *useCase) validateVenueCourts(ctx context.Context, venueID uuid.UUID, venueName string, courtIDs []uuid.UUID) error {
+	if len(courtIDs) == 0 {
+		return nil
+	}
+
+	courts, err := uc.venueRepo.GetCourts(ctx, venueID)
+	if err != nil {
+		return fmt.Errorf("failed to get venue courts: %w", err)
+	}
+	venueCourts := make(map[uuid.UUID]bool, len(courts))
+	for _, court := range courts {
+		venueCourts[court.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(courtIDs))
+	for _, id := range courtIDs {
+		if !venueCourts[id] {
+			return fmt.Errorf("%w: court %s is not a court of %s", ErrValidation, id, venueName)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: court %s is listed more than once", ErrValidation, id)
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// cancellationDeadline picks the deadline for a new session. Sessions that
+// allow cancellation without a deadline get the configured default, shortened
+// to the lead time when the session starts sooner than that.
+func (uc *useCase) cancellationDeadline(req requests.CreateSessionRequest, sessionDate, startTime time.Time, loc *time.Location) (*int, error) {
+	start := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
+		startTime.Hour(), startTime.Minute(), 0, 0, loc)
+
+	if req.CancellationDeadlineHours != nil {
+		if err := validateCancellationDeadline(*req.CancellationDeadlineHours, start); err != nil {
+			return nil, err
+		}
+		return req.CancellationDeadlineHours, nil
+	}
+	if !req.AllowCancellation {
+		return nil, nil
+	}
+
+	hours := uc.defaultCancellationDeadlineHours
+	if leadHours := int(time.Until(start).Hours()); hours > leadHours {
+		hours = max(leadHours, 0)
+	}
+	return &hours, nil
+}
+
+// validateCancellationDeadline rejects deadlines that would fall before now:
+// players could never leave such a session
+func validateCancellationDeadline(hours int, start time.Time) error {
+	if hours < 0 {
+		return fmt.Errorf("%w: cancellation deadline hours cannot be negative", ErrValidation)
+	}
+	if leadTime := time.Until(start); time.Duration(hours)*time.Hour > leadTime {
+		return fmt.Errorf("%w: a cancellation deadline of %d hours is longer than the %s until the session starts",
+			ErrValidation, hours, formatDuration(max(leadTime, 0).Truncate(time.Minute)))
+	}
+	return nil
+}
+
+// parseCostMode defaults an empty mode to fixed
+func parseCostMode(mode string) (models.SessionCostMode, error) {
+	switch models.SessionCostMode(mode) {
+	case "", models.SessionCostModeFixed:
+		return models.SessionCostModeFixed, nil
+	case models.SessionCostModeSplit:
+		return models.SessionCostModeSplit, nil
+	default:
+		return "", fmt.Errorf("%w: cost mode must be fixed or split", ErrValidation)
+	}
+}
+
+func parsePlayFormat(format string) (models.SessionFormat, error) {
+	switch models.SessionFormat(format) {
+	case "", models.SessionFormatDoubles:
+		return models.SessionFormatDoubles, nil
+	case models.SessionFormatSingles:
+		return models.SessionFormatSingles, nil
+	default:
+		return "", fmt.Errorf("%w: play format must be singles or doubles", ErrValidation)
+	}
+}
+
+// validateCourtsRequired rejects a session that reserves fewer courts than its
+// players need, when the courts policy enforces it
+func (uc *useCase) validateCourtsRequired(format models.SessionFormat, maxParticipants, courtCount int) error {
+	if !uc.courtsPolicy.Enforce {
+		return nil
+	}
+
+	needed := uc.courtsPolicy.CourtsNeeded(format, maxParticipants)
+	if courtCount < needed {
+		return fmt.Errorf("%w: %d players in a %s session need at least %d courts, %d reserved",
+			ErrValidation, maxParticipants, format, needed, courtCount)
+	}
+
+	return nil
+}
+
+// applyCostSplit prices the session from its courts: the total court cost for the
+// session's duration, divided evenly across every place in the session
+func applyCostSplit(session *models.Session, hourlyRate float64) {
+	hours := session.EndTime.Sub(session.StartTime).Hours()
+	session.TotalCourtCost = roundCurrency(hourlyRate * hours)
+	session.CostPerPerson = roundCurrency(session.TotalCourtCost / float64(session.MaxParticipants))
+}
+
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// validateCostPerPerson rejects negative costs and implausibly large ones
+func (uc *useCase) validateCostPerPerson(cost float64) error {
+	if cost < 0 {
+		return fmt.Errorf("%w: cost per person cannot be negative", ErrValidation)
+	}
+	if cost > maxCostPerPerson {
+		return fmt.Errorf("%w: cost per person cannot exceed %.2f", ErrValidation, maxCostPerPerson)
+	}
+	return nil
+}
+
+// validateMaxParticipants checks the participant capacity is within sane bounds
+func (uc *useCase) validateMaxParticipants(maxParticipants int) error {
+	if maxParticipants < minSessionParticipants || maxParticipants > maxSessionParticipants {
+		return fmt.Errorf("%w: max participants must be between %d and %d",
+			ErrValidation, minSessionParticipants, maxSessionParticipants)
+	}
 	return nil
 }
 
-// validateParticipantLimit validates the participant limit
-func (uc *useCase) validateParticipantLimit(confirmedCount, maxParticipants int) error {
-	if confirmedCount > maxParticipants {
-		return fmt.Errorf("confirmed participants exceed the maximum allowed")
+// validateParticipantLimit validates the participant limit
+func (uc *useCase) validateParticipantLimit(confirmedCount, maxParticipants int) error {
+	if confirmedCount > maxParticipants {
+		return fmt.Errorf("confirmed participants exceed the maximum allowed")
+	}
+	return nil
+}
+
+// JoinSession adds the user to the session and its chat. With OpenDirectChat
+// it also opens, or reuses, a direct chat between the player and the host.
+func (uc *useCase) JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) (*responses.JoinSessionResponse, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session not found: %w", err)
+	}
+
+	if err := uc.canJoinSession(session, userID); err != nil {
+		return nil, err
+	}
+
+	var joinMessage *string
+	if message := strings.TrimSpace(req.Message); message != "" {
+		if len([]rune(message)) > maxJoinMessageLength {
+			return nil, fmt.Errorf("%w: message must be at most %d characters", ErrValidation, maxJoinMessageLength)
+		}
+		joinMessage = &message
+	}
+
+	// Check if user is already participating
+	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	if isParticipating, status := uc.isParticipantInSession(participants, userID); isParticipating {
+		if status == models.ParticipantStatusCancelled {
+			return nil, fmt.Errorf("you have previously cancelled participation in this session")
+		}
+		// Joining again is a no-op so clients can safely retry
+		return &responses.JoinSessionResponse{Status: string(status)}, nil
+	}
+
+	confirmedCount, _ := uc.countParticipantsByStatus(participants)
+	full := confirmedCount >= session.MaxParticipants
+	if full && !req.WaitlistOK {
+		return nil, ErrSessionFull
+	}
+
+	// Players can't be in two sessions at the same time
+	if err := uc.checkUserScheduleConflict(ctx, userID, session.SessionDate, session.StartTime, session.EndTime, sessionID); err != nil {
+		return nil, err
+	}
+
+	// Private sessions need the host's approval, and a full session can only
+	// put the player on its waitlist until a place frees up
+	status := models.ParticipantStatusConfirmed
+	if !session.IsPublic || full {
+		status = models.ParticipantStatusPending
+	}
+
+	participant := &models.SessionParticipant{
+		ID:          uuid.New(),
+		SessionID:   sessionID,
+		UserID:      userID,
+		Status:      status,
+		JoinedAt:    time.Now(),
+		JoinMessage: joinMessage,
+	}
+
+	added, err := uc.sessionRepo.AddParticipant(ctx, participant)
+	if errors.Is(err, interfaces.ErrSessionFull) {
+		// Someone took the last place since the participants were read
+		if !req.WaitlistOK {
+			return nil, ErrSessionFull
+		}
+		participant.Status = models.ParticipantStatusPending
+		status = participant.Status
+		added, err = uc.sessionRepo.AddParticipant(ctx, participant)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add participant: %w", err)
+	}
+	if !added {
+		// A concurrent request for the same user got there first and takes
+		// care of the chat, so report the status it joined with
+		participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participants: %w", err)
+		}
+		_, existing := uc.isParticipantInSession(participants, userID)
+		return &responses.JoinSessionResponse{Status: string(existing)}, nil
+	}
+
+	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat ID: %w", err)
+	}
+
+	if err := uc.chatRepo.AddUserToChat(ctx, userID, chatID); err != nil {
+		return nil, fmt.Errorf("failed to add user to chat: %w", err)
+	}
+
+	// AddParticipant has marked the session full if this took the last place
+	if status == models.ParticipantStatusPending {
+		uc.notifyJoinRequest(ctx, session, userID, joinMessage)
+	}
+
+	result := &responses.JoinSessionResponse{Status: string(status)}
+	if req.OpenDirectChat && session.HostID != userID {
+		// Best-effort: the player has joined either way
+		directChatID, err := uc.chatRepo.GetDirectChatID(ctx, userID, session.HostID)
+		if err != nil {
+			log.Printf("failed to open direct chat between %s and host %s: %v", userID, session.HostID, err)
+		} else {
+			result.DirectChatID = directChatID.String()
+		}
+	}
+
+	return result, nil
+}
+
+// BulkAddParticipants lets the host add a known roster as confirmed players in
+// one go. Either all new players fit in the session or none are added.
+func (uc *useCase) BulkAddParticipants(ctx context.Context, sessionID, hostID uuid.UUID, req requests.BulkAddParticipantsRequest) (*responses.BulkAddParticipantsResponse, error) {
+	if len(req.UserIDs)+len(req.Emails) == 0 {
+		return nil, fmt.Errorf("%w: user_ids or emails is required", ErrValidation)
+	}
+	if len(req.UserIDs)+len(req.Emails) > maxSessionParticipants {
+		return nil, fmt.Errorf("%w: at most %d players can be added at once", ErrValidation, maxSessionParticipants)
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+
+	if session.HostID != hostID {
+		return nil, fmt.Errorf("%w: only host can add participants", ErrUnauthorized)
+	}
+
+	if err := uc.canJoinSession(session, hostID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	users, err := uc.resolveRoster(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		overlapping, err := uc.sessionRepo.GetUserOverlappingSessions(ctx, user.ID, session.SessionDate, session.StartTime, session.EndTime, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check schedule conflicts: %w", err)
+		}
+		if len(overlapping) > 0 {
+			return nil, fmt.Errorf("%w: %s %s already has a session at this time (%s)",
+				ErrScheduleConflict, user.FirstName, user.LastName, overlapping[0].Title)
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+
+	added, ok, err := uc.sessionRepo.AddConfirmedParticipants(ctx, sessionID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add participants: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: the players don't fit in the session's %d places", ErrSessionFull, session.MaxParticipants)
+	}
+
+	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat ID: %w", err)
+	}
+
+	result := &responses.BulkAddParticipantsResponse{AddedUserIDs: []string{}, SkippedUserIDs: []string{}}
+	isAdded := make(map[uuid.UUID]bool, len(added))
+	for _, userID := range added {
+		isAdded[userID] = true
+		if err := uc.chatRepo.AddUserToChat(ctx, userID, chatID); err != nil {
+			return nil, fmt.Errorf("failed to add user to chat: %w", err)
+		}
+		result.AddedUserIDs = append(result.AddedUserIDs, userID.String())
+	}
+	for _, userID := range userIDs {
+		if !isAdded[userID] {
+			result.SkippedUserIDs = append(result.SkippedUserIDs, userID.String())
+		}
+	}
+
+	title := fmt.Sprintf("Added to %s", session.Title)
+	content := fmt.Sprintf("The host added you to %s on %s, %s - %s", session.Title,
+		session.SessionDate.Format("Mon 2 Jan"), session.StartTime.Format("15:04"), session.EndTime.Format("15:04"))
+	data := map[string]interface{}{
+		"session_id": sessionID,
+		"chat_id":    chatID,
+	}
+	for _, userID := range added {
+		if err := uc.notificationUseCase.Notify(ctx, userID, models.NotificationTypeParticipant, title, content, data); err != nil {
+			log.Printf("failed to notify user %s: %v", userID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// resolveRoster looks up the users of a bulk add by ID and email, once each
+func (uc *useCase) resolveRoster(ctx context.Context, req requests.BulkAddParticipantsRequest) ([]models.User, error) {
+	ids := make([]uuid.UUID, 0, len(req.UserIDs))
+	seen := map[uuid.UUID]bool{}
+	for _, rawID := range req.UserIDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid user ID %q", ErrValidation, rawID)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	users := []models.User{}
+	if len(ids) > 0 {
+		found, err := uc.userRepo.GetUsersByIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		if len(found) != len(ids) {
+			byID := make(map[uuid.UUID]bool, len(found))
+			for _, user := range found {
+				byID[user.ID] = true
+			}
+			for _, id := range ids {
+				if !byID[id] {
+					return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+				}
+			}
+		}
+		users = append(users, found...)
+	}
+
+	for _, email := range req.Emails {
+		user, err := uc.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+		if err != nil {
+			return nil, fmt.Errorf("%w: no user with email %s", ErrUserNotFound, email)
+		}
+		if !seen[user.ID] {
+			seen[user.ID] = true
+			users = append(users, *user)
+		}
+	}
+
+	return users, nil
+}
+
+// notifyJoinRequest asks the host of a private session to approve a player,
+// passing on the player's message. It is best-effort: the request is saved.
+func (uc *useCase) notifyJoinRequest(ctx context.Context, session *models.SessionDetail, userID uuid.UUID, joinMessage *string) {
+	name := "A player"
+	if user, err := uc.userRepo.GetByID(ctx, userID); err == nil {
+		name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	}
+
+	title := fmt.Sprintf("Join request: %s", session.Title)
+	content := fmt.Sprintf("%s asked to join %s", name, session.Title)
+	if joinMessage != nil {
+		content = fmt.Sprintf("%s asked to join %s: %s", name, session.Title, *joinMessage)
+	}
+	data := map[string]interface{}{
+		"session_id": session.ID,
+		"user_id":    userID,
+	}
+	if err := uc.notificationUseCase.Notify(ctx, session.HostID, models.NotificationTypeParticipant, title, content, data); err != nil {
+		log.Printf("failed to notify user %s: %v", session.HostID, err)
+	}
+}
+
+func (uc *useCase) LeaveSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	// Check if user is host
+	if session.HostID == userID {
+		return fmt.Errorf("host cannot leave session, use cancel instead")
+	}
+
+	// Check cancellation policy
+	if !session.AllowCancellation {
+		return fmt.Errorf("cancellation is not allowed for this session")
+	}
+
+	if session.CancellationDeadlineHours != nil {
+		// Anchor the deadline to the start time in the venue's zone, not midnight
+		deadline := sessionStartTime(session).Add(-time.Duration(*session.CancellationDeadlineHours) * time.Hour)
+		if time.Now().After(deadline) {
+			return fmt.Errorf("cancellation deadline has passed")
+		}
+	}
+
+	// Check if user is participating
+	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	isParticipating, currentStatus := uc.isParticipantInSession(participants, userID)
+	if !isParticipating {
+		return fmt.Errorf("user is not participating in this session")
+	}
+
+	// Update participant status to cancelled
+	if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, userID, models.ParticipantStatusCancelled); err != nil {
+		return fmt.Errorf("failed to update participant status: %w", err)
+	}
+
+	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get chat ID: %w", err)
+	}
+
+	if err := uc.chatRepo.RemoveUserFromChat(ctx, userID, chatID); err != nil {
+		return fmt.Errorf("failed to remove user from chat: %w", err)
+	}
+
+	if currentStatus != models.ParticipantStatusConfirmed {
+		return nil
+	}
+
+	// Pending players of a public session are its waitlist, so the one who joined
+	// first takes the free place. In a private session they wait for the host.
+	if session.IsPublic {
+		promotedID, promoted, err := uc.sessionRepo.PromoteWaitlisted(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to promote waitlisted participant: %w", err)
+		}
+		if promoted {
+			uc.notifyPromoted(ctx, session, promotedID)
+		}
+		return nil
+	}
+
+	if session.Status == models.SessionStatusFull {
+		session.Status = models.SessionStatusOpen
+		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
+			return fmt.Errorf("failed to update session status: %w", err)
+		}
+	}
+	return nil
+}
+
+// notifyPromoted tells a waitlisted player they now have a place in the session
+func (uc *useCase) notifyPromoted(ctx context.Context, session *models.SessionDetail, userID uuid.UUID) {
+	title := fmt.Sprintf("You're in: %s", session.Title)
+	content := fmt.Sprintf("A place opened up in %s and you have been moved off the waitlist", session.Title)
+	data := map[string]interface{}{
+		"session_id": session.ID,
+	}
+	if err := uc.notificationUseCase.Notify(ctx, userID, models.NotificationTypeParticipant, title, content, data); err != nil {
+		log.Printf("failed to notify user %s: %v", userID, err)
+	}
+}
+
+func (uc *useCase) CancelSession(ctx context.Context, sessionID, hostID uuid.UUID) error {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+
+	// Verify host
+	if session.HostID != hostID {
+		return fmt.Errorf("only host can cancel session")
+	}
+
+	if session.Status == models.SessionStatusCancelled || session.Status == models.SessionStatusCompleted {
+		return fmt.Errorf("session is already cancelled or completed")
+	}
+
+	// Update session status
+	session.Status = models.SessionStatusCancelled
+	session.UpdatedAt = time.Now()
+
+	if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
+		return fmt.Errorf("failed to update session status: %w", err)
+	}
+	metrics.SessionsCancelled.WithLabelValues("host").Inc()
+
+	// Update all active participants to cancelled
+	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get chat ID: %w", err)
+	}
+
+	for _, p := range participants {
+		if p.Status != models.ParticipantStatusCancelled {
+			if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, p.UserID, models.ParticipantStatusCancelled); err != nil {
+				return fmt.Errorf("failed to update participant status: %w", err)
+			}
+
+			if err := uc.chatRepo.RemoveUserFromChat(ctx, p.UserID, chatID); err != nil {
+				return fmt.Errorf("failed to remove user from chat: %w", err)
+			}
+		}
+	}
+
+	// The session chat goes with the session
+	if err := uc.chatRepo.DeleteChat(ctx, chatID); err != nil {
+		return fmt.Errorf("failed to delete session chat: %w", err)
 	}
+
 	return nil
 }
 
-func (uc *useCase) JoinSession(ctx context.Context, sessionID, userID uuid.UUID, req requests.JoinSessionRequest) error {
-	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+// BulkCancelSessions cancels every active session the host runs on a date,
+// optionally limited to one venue
+func (uc *useCase) BulkCancelSessions(ctx context.Context, hostID uuid.UUID, req requests.BulkCancelSessionsRequest) (*responses.BulkCancelSessionsResponse, error) {
+	sessionDate, err := time.Parse("2006-01-02", req.SessionDate)
 	if err != nil {
-		return fmt.Errorf("session not found: %w", err)
+		return nil, fmt.Errorf("%w: invalid session date format", ErrValidation)
 	}
 
-	if err := uc.canJoinSession(session, userID); err != nil {
-		return err
+	var venueID uuid.UUID
+	if req.VenueID != "" {
+		venueID, err = uuid.Parse(req.VenueID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid venue ID format", ErrValidation)
+		}
 	}
 
-	// Check if user is already participating
-	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+	sessions, err := uc.sessionRepo.GetMyHostedSessions(ctx, hostID, true)
 	if err != nil {
-		return fmt.Errorf("failed to get participants: %w", err)
+		return nil, fmt.Errorf("failed to get hosted sessions: %w", err)
 	}
 
-	if isParticipating, status := uc.isParticipantInSession(participants, userID); isParticipating {
-		if status == models.ParticipantStatusCancelled {
-			return fmt.Errorf("you have previously cancelled participation in this session")
+	result := &responses.BulkCancelSessionsResponse{CancelledSessionIDs: []string{}}
+	for _, session := range sessions {
+		if session.SessionDate.Format("2006-01-02") != sessionDate.Format("2006-01-02") {
+			continue
+		}
+		if venueID != uuid.Nil && session.VenueID != venueID {
+			continue
+		}
+		if session.Status == models.SessionStatusCancelled || session.Status == models.SessionStatusCompleted {
+			continue
 		}
-		return fmt.Errorf("you are already participating in this session")
+
+		if err := uc.CancelSession(ctx, session.ID, hostID); err != nil {
+			return nil, fmt.Errorf("failed to cancel session %s after cancelling %d: %w", session.ID, len(result.CancelledSessionIDs), err)
+		}
+		result.CancelledSessionIDs = append(result.CancelledSessionIDs, session.ID.String())
 	}
 
-	confirmedCount, _ := uc.countParticipantsByStatus(participants)
-	if confirmedCount >= session.MaxParticipants {
-		return fmt.Errorf("session is full")
+	return result, nil
+}
+
+// Announce posts a system message from the host to the session chat and
+// notifies every confirmed participant
+func (uc *useCase) Announce(ctx context.Context, sessionID, hostID uuid.UUID, req requests.AnnounceRequest) error {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSessionNotFound, err)
 	}
-	status := models.ParticipantStatusConfirmed
-	if !session.IsPublic {
-		status = models.ParticipantStatusPending
+
+	if session.HostID != hostID {
+		return fmt.Errorf("%w: only host can announce", ErrUnauthorized)
 	}
 
-	participant := &models.SessionParticipant{
-		ID:        uuid.New(),
-		SessionID: sessionID,
-		UserID:    userID,
-		Status:    status,
-		JoinedAt:  time.Now(),
+	if session.Status == models.SessionStatusCancelled || session.Status == models.SessionStatusCompleted {
+		return fmt.Errorf("%w: session is already cancelled or completed", ErrValidation)
 	}
 
-	if err := uc.sessionRepo.AddParticipant(ctx, participant); err != nil {
-		return fmt.Errorf("failed to add participant: %w", err)
+	content := strings.TrimSpace(req.Message)
+	if content == "" {
+		return fmt.Errorf("%w: message cannot be empty", ErrValidation)
+	}
+	if len([]rune(content)) > maxAnnouncementLength {
+		return fmt.Errorf("%w: message must be at most %d characters", ErrValidation, maxAnnouncementLength)
 	}
 
 	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
@@ -289,142 +1338,168 @@ func (uc *useCase) JoinSession(ctx context.Context, sessionID, userID uuid.UUID,
 		return fmt.Errorf("failed to get chat ID: %w", err)
 	}
 
-	if err := uc.chatRepo.AddUserToChat(ctx, userID, chatID); err != nil {
-		return fmt.Errorf("failed to add user to chat: %w", err)
+	message := models.Message{
+		ID:       uuid.New(),
+		ChatID:   chatID,
+		SenderID: hostID,
+		Type:     models.MessageTypeSystem,
+		Content:  content,
+		Status:   models.MessageStatusSent,
+	}
+	if _, err := uc.chatRepo.SaveMessage(ctx, &message); err != nil {
+		return fmt.Errorf("failed to save announcement: %w", err)
 	}
 
-	// Update session status if max participants reached
-	if status == models.ParticipantStatusConfirmed && confirmedCount+1 >= session.MaxParticipants {
-		session.Status = models.SessionStatusFull
-		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-			return fmt.Errorf("failed to update session status: %w", err)
+	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	title := fmt.Sprintf("Announcement: %s", session.Title)
+	data := map[string]interface{}{
+		"session_id": sessionID,
+		"chat_id":    chatID,
+		"message_id": message.ID,
+	}
+	for _, p := range participants {
+		if p.Status != models.ParticipantStatusConfirmed || p.UserID == hostID {
+			continue
+		}
+		// Best-effort: the announcement is already in the chat
+		if err := uc.notificationUseCase.Notify(ctx, p.UserID, models.NotificationTypeSessionAnnouncement, title, content, data); err != nil {
+			log.Printf("failed to notify user %s: %v", p.UserID, err)
 		}
 	}
 
 	return nil
 }
 
-func (uc *useCase) LeaveSession(ctx context.Context, sessionID, userID uuid.UUID) error {
+func (uc *useCase) CheckIn(ctx context.Context, sessionID, callerID uuid.UUID, req requests.CheckInRequest) error {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("session not found: %w", err)
+		return fmt.Errorf("%w: %v", ErrSessionNotFound, err)
 	}
 
-	// Check if user is host
-	if session.HostID == userID {
-		return fmt.Errorf("host cannot leave session, use cancel instead")
+	userID := callerID
+	if req.UserID != "" {
+		userID, err = uuid.Parse(req.UserID)
+		if err != nil {
+			return fmt.Errorf("%w: invalid user ID", ErrValidation)
+		}
 	}
 
-	// Check cancellation policy
-	if !session.AllowCancellation {
-		return fmt.Errorf("cancellation is not allowed for this session")
+	// Participants check themselves in; only the host may check in someone else
+	if userID != callerID && session.HostID != callerID {
+		return fmt.Errorf("%w: only host can check in other participants", ErrUnauthorized)
 	}
 
-	if session.CancellationDeadlineHours != nil {
-		deadline := session.SessionDate.Add(-time.Duration(*session.CancellationDeadlineHours) * time.Hour)
-		if time.Now().After(deadline) {
-			return fmt.Errorf("cancellation deadline has passed")
-		}
+	if session.Status == models.SessionStatusCancelled || session.Status == models.SessionStatusCompleted {
+		return fmt.Errorf("%w: session is already cancelled or completed", ErrValidation)
 	}
 
-	// Check if user is participating
-	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to get participants: %w", err)
+	if time.Now().Before(sessionStartTime(session).Add(-checkInWindow)) {
+		return fmt.Errorf("%w: check-in opens %s before the session starts", ErrValidation, checkInWindow)
 	}
 
-	isParticipating, currentStatus := uc.isParticipantInSession(participants, userID)
-	if !isParticipating {
-		return fmt.Errorf("user is not participating in this session")
+	// The code is short, so wrong guesses are limited per caller and session
+	key := checkInKey{sessionID: sessionID, callerID: callerID}
+	if !uc.checkInAllowed(key) {
+		return ErrTooManyCheckInAttempts
 	}
+	if session.CheckInCode == nil || subtle.ConstantTimeCompare([]byte(*session.CheckInCode), []byte(req.Code)) != 1 {
+		uc.recordCheckInFailure(key)
+		return fmt.Errorf("%w: invalid check-in code", ErrValidation)
+	}
+	uc.clearCheckInFailures(key)
 
-	// Update participant status to cancelled
-	if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, userID, models.ParticipantStatusCancelled); err != nil {
-		return fmt.Errorf("failed to update participant status: %w", err)
+	if err := uc.sessionRepo.CheckInParticipant(ctx, sessionID, userID); err != nil {
+		return fmt.Errorf("%w: only confirmed participants can check in", ErrValidation)
 	}
 
-	// // If user was confirmed, try to promote a pending participant
-	// if currentStatus == models.ParticipantStatusConfirmed {
-	// 	for _, p := range participants {
-	// 		if p.Status == models.ParticipantStatusPending {
-	// 			if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, p.UserID, models.ParticipantStatusConfirmed); err != nil {
-	// 				return fmt.Errorf("failed to promote pending participant: %w", err)
-	// 			}
-	// 			return nil
-	// 		}
-	// 	}
-
-	// 	// No pending participants and session was full, update to open
-	// 	if session.Status == models.SessionStatusFull {
-	// 		session.Status = models.SessionStatusOpen
-	// 		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-	// 			return fmt.Errorf("failed to update session status: %w", err)
-	// 		}
-	// 	}
-	// }
+	return nil
+}
 
-	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to get chat ID: %w", err)
-	}
+// checkInAllowed reports whether the caller has wrong guesses left in the current window
+func (uc *useCase) checkInAllowed(key checkInKey) bool {
+	uc.checkInMu.Lock()
+	defer uc.checkInMu.Unlock()
 
-	if err := uc.chatRepo.RemoveUserFromChat(ctx, userID, chatID); err != nil {
-		return fmt.Errorf("failed to remove user from chat: %w", err)
-	}
+	failures, ok := uc.checkInFailures[key]
+	return !ok || time.Since(failures.since) >= checkInAttemptWindow || failures.count < maxCheckInAttempts
+}
 
-	if currentStatus == models.ParticipantStatusConfirmed && session.Status == models.SessionStatusFull {
-		session.Status = models.SessionStatusOpen
-		if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-			return fmt.Errorf("failed to update session status: %w", err)
+func (uc *useCase) recordCheckInFailure(key checkInKey) {
+	uc.checkInMu.Lock()
+	defer uc.checkInMu.Unlock()
+
+	now := time.Now()
+	// Expired windows no longer limit anything, so drop them to keep the map small
+	for k, failures := range uc.checkInFailures {
+		if now.Sub(failures.since) >= checkInAttemptWindow {
+			delete(uc.checkInFailures, k)
 		}
 	}
-	return nil
+
+	failures, ok := uc.checkInFailures[key]
+	if !ok {
+		failures.since = now
+	}
+	failures.count++
+	uc.checkInFailures[key] = failures
 }
 
-func (uc *useCase) CancelSession(ctx context.Context, sessionID, hostID uuid.UUID) error {
+func (uc *useCase) clearCheckInFailures(key checkInKey) {
+	uc.checkInMu.Lock()
+	defer uc.checkInMu.Unlock()
+
+	delete(uc.checkInFailures, key)
+}
+
+func (uc *useCase) CompleteSession(ctx context.Context, sessionID, hostID uuid.UUID, req requests.CompleteSessionRequest) error {
 	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("session not found: %w", err)
+		return fmt.Errorf("%w: %v", ErrSessionNotFound, err)
 	}
 
 	// Verify host
 	if session.HostID != hostID {
-		return fmt.Errorf("only host can cancel session")
+		return fmt.Errorf("%w: only host can complete session", ErrUnauthorized)
 	}
 
 	if session.Status == models.SessionStatusCancelled || session.Status == models.SessionStatusCompleted {
-		return fmt.Errorf("session is already cancelled or completed")
+		return fmt.Errorf("%w: session is already cancelled or completed", ErrValidation)
 	}
 
-	// Update session status
-	session.Status = models.SessionStatusCancelled
-	session.UpdatedAt = time.Now()
-
-	if err := uc.sessionRepo.Update(ctx, &session.Session); err != nil {
-		return fmt.Errorf("failed to update session status: %w", err)
+	if time.Now().Before(sessionStartTime(session)) {
+		return fmt.Errorf("%w: cannot complete session that has not started yet", ErrValidation)
 	}
 
-	// Update all active participants to cancelled
 	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get participants: %w", err)
 	}
-
-	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
-	if err != nil {
-		return fmt.Errorf("failed to get chat ID: %w", err)
-	}
-
+	confirmed := make(map[uuid.UUID]bool, len(participants))
 	for _, p := range participants {
-		if p.Status != models.ParticipantStatusCancelled {
-			if err := uc.sessionRepo.UpdateParticipantStatus(ctx, sessionID, p.UserID, models.ParticipantStatusCancelled); err != nil {
-				return fmt.Errorf("failed to update participant status: %w", err)
-			}
+		if p.Status == models.ParticipantStatusConfirmed {
+			confirmed[p.UserID] = true
+		}
+	}
 
-			if err := uc.chatRepo.RemoveUserFromChat(ctx, p.UserID, chatID); err != nil {
-				return fmt.Errorf("failed to remove user from chat: %w", err)
-			}
+	attended := make([]uuid.UUID, 0, len(req.Attended))
+	for _, id := range req.Attended {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("%w: invalid attended user ID %q", ErrValidation, id)
 		}
+		if !confirmed[userID] {
+			return fmt.Errorf("%w: attended user %s is not a confirmed participant", ErrValidation, userID)
+		}
+		attended = append(attended, userID)
+	}
+
+	// Confirmed participants who neither checked in nor were marked attended are no-shows
+	if err := uc.sessionRepo.CompleteSession(ctx, sessionID, attended); err != nil {
+		return fmt.Errorf("failed to complete session: %w", err)
 	}
 
 	return nil
@@ -439,6 +1514,49 @@ func (uc *useCase) GetSession(ctx context.Context, id uuid.UUID) (*responses.Ses
 	return uc.toSessionResponse(session), nil
 }
 
+// GetSessionsBatch returns the requested sessions in the order they were asked for,
+// skipping IDs that don't exist
+func (uc *useCase) GetSessionsBatch(ctx context.Context, req requests.BatchGetSessionsRequest) ([]responses.SessionResponse, error) {
+	if len(req.IDs) == 0 {
+		return nil, fmt.Errorf("%w: ids is required", ErrValidation)
+	}
+	if len(req.IDs) > maxBatchSessions {
+		return nil, fmt.Errorf("%w: at most %d sessions can be requested at once", ErrValidation, maxBatchSessions)
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, rawID := range req.IDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid session ID %q", ErrValidation, rawID)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	sessions, err := uc.sessionRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*models.SessionDetail, len(sessions))
+	for i := range sessions {
+		byID[sessions[i].ID] = &sessions[i]
+	}
+
+	sessionResponses := make([]responses.SessionResponse, 0, len(sessions))
+	for _, id := range ids {
+		if session, ok := byID[id]; ok {
+			sessionResponses = append(sessionResponses, *uc.toSessionResponse(session))
+		}
+	}
+
+	return sessionResponses, nil
+}
+
 func (uc *useCase) ListSessions(ctx context.Context, filters map[string]interface{}, limit, offset int) (*responses.SessionListResponse, error) {
 	sessions, err := uc.sessionRepo.List(ctx, filters, limit, offset)
 	if err != nil {
@@ -456,6 +1574,82 @@ func (uc *useCase) ListSessions(ctx context.Context, filters map[string]interfac
 	}, nil
 }
 
+// GetRecommendedSessions lists upcoming open sessions matching the user's play level
+// and location, leaving out the ones they host or already joined
+func (uc *useCase) GetRecommendedSessions(ctx context.Context, userID uuid.UUID, limit, offset int) (*responses.SessionListResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	filters := map[string]interface{}{
+		"status":          string(models.SessionStatusOpen),
+		"is_public":       true,
+		"from_date":       time.Now().Format("2006-01-02"),
+		"exclude_user_id": userID,
+	}
+	if user.PlayLevel != "" {
+		filters["player_level"] = string(user.PlayLevel)
+	}
+	if user.Location != "" {
+		filters["location"] = user.Location
+	}
+
+	sessions, err := uc.sessionRepo.List(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessionResponses := make([]responses.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		if !sessionStartTime(&sessions[i]).After(now) {
+			continue
+		}
+		sessionResponses = append(sessionResponses, *uc.toSessionResponse(&sessions[i]))
+	}
+
+	return &responses.SessionListResponse{
+		Sessions: sessionResponses,
+		Total:    len(sessionResponses),
+	}, nil
+}
+
+// GetHostedSessions lists a host's upcoming public sessions for their profile page
+func (uc *useCase) GetHostedSessions(ctx context.Context, hostID uuid.UUID, limit, offset int) (*responses.SessionListResponse, error) {
+	if _, err := uc.userRepo.GetByID(ctx, hostID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	filters := map[string]interface{}{
+		"host_id":   hostID,
+		"is_public": true,
+		"from_date": time.Now().Format("2006-01-02"),
+	}
+
+	sessions, err := uc.sessionRepo.List(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessionResponses := make([]responses.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		if sessions[i].Status == models.SessionStatusCancelled || sessions[i].Status == models.SessionStatusCompleted {
+			continue
+		}
+		if !sessionStartTime(&sessions[i]).After(now) {
+			continue
+		}
+		sessionResponses = append(sessionResponses, *uc.toSessionResponse(&sessions[i]))
+	}
+
+	return &responses.SessionListResponse{
+		Sessions: sessionResponses,
+		Total:    len(sessionResponses),
+	}, nil
+}
+
 func (uc *useCase) GetUserSessions(ctx context.Context, userID uuid.UUID, includeHistory bool) ([]responses.SessionResponse, error) {
 	sessions, err := uc.sessionRepo.GetUserSessions(ctx, userID, includeHistory)
 	if err != nil {
@@ -493,6 +1687,10 @@ func (uc *useCase) GetMyHostedSessions(ctx context.Context, userID uuid.UUID, in
 	sessionResponses := make([]responses.SessionResponse, len(sessions))
 	for i, session := range sessions {
 		sessionResponses[i] = *uc.toSessionResponse(&session)
+		// The caller hosts these sessions, so they may see the check-in code
+		if session.CheckInCode != nil {
+			sessionResponses[i].CheckInCode = *session.CheckInCode
+		}
 	}
 
 	return sessionResponses, nil
@@ -571,6 +1769,30 @@ func (uc *useCase) ChangeParticipantStatus(ctx context.Context, sessionID, hostI
 	return nil
 }
 
+func (uc *useCase) GetSessionChatID(ctx context.Context, sessionID, userID uuid.UUID) (uuid.UUID, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+
+	if session.HostID != userID {
+		participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to get participants: %w", err)
+		}
+		if _, status := uc.isParticipantInSession(participants, userID); status != models.ParticipantStatusConfirmed {
+			return uuid.Nil, fmt.Errorf("%w: only confirmed players can read the session chat", ErrUnauthorized)
+		}
+	}
+
+	chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, sessionID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get chat ID: %w", err)
+	}
+
+	return chatID, nil
+}
+
 func (uc *useCase) GetSessionParticipants(ctx context.Context, sessionID uuid.UUID) ([]responses.ParticipantResponse, error) {
 	participants, err := uc.sessionRepo.GetParticipants(ctx, sessionID)
 	if err != nil {
@@ -589,6 +1811,9 @@ func (uc *useCase) GetSessionParticipants(ctx context.Context, sessionID uuid.UU
 		if p.CancelledAt != nil {
 			participantResponses[i].CancelledAt = p.CancelledAt.Format(time.RFC3339)
 		}
+		if p.JoinMessage != nil {
+			participantResponses[i].JoinMessage = *p.JoinMessage
+		}
 	}
 
 	return participantResponses, nil
@@ -608,6 +1833,12 @@ func (uc *useCase) toSessionResponse(session *models.SessionDetail) *responses.S
 		if p.CancelledAt != nil {
 			participants[i].CancelledAt = p.CancelledAt.Format(time.RFC3339)
 		}
+		if p.CheckedInAt != nil {
+			participants[i].CheckedInAt = p.CheckedInAt.Format(time.RFC3339)
+		}
+		if p.JoinMessage != nil {
+			participants[i].JoinMessage = *p.JoinMessage
+		}
 	}
 
 	// confirmedPlayers, pendingPlayers := uc.countParticipantsByStatus(session.Participants)
@@ -622,6 +1853,34 @@ func (uc *useCase) toSessionResponse(session *models.SessionDetail) *responses.S
 		cancellationDeadlineHours = session.CancellationDeadlineHours
 	}
 
+	var costPerConfirmedPlayer float64
+	if session.CostMode == models.SessionCostModeSplit && session.ConfirmedPlayers > 0 {
+		costPerConfirmedPlayer = roundCurrency(session.TotalCourtCost / float64(session.ConfirmedPlayers))
+	}
+
+	openSpots := max(session.MaxParticipants-session.ConfirmedPlayers, 0)
+
+	var rules []responses.SessionRuleResponse
+	for _, rule := range session.Rules {
+		rules = append(rules, responses.SessionRuleResponse{
+			ID:        rule.ID.String(),
+			RuleText:  rule.RuleText,
+			CreatedAt: rule.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	var courts []responses.CourtResponse
+	for _, court := range session.Courts {
+		courts = append(courts, responses.CourtResponse{
+			ID:           court.ID.String(),
+			Name:         court.Name,
+			Description:  court.Description,
+			PricePerHour: court.PricePerHour,
+			MaxPlayers:   court.MaxPlayers,
+			Status:       string(court.Status),
+		})
+	}
+
 	return &responses.SessionResponse{
 		ID:                        session.ID.String(),
 		Title:                     session.Title,
@@ -635,16 +1894,25 @@ func (uc *useCase) toSessionResponse(session *models.SessionDetail) *responses.S
 		SessionDate:               session.SessionDate.Format("2006-01-02"),
 		StartTime:                 session.StartTime.Format("15:04"),
 		EndTime:                   session.EndTime.Format("15:04"),
+		OpenEnded:                 session.OpenEnded,
 		PlayerLevel:               string(session.PlayerLevel),
 		MaxParticipants:           session.MaxParticipants,
 		CostPerPerson:             session.CostPerPerson,
+		CostMode:                  string(session.CostMode),
+		PlayFormat:                string(session.PlayFormat),
+		CourtsNeeded:              uc.courtsPolicy.CourtsNeeded(session.PlayFormat, session.MaxParticipants),
+		TotalCourtCost:            session.TotalCourtCost,
+		CostPerConfirmedPlayer:    costPerConfirmedPlayer,
 		Status:                    string(session.Status),
 		AllowCancellation:         session.AllowCancellation,
 		CancellationDeadlineHours: cancellationDeadlineHours,
 		IsPublic:                  session.IsPublic,
 		ConfirmedPlayers:          session.ConfirmedPlayers,
 		PendingPlayers:            session.PendingPlayers,
+		OpenSpots:                 openSpots,
 		Participants:              participants,
+		Rules:                     rules,
+		Courts:                    courts,
 		CreatedAt:                 session.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:                 session.UpdatedAt.Format(time.RFC3339),
 	}
@@ -659,14 +1927,8 @@ func (uc *useCase) validateSessionTime(sessionDate time.Time, startTime, endTime
 		return fmt.Errorf("session date must be in the future")
 	}
 
-	// Session must be at least 30 minutes long
-	sessionStartTime := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
-		startTime.Hour(), startTime.Minute(), 0, 0, time.Local)
-	sessionEndTime := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
-		endTime.Hour(), endTime.Minute(), 0, 0, time.Local)
-
-	if sessionEndTime.Sub(sessionStartTime) < 30*time.Minute {
-		return fmt.Errorf("session must be at least 30 minutes long")
+	if err := uc.validateSessionDuration(startTime, endTime); err != nil {
+		return err
 	}
 
 	// Can't create sessions more than 3 months in advance
@@ -702,35 +1964,65 @@ func (uc *useCase) validateSessionTime(sessionDate time.Time, startTime, endTime
 	return nil
 }
 
-// checkSessionConflict checks if there's any conflict with existing sessions
-func (uc *useCase) checkSessionConflict(ctx context.Context, sessionDate time.Time, startTime, endTime time.Time, courtID uuid.UUID) error {
-	filters := map[string]interface{}{
-		"date": sessionDate.Format("2006-01-02"),
+// validateSessionDuration checks the session length against the configured limits
+func (uc *useCase) validateSessionDuration(startTime, endTime time.Time) error {
+	duration := endTime.Sub(startTime)
+
+	if duration < uc.minDuration || duration > uc.maxDuration {
+		return fmt.Errorf("%w: session must be between %s and %s long",
+			ErrValidation, formatDuration(uc.minDuration), formatDuration(uc.maxDuration))
+	}
+
+	return nil
+}
+
+// formatDuration renders a duration as e.g. "30m" or "6h" instead of "6h0m0s"
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
 	}
+	return s
+}
 
-	existingSessions, err := uc.sessionRepo.List(ctx, filters, 100, 0)
+// checkSessionConflict rejects the time slot if another active session holds
+// one of the courts during it. excludeSessionID is skipped, pass uuid.Nil for none.
+func (uc *useCase) checkSessionConflict(ctx context.Context, courtIDs []uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) error {
+	conflicts, err := uc.sessionRepo.GetConflictingSessionCourts(ctx, courtIDs, sessionDate, startTime, endTime, excludeSessionID)
 	if err != nil {
 		return fmt.Errorf("failed to check session conflicts: %w", err)
 	}
 
-	proposedStart := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
-		startTime.Hour(), startTime.Minute(), 0, 0, time.Local)
-	proposedEnd := time.Date(sessionDate.Year(), sessionDate.Month(), sessionDate.Day(),
-		endTime.Hour(), endTime.Minute(), 0, 0, time.Local)
+	if len(conflicts) > 0 {
+		conflict := conflicts[0]
+		return fmt.Errorf("%w: a court is already reserved by %s (%s - %s)",
+			ErrScheduleConflict,
+			conflict.Title,
+			conflict.StartTime.Format("15:04"),
+			conflict.EndTime.Format("15:04"))
+	}
 
-	for _, session := range existingSessions {
-		if session.Status != models.SessionStatusCancelled {
-			existingStart := time.Date(session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
-				session.StartTime.Hour(), session.StartTime.Minute(), 0, 0, time.Local)
-			existingEnd := time.Date(session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
-				session.EndTime.Hour(), session.EndTime.Minute(), 0, 0, time.Local)
+	return nil
+}
 
-			if proposedStart.Before(existingEnd) && existingStart.Before(proposedEnd) {
-				return fmt.Errorf("court is already booked from %s to %s",
-					existingStart.Format("15:04"),
-					existingEnd.Format("15:04"))
-			}
-		}
+// checkUserScheduleConflict rejects the time slot if the user already hosts or takes part
+// in another active session overlapping it. excludeSessionID is skipped, pass uuid.Nil for none.
+func (uc *useCase) checkUserScheduleConflict(ctx context.Context, userID uuid.UUID, sessionDate, startTime, endTime time.Time, excludeSessionID uuid.UUID) error {
+	overlapping, err := uc.sessionRepo.GetUserOverlappingSessions(ctx, userID, sessionDate, startTime, endTime, excludeSessionID)
+	if err != nil {
+		return fmt.Errorf("failed to check schedule conflicts: %w", err)
+	}
+
+	if len(overlapping) > 0 {
+		conflict := overlapping[0]
+		return fmt.Errorf("%w: you already have a session at this time (%s, %s - %s)",
+			ErrScheduleConflict,
+			conflict.Title,
+			conflict.StartTime.Format("15:04"),
+			conflict.EndTime.Format("15:04"))
 	}
 
 	return nil
@@ -773,6 +2065,26 @@ func (uc *useCase) validatePlayerLevel(level string) error {
 	return nil
 }
 
+// generateCheckInCode returns a random 6-digit numeric code
+func generateCheckInCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// sessionStartTime combines the session date and start time into a single timestamp
+func sessionStartTime(session *models.SessionDetail) time.Time {
+	return time.Date(
+		session.SessionDate.Year(),
+		session.SessionDate.Month(),
+		session.SessionDate.Day(),
+		session.StartTime.Hour(),
+		session.StartTime.Minute(),
+		0, 0, models.LoadTimezone(session.VenueTimezone))
+}
+
 // canUpdateSession checks if a session can be updated
 func (uc *useCase) canUpdateSession(session *models.SessionDetail) error {
 	if session.Status == models.SessionStatusCancelled {
@@ -782,15 +2094,7 @@ func (uc *useCase) canUpdateSession(session *models.SessionDetail) error {
 		return fmt.Errorf("cannot update completed session")
 	}
 
-	sessionDateTime := time.Date(
-		session.SessionDate.Year(),
-		session.SessionDate.Month(),
-		session.SessionDate.Day(),
-		session.StartTime.Hour(),
-		session.StartTime.Minute(),
-		0, 0, time.Local)
-
-	if time.Now().After(sessionDateTime) {
+	if time.Now().After(sessionStartTime(session)) {
 		return fmt.Errorf("cannot update session that has already started")
 	}
 
@@ -803,15 +2107,7 @@ func (uc *useCase) canJoinSession(session *models.SessionDetail, userID uuid.UUI
 		return fmt.Errorf("session is not open for joining")
 	}
 
-	sessionDateTime := time.Date(
-		session.SessionDate.Year(),
-		session.SessionDate.Month(),
-		session.SessionDate.Day(),
-		session.StartTime.Hour(),
-		session.StartTime.Minute(),
-		0, 0, time.Local)
-
-	if time.Now().After(sessionDateTime) {
+	if time.Now().After(sessionStartTime(session)) {
 		return fmt.Errorf("cannot join session that has already started")
 	}
 
@@ -0,0 +1,557 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/repositories/memory"
+	"badbuddy/internal/usecase/notification"
+
+	"github.com/google/uuid"
+)
+
+// stubNotifications records Notify calls and leaves the rest of the interface unimplemented
+type stubNotifications struct {
+	notification.UseCase
+
+	mu   sync.Mutex
+	sent []uuid.UUID // Recipients, in the order they were notified
+}
+
+func (n *stubNotifications) Notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title, body string, data interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, userID)
+	return nil
+}
+
+type fixture struct {
+	store         *memory.Store
+	sessionRepo   interfaces.SessionRepository
+	chatRepo      interfaces.ChatRepository
+	notifications *stubNotifications
+	uc            UseCase
+	venueID       uuid.UUID
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	store := memory.NewStore()
+	f := &fixture{
+		store:         store,
+		sessionRepo:   memory.NewSessionRepository(store),
+		chatRepo:      memory.NewChatRepository(store),
+		notifications: &stubNotifications{},
+		venueID:       uuid.New(),
+	}
+	store.PutVenue(models.Venue{ID: f.venueID, Name: "Test Hall", Status: models.VenueStatusActive, Timezone: "Asia/Bangkok"})
+
+	f.uc = NewSessionUseCase(f.sessionRepo, memory.NewVenueRepository(store), f.chatRepo, memory.NewUserRepository(store),
+		f.notifications, 30*time.Minute, 6*time.Hour, 0, DefaultCourtsPolicy())
+	return f
+}
+
+func (f *fixture) user(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	f.store.PutUser(models.User{ID: id, FirstName: "Player", LastName: id.String()[:8], Status: models.UserStatusActive})
+	return id
+}
+
+// session creates an open session hosted by a new user, who takes one of the
+// places, starting at start in the venue's zone
+func (f *fixture) session(t *testing.T, maxParticipants int, start time.Time, configure func(*models.Session)) *models.Session {
+	t.Helper()
+
+	start = start.In(models.LoadTimezone("Asia/Bangkok"))
+	now := time.Now()
+	s := &models.Session{
+		ID:                uuid.New(),
+		HostID:            f.user(t),
+		VenueID:           f.venueID,
+		Title:             "Evening doubles",
+		SessionDate:       time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC),
+		StartTime:         time.Date(0, 1, 1, start.Hour(), start.Minute(), 0, 0, time.UTC),
+		EndTime:           time.Date(0, 1, 1, start.Hour(), start.Minute(), 0, 0, time.UTC).Add(2 * time.Hour),
+		PlayerLevel:       models.PlayerLevelIntermediate,
+		MaxParticipants:   maxParticipants,
+		AllowCancellation: true,
+		IsPublic:          true,
+		Status:            models.SessionStatusOpen,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if configure != nil {
+		configure(s)
+	}
+
+	host := &models.SessionParticipant{
+		ID:        uuid.New(),
+		SessionID: s.ID,
+		UserID:    s.HostID,
+		Status:    models.ParticipantStatusConfirmed,
+		JoinedAt:  now,
+	}
+	if err := f.sessionRepo.CreateWithHost(context.Background(), s, host, nil, nil); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	if err := f.chatRepo.CreateChat(context.Background(), &models.Chat{ID: uuid.New(), Type: models.ChatTypeGroup, SessionID: &s.ID}); err != nil {
+		t.Fatalf("create session chat: %v", err)
+	}
+	return s
+}
+
+func (f *fixture) statuses(t *testing.T, sessionID uuid.UUID) map[models.ParticipantStatus]int {
+	t.Helper()
+
+	participants, err := f.sessionRepo.GetParticipants(context.Background(), sessionID)
+	if err != nil {
+		t.Fatalf("get participants: %v", err)
+	}
+	counts := make(map[models.ParticipantStatus]int)
+	for _, p := range participants {
+		counts[p.Status]++
+	}
+	return counts
+}
+
+func TestJoinSessionConcurrentJoinsDoNotOverfill(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		waitlistOK  bool
+		wantPending int
+	}{
+		{name: "without waitlist", waitlistOK: false, wantPending: 0},
+		{name: "with waitlist", waitlistOK: true, wantPending: 17},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			s := f.session(t, 4, time.Now().Add(48*time.Hour), nil)
+
+			const players = 20
+			userIDs := make([]uuid.UUID, players)
+			for i := range userIDs {
+				userIDs[i] = f.user(t)
+			}
+
+			var (
+				wg       sync.WaitGroup
+				mu       sync.Mutex
+				joined   int
+				rejected int
+			)
+			start := make(chan struct{})
+			for _, userID := range userIDs {
+				wg.Add(1)
+				go func(userID uuid.UUID) {
+					defer wg.Done()
+					<-start
+					resp, err := f.uc.JoinSession(context.Background(), s.ID, userID, requests.JoinSessionRequest{WaitlistOK: tc.waitlistOK})
+
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case errors.Is(err, ErrSessionFull):
+						rejected++
+					case err != nil:
+						t.Errorf("join: %v", err)
+					case resp.Status == string(models.ParticipantStatusConfirmed):
+						joined++
+					}
+				}(userID)
+			}
+			close(start)
+			wg.Wait()
+
+			if joined != 3 {
+				t.Errorf("confirmed joins = %d, want 3", joined)
+			}
+			if want := players - 3 - tc.wantPending; rejected != want {
+				t.Errorf("rejected joins = %d, want %d", rejected, want)
+			}
+
+			counts := f.statuses(t, s.ID)
+			if counts[models.ParticipantStatusConfirmed] != 4 {
+				t.Errorf("confirmed participants = %d, want 4", counts[models.ParticipantStatusConfirmed])
+			}
+			if counts[models.ParticipantStatusPending] != tc.wantPending {
+				t.Errorf("pending participants = %d, want %d", counts[models.ParticipantStatusPending], tc.wantPending)
+			}
+
+			stored, err := f.sessionRepo.GetByID(context.Background(), s.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if stored.Status != models.SessionStatusFull {
+				t.Errorf("session status = %s, want %s", stored.Status, models.SessionStatusFull)
+			}
+		})
+	}
+}
+
+func TestJoinAndLeaveSession(t *testing.T) {
+	deadline := 24
+
+	type step struct {
+		player     int // Index into the case's players
+		leave      bool
+		waitlistOK bool
+		wantStatus models.ParticipantStatus // Status returned by a join
+		wantErr    string
+	}
+	for _, tc := range []struct {
+		name          string
+		max           int
+		start         time.Duration // From now
+		players       int
+		steps         []step
+		want          []models.ParticipantStatus // Final status of each player
+		wantSession   models.SessionStatus
+		wantNotified  int // Index of the player told they were promoted, -1 for nobody
+		deadlineHours *int
+	}{
+		{
+			name:    "join when full goes to the waitlist",
+			max:     2,
+			start:   48 * time.Hour,
+			players: 3,
+			steps: []step{
+				{player: 0, wantStatus: models.ParticipantStatusConfirmed},
+				{player: 1, wantErr: ErrSessionFull.Error()},
+				{player: 2, waitlistOK: true, wantStatus: models.ParticipantStatusPending},
+			},
+			want:         []models.ParticipantStatus{models.ParticipantStatusConfirmed, "", models.ParticipantStatusPending},
+			wantSession:  models.SessionStatusFull,
+			wantNotified: -1,
+		},
+		{
+			name:    "leave promotes the waitlist",
+			max:     2,
+			start:   48 * time.Hour,
+			players: 3,
+			steps: []step{
+				{player: 0, wantStatus: models.ParticipantStatusConfirmed},
+				{player: 1, waitlistOK: true, wantStatus: models.ParticipantStatusPending},
+				{player: 2, waitlistOK: true, wantStatus: models.ParticipantStatusPending},
+				{player: 0, leave: true},
+			},
+			want:         []models.ParticipantStatus{models.ParticipantStatusCancelled, models.ParticipantStatusConfirmed, models.ParticipantStatusPending},
+			wantSession:  models.SessionStatusFull,
+			wantNotified: 1,
+		},
+		{
+			name:    "leave without a waitlist reopens the session",
+			max:     2,
+			start:   48 * time.Hour,
+			players: 1,
+			steps: []step{
+				{player: 0, wantStatus: models.ParticipantStatusConfirmed},
+				{player: 0, leave: true},
+			},
+			want:         []models.ParticipantStatus{models.ParticipantStatusCancelled},
+			wantSession:  models.SessionStatusOpen,
+			wantNotified: -1,
+		},
+		{
+			name:    "duplicate join",
+			max:     4,
+			start:   48 * time.Hour,
+			players: 1,
+			steps: []step{
+				{player: 0, wantStatus: models.ParticipantStatusConfirmed},
+				{player: 0, wantStatus: models.ParticipantStatusConfirmed},
+			},
+			want:         []models.ParticipantStatus{models.ParticipantStatusConfirmed},
+			wantSession:  models.SessionStatusOpen,
+			wantNotified: -1,
+		},
+		{
+			name:          "leave after the deadline",
+			max:           4,
+			start:         2 * time.Hour,
+			players:       1,
+			deadlineHours: &deadline,
+			steps: []step{
+				{player: 0, wantStatus: models.ParticipantStatusConfirmed},
+				{player: 0, leave: true, wantErr: "cancellation deadline has passed"},
+			},
+			want:         []models.ParticipantStatus{models.ParticipantStatusConfirmed},
+			wantSession:  models.SessionStatusOpen,
+			wantNotified: -1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			s := f.session(t, tc.max, time.Now().Add(tc.start), func(s *models.Session) {
+				s.CancellationDeadlineHours = tc.deadlineHours
+			})
+			players := make([]uuid.UUID, tc.players)
+			for i := range players {
+				players[i] = f.user(t)
+			}
+
+			ctx := context.Background()
+			for i, st := range tc.steps {
+				var (
+					resp *responses.JoinSessionResponse
+					err  error
+				)
+				if st.leave {
+					err = f.uc.LeaveSession(ctx, s.ID, players[st.player])
+				} else {
+					resp, err = f.uc.JoinSession(ctx, s.ID, players[st.player], requests.JoinSessionRequest{WaitlistOK: st.waitlistOK})
+				}
+
+				if st.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), st.wantErr) {
+						t.Fatalf("step %d: error = %v, want %q", i, err, st.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("step %d: %v", i, err)
+				}
+				if !st.leave && resp.Status != string(st.wantStatus) {
+					t.Fatalf("step %d: join status = %q, want %q", i, resp.Status, st.wantStatus)
+				}
+			}
+
+			participants, err := f.sessionRepo.GetParticipants(ctx, s.ID)
+			if err != nil {
+				t.Fatalf("get participants: %v", err)
+			}
+			rows := make(map[uuid.UUID]int)
+			got := make(map[uuid.UUID]models.ParticipantStatus)
+			for _, p := range participants {
+				rows[p.UserID]++
+				got[p.UserID] = p.Status
+			}
+			for i, want := range tc.want {
+				if got[players[i]] != want {
+					t.Errorf("player %d status = %q, want %q", i, got[players[i]], want)
+				}
+				if rows[players[i]] > 1 {
+					t.Errorf("player %d has %d participant rows", i, rows[players[i]])
+				}
+			}
+
+			stored, err := f.sessionRepo.GetByID(ctx, s.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if stored.Status != tc.wantSession {
+				t.Errorf("session status = %s, want %s", stored.Status, tc.wantSession)
+			}
+
+			var notified []uuid.UUID
+			for _, id := range f.notifications.sent {
+				if id != s.HostID {
+					notified = append(notified, id)
+				}
+			}
+			switch {
+			case tc.wantNotified < 0 && len(notified) > 0:
+				t.Errorf("notified %v, want nobody", notified)
+			case tc.wantNotified >= 0 && (len(notified) != 1 || notified[0] != players[tc.wantNotified]):
+				t.Errorf("notified %v, want player %d", notified, tc.wantNotified)
+			}
+		})
+	}
+}
+
+// The deadline counts back from the start time in the venue's zone. A late
+// evening start puts midnight 22 hours earlier, so a deadline anchored there
+// would already have passed in the allowed case.
+func TestLeaveSessionDeadlineCountsBackFromStart(t *testing.T) {
+	zone := models.LoadTimezone("Asia/Bangkok")
+	now := time.Now().In(zone)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 22, 0, 0, 0, zone)
+	for start.Sub(now) < 48*time.Hour {
+		start = start.AddDate(0, 0, 1)
+	}
+	hoursToStart := int(start.Sub(now).Hours())
+
+	for _, tc := range []struct {
+		name          string
+		deadlineHours int
+		wantErr       bool
+	}{
+		{name: "before the deadline", deadlineHours: hoursToStart - 2, wantErr: false},
+		{name: "after the deadline", deadlineHours: hoursToStart + 2, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			s := f.session(t, 4, start, func(s *models.Session) {
+				s.CancellationDeadlineHours = &tc.deadlineHours
+			})
+			player := f.user(t)
+			if _, err := f.uc.JoinSession(context.Background(), s.ID, player, requests.JoinSessionRequest{}); err != nil {
+				t.Fatalf("join: %v", err)
+			}
+
+			err := f.uc.LeaveSession(context.Background(), s.ID, player)
+			if tc.wantErr && (err == nil || !strings.Contains(err.Error(), "cancellation deadline has passed")) {
+				t.Fatalf("leave error = %v, want the deadline to have passed", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("leave: %v", err)
+			}
+		})
+	}
+}
+
+// racingJoinRepo lets another request for the same user insert their row just
+// before each AddParticipant
+type racingJoinRepo struct {
+	interfaces.SessionRepository
+}
+
+func (r racingJoinRepo) AddParticipant(ctx context.Context, participant *models.SessionParticipant) (bool, error) {
+	winner := *participant
+	winner.ID = uuid.New()
+	if _, err := r.SessionRepository.AddParticipant(ctx, &winner); err != nil {
+		return false, err
+	}
+	return r.SessionRepository.AddParticipant(ctx, participant)
+}
+
+func TestJoinSessionLosingDuplicateJoinReportsStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		isPublic bool
+		want     models.ParticipantStatus
+	}{
+		{name: "public session", isPublic: true, want: models.ParticipantStatusConfirmed},
+		{name: "private session", isPublic: false, want: models.ParticipantStatusPending},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			s := f.session(t, 4, time.Now().Add(48*time.Hour), func(s *models.Session) {
+				s.IsPublic = tc.isPublic
+			})
+			uc := NewSessionUseCase(racingJoinRepo{f.sessionRepo}, memory.NewVenueRepository(f.store), f.chatRepo, memory.NewUserRepository(f.store),
+				f.notifications, 30*time.Minute, 6*time.Hour, 0, DefaultCourtsPolicy())
+
+			resp, err := uc.JoinSession(context.Background(), s.ID, f.user(t), requests.JoinSessionRequest{})
+			if err != nil {
+				t.Fatalf("join: %v", err)
+			}
+			if resp.Status != string(tc.want) {
+				t.Errorf("status = %q, want %q", resp.Status, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckInLimitsWrongCodes(t *testing.T) {
+	f := newFixture(t)
+	code := "123456"
+	s := f.session(t, 4, time.Now().Add(30*time.Minute), func(s *models.Session) {
+		s.CheckInCode = &code
+	})
+	player := f.user(t)
+	ctx := context.Background()
+	if _, err := f.uc.JoinSession(ctx, s.ID, player, requests.JoinSessionRequest{}); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+
+	for i := 0; i < maxCheckInAttempts; i++ {
+		err := f.uc.CheckIn(ctx, s.ID, player, requests.CheckInRequest{Code: "000000"})
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("attempt %d: error = %v, want %v", i+1, err, ErrValidation)
+		}
+	}
+
+	// The right code is refused too once the attempts are used up
+	if err := f.uc.CheckIn(ctx, s.ID, player, requests.CheckInRequest{Code: code}); !errors.Is(err, ErrTooManyCheckInAttempts) {
+		t.Fatalf("error = %v, want %v", err, ErrTooManyCheckInAttempts)
+	}
+
+	// Other callers keep their own attempts
+	other := f.user(t)
+	if _, err := f.uc.JoinSession(ctx, s.ID, other, requests.JoinSessionRequest{}); err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	if err := f.uc.CheckIn(ctx, s.ID, other, requests.CheckInRequest{Code: code}); err != nil {
+		t.Fatalf("check in: %v", err)
+	}
+}
+
+func TestCompleteSessionMarksNoShows(t *testing.T) {
+	f := newFixture(t)
+	s := f.session(t, 6, time.Now().Add(-time.Hour), nil)
+	ctx := context.Background()
+
+	// Player 0 checks in, 1 is marked attended, 2 does neither and 3 is waitlisted
+	players := make([]uuid.UUID, 4)
+	for i := range players {
+		players[i] = f.user(t)
+		status := models.ParticipantStatusConfirmed
+		if i == 3 {
+			status = models.ParticipantStatusPending
+		}
+		if _, err := f.sessionRepo.AddParticipant(ctx, &models.SessionParticipant{
+			ID:        uuid.New(),
+			SessionID: s.ID,
+			UserID:    players[i],
+			Status:    status,
+			JoinedAt:  time.Now(),
+		}); err != nil {
+			t.Fatalf("add participant: %v", err)
+		}
+	}
+	if err := f.sessionRepo.CheckInParticipant(ctx, s.ID, players[0]); err != nil {
+		t.Fatalf("check in: %v", err)
+	}
+
+	err := f.uc.CompleteSession(ctx, s.ID, s.HostID, requests.CompleteSessionRequest{Attended: []string{players[3].String()}})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("completing with a waitlisted player attended: error = %v, want %v", err, ErrValidation)
+	}
+	if counts := f.statuses(t, s.ID); counts[models.ParticipantStatusNoShow] != 0 {
+		t.Fatalf("rejected completion marked %d no-shows", counts[models.ParticipantStatusNoShow])
+	}
+
+	if err := f.uc.CompleteSession(ctx, s.ID, s.HostID, requests.CompleteSessionRequest{Attended: []string{players[1].String()}}); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	participants, err := f.sessionRepo.GetParticipants(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("get participants: %v", err)
+	}
+	got := make(map[uuid.UUID]models.ParticipantStatus)
+	for _, p := range participants {
+		got[p.UserID] = p.Status
+	}
+	want := map[uuid.UUID]models.ParticipantStatus{
+		s.HostID:   models.ParticipantStatusConfirmed,
+		players[0]: models.ParticipantStatusConfirmed,
+		players[1]: models.ParticipantStatusConfirmed,
+		players[2]: models.ParticipantStatusNoShow,
+		players[3]: models.ParticipantStatusPending,
+	}
+	for id, status := range want {
+		if got[id] != status {
+			t.Errorf("participant %s status = %q, want %q", id, got[id], status)
+		}
+	}
+
+	stored, err := f.sessionRepo.GetByID(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if stored.Status != models.SessionStatusCompleted {
+		t.Errorf("session status = %s, want %s", stored.Status, models.SessionStatusCompleted)
+	}
+}
@@ -17,6 +17,11 @@ var (
 	ErrDuplicateUsername  = errors.New("username already exists")
 	ErrInvalidPlayLevel   = errors.New("invalid play level")
 	ErrInvalidPassword    = errors.New("password does not meet requirements")
+	ErrInvalidToken       = errors.New("invalid or expired verification token")
+	ErrInvalidAvatar      = errors.New("invalid avatar")
+	ErrInvalidMetric      = errors.New("invalid leaderboard metric")
+	ErrInvalidPhone       = errors.New("invalid phone number")
+	ErrAlreadyVerified    = errors.New("email is already verified")
 )
 
 type UseCase interface {
@@ -25,8 +30,16 @@ type UseCase interface {
 	GetProfile(ctx context.Context, userID uuid.UUID) (*responses.UserProfileResponse, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req requests.UpdateProfileRequest) error
 	SearchUsers(ctx context.Context, query string, filters requests.SearchFilters) ([]responses.UserResponse, error)
+	GetLeaderboard(ctx context.Context, req requests.LeaderboardRequest) (*responses.LeaderboardResponse, error)
 	RefreshToken(ctx context.Context, userID uuid.UUID) (string, error)
 	IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error)
 	GetVenueUserOwn(ctx context.Context, userID uuid.UUID) ([]responses.Venue, error)
 	UpdateRoles(ctx context.Context, adminID uuid.UUID, req requests.UpdateRolesRequest) error
+	VerifyEmail(ctx context.Context, req requests.VerifyEmailRequest) error
+	ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error
+}
+
+// Mailer sends a plain text email
+type Mailer interface {
+	Send(to, subject, body string) error
 }
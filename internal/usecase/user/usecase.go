@@ -5,9 +5,15 @@ import (
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
+	"badbuddy/internal/infrastructure/media"
+	"badbuddy/internal/infrastructure/phone"
 	"badbuddy/internal/repositories/interfaces"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"time"
 	"unicode"
 
@@ -16,17 +22,48 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// emailVerificationTTL is how long the link in a verification email works
+const emailVerificationTTL = 48 * time.Hour
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+
+	// minLeaderboardReviews keeps a single five star review from topping the
+	// rating leaderboard
+	minLeaderboardReviews = 3
+)
+
 type useCase struct {
 	userRepo    interfaces.UserRepository
 	jwtSecret   []byte
 	jwtDuration time.Duration
+	bcryptCost  int
+	mailer      Mailer
+	verifyURL   string
+	imagePolicy media.Policy
+	phoneRegion string
 }
 
-func NewUserUseCase(userRepo interfaces.UserRepository, jwtSecret string, jwtDuration time.Duration) UseCase {
+// NewUserUseCase hashes passwords with bcryptCost, falling back to
+// bcrypt.DefaultCost when it is outside the range bcrypt accepts.
+// Verification emails link to verifyURL with the token appended as ?token=.
+// Avatar URLs must pass imagePolicy. Phone numbers without a country code are
+// read as numbers of phoneRegion.
+func NewUserUseCase(userRepo interfaces.UserRepository, jwtSecret string, jwtDuration time.Duration, bcryptCost int, mailer Mailer, verifyURL string, imagePolicy media.Policy, phoneRegion string) UseCase {
+	if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+		bcryptCost = bcrypt.DefaultCost
+	}
+
 	return &useCase{
 		userRepo:    userRepo,
 		jwtSecret:   []byte(jwtSecret),
 		jwtDuration: jwtDuration,
+		bcryptCost:  bcryptCost,
+		mailer:      mailer,
+		verifyURL:   verifyURL,
+		imagePolicy: imagePolicy,
+		phoneRegion: phoneRegion,
 	}
 }
 
@@ -75,13 +112,24 @@ func (uc *useCase) Register(ctx context.Context, req requests.RegisterRequest) e
 		return err
 	}
 
+	if req.AvatarURL != "" {
+		if err := uc.imagePolicy.CheckURL(req.AvatarURL); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAvatar, err)
+		}
+	}
+
+	normalizedPhone, err := phone.Normalize(req.Phone, uc.phoneRegion)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPhone, err)
+	}
+
 	// Check if email exists
 	if _, err := uc.userRepo.GetByEmail(ctx, req.Email); err == nil {
 		return ErrDuplicateEmail
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), uc.bcryptCost)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -93,7 +141,7 @@ func (uc *useCase) Register(ctx context.Context, req requests.RegisterRequest) e
 		Password:  string(hashedPassword),
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
-		Phone:     req.Phone,
+		Phone:     normalizedPhone,
 		PlayLevel: models.PlayerLevel(req.PlayLevel),
 		Gender:    req.Gender,
 		PlayHand:  req.PlayHand,
@@ -107,9 +155,92 @@ func (uc *useCase) Register(ctx context.Context, req requests.RegisterRequest) e
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
+	// The account works without verification, it just can't host yet
+	if err := uc.sendVerificationEmail(ctx, user); err != nil {
+		log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	// Only the hash is stored, so a leaked table can't verify anyone
+	if err := uc.userRepo.CreateEmailVerification(ctx, user.ID, hashToken(token), time.Now().Add(emailVerificationTTL)); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nConfirm your email address to start hosting sessions and venues on BadBuddy:\n\n%s?token=%s\n\nThe link expires in %d hours.",
+		user.FirstName, uc.verifyURL, token, int(emailVerificationTTL.Hours()))
+	return uc.mailer.Send(user.Email, "Verify your BadBuddy email", body)
+}
+
+// VerifyEmail marks the account behind a token from a verification email as verified
+func (uc *useCase) VerifyEmail(ctx context.Context, req requests.VerifyEmailRequest) error {
+	if req.Token == "" {
+		return ErrInvalidToken
+	}
+
+	verified, err := uc.userRepo.VerifyEmail(ctx, hashToken(req.Token))
+	if err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	if !verified {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail sends a fresh verification link to an unverified user.
+// Links from earlier emails stop working.
+func (uc *useCase) ResendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrAlreadyVerified
+	}
+
+	if err := uc.sendVerificationEmail(ctx, user); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
 	return nil
 }
 
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// upgradePasswordHash rehashes the password with the configured cost when the
+// stored hash is weaker. The login still succeeds if the upgrade fails.
+func (uc *useCase) upgradePasswordHash(ctx context.Context, user *models.User, password string) {
+	cost, err := bcrypt.Cost([]byte(user.Password))
+	if err != nil || cost >= uc.bcryptCost {
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), uc.bcryptCost)
+	if err != nil {
+		log.Printf("failed to rehash password for user %s: %v", user.ID, err)
+		return
+	}
+
+	if err := uc.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		log.Printf("failed to store rehashed password for user %s: %v", user.ID, err)
+		return
+	}
+	user.Password = string(hashedPassword)
+}
+
 func (uc *useCase) generateToken(userID uuid.UUID) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": userID.String(),
@@ -135,6 +266,8 @@ func (uc *useCase) Login(ctx context.Context, req requests.LoginRequest) (*respo
 		return nil, fmt.Errorf("account is not active")
 	}
 
+	uc.upgradePasswordHash(ctx, user, req.Password)
+
 	// Update last active
 	if err := uc.userRepo.UpdateLastActive(ctx, user.ID); err != nil {
 		return nil, fmt.Errorf("failed to update last active: %w", err)
@@ -178,6 +311,7 @@ func (uc *useCase) GetProfile(ctx context.Context, userID uuid.UUID) (*responses
 		AverageRating:   profile.AverageRating,
 		TotalReviews:    profile.TotalReviews,
 		RegularPartners: profile.RegularPartners,
+		NoShowSessions:  profile.NoShowSessions,
 	}, nil
 }
 
@@ -202,7 +336,11 @@ func (uc *useCase) UpdateProfile(ctx context.Context, userID uuid.UUID, req requ
 		user.LastName = req.LastName
 	}
 	if req.Phone != "" {
-		user.Phone = req.Phone
+		normalizedPhone, err := phone.Normalize(req.Phone, uc.phoneRegion)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPhone, err)
+		}
+		user.Phone = normalizedPhone
 	}
 	if req.Location != "" {
 		user.Location = req.Location
@@ -212,6 +350,9 @@ func (uc *useCase) UpdateProfile(ctx context.Context, userID uuid.UUID, req requ
 	}
 
 	if req.AvatarURL != "" {
+		if err := uc.imagePolicy.CheckURL(req.AvatarURL); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAvatar, err)
+		}
 		user.AvatarURL = req.AvatarURL
 	}
 
@@ -243,6 +384,64 @@ func (uc *useCase) SearchUsers(ctx context.Context, query string, filters reques
 	return userResponses, nil
 }
 
+// GetLeaderboard ranks users that aren't inactive by sessions played, sessions
+// hosted or average rating, optionally within one location
+func (uc *useCase) GetLeaderboard(ctx context.Context, req requests.LeaderboardRequest) (*responses.LeaderboardResponse, error) {
+	metric := models.LeaderboardMetric(req.Metric)
+	switch metric {
+	case "":
+		metric = models.LeaderboardMetricSessionsPlayed
+	case models.LeaderboardMetricSessionsPlayed, models.LeaderboardMetricHosted, models.LeaderboardMetricRating:
+	default:
+		return nil, fmt.Errorf("%w: metric must be sessions_played, hosted or rating", ErrInvalidMetric)
+	}
+
+	filters := interfaces.LeaderboardFilters{
+		Metric:     metric,
+		Location:   req.Location,
+		MinReviews: minLeaderboardReviews,
+		Limit:      req.Limit,
+		Offset:     max(req.Offset, 0),
+	}
+	if filters.Limit <= 0 || filters.Limit > maxLeaderboardLimit {
+		filters.Limit = defaultLeaderboardLimit
+	}
+
+	entries, err := uc.userRepo.GetLeaderboard(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	total, err := uc.userRepo.CountLeaderboard(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count leaderboard: %w", err)
+	}
+
+	result := &responses.LeaderboardResponse{
+		Entries: make([]responses.LeaderboardEntryResponse, len(entries)),
+		Total:   total,
+		Limit:   filters.Limit,
+		Offset:  filters.Offset,
+	}
+	for i, entry := range entries {
+		result.Entries[i] = responses.LeaderboardEntryResponse{
+			Rank:           filters.Offset + i + 1,
+			UserID:         entry.ID.String(),
+			FirstName:      entry.FirstName,
+			LastName:       entry.LastName,
+			AvatarURL:      entry.AvatarURL,
+			Location:       entry.Location,
+			PlayLevel:      string(entry.PlayLevel),
+			SessionsPlayed: entry.HostedSessions + entry.JoinedSessions,
+			HostedSessions: entry.HostedSessions,
+			AverageRating:  entry.AverageRating,
+			TotalReviews:   entry.TotalReviews,
+		}
+	}
+
+	return result, nil
+}
+
 func (uc *useCase) GetVenueUserOwn(ctx context.Context, userID uuid.UUID) ([]responses.Venue, error) {
 	venues, err := uc.userRepo.GetVenueUserOwn(ctx, userID)
 	if err != nil {
@@ -290,19 +489,20 @@ func (uc *useCase) mapUserToResponse(user *models.User) responses.UserResponse {
 	}
 
 	return responses.UserResponse{
-		ID:           userID,
-		Email:        user.Email,
-		FirstName:    user.FirstName,
-		LastName:     user.LastName,
-		Phone:        user.Phone,
-		PlayLevel:    string(user.PlayLevel),
-		Gender:       user.Gender,
-		PlayHand:     user.PlayHand,
-		Location:     user.Location,
-		Bio:          user.Bio,
-		AvatarURL:    user.AvatarURL,
-		LastActiveAt: user.LastActiveAt,
-		Role:         user.Role,
+		ID:            userID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Phone:         user.Phone,
+		PlayLevel:     string(user.PlayLevel),
+		Gender:        user.Gender,
+		PlayHand:      user.PlayHand,
+		Location:      user.Location,
+		Bio:           user.Bio,
+		AvatarURL:     user.AvatarURL,
+		LastActiveAt:  user.LastActiveAt,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
 	}
 }
 
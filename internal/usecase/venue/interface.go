@@ -5,21 +5,69 @@ import (
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrValidation = errors.New("validation error")
+
+	ErrVenueNotFound = errors.New("venue not found")
+
+	ErrUnauthorized = errors.New("unauthorized")
+
+	ErrAlreadyReviewed = errors.New("you have already reviewed this venue")
+
+	ErrReviewCooldown = errors.New("please wait before posting another review")
+
+	ErrReviewNotFound = errors.New("review not found")
+
+	ErrVenueAlreadyOwned = errors.New("venue already has an owner")
+
+	ErrAlreadyClaimed = errors.New("you already have a pending claim on this venue")
+
+	ErrClaimNotFound = errors.New("claim not found")
+
+	ErrTagNotFound = errors.New("tag not found")
+
+	ErrEmailNotVerified = errors.New("email not verified")
+)
+
 type UseCase interface {
 	CreateVenue(ctx context.Context, ownerID uuid.UUID, req requests.CreateVenueRequest) (*responses.VenueResponse, error)
 	GetVenue(ctx context.Context, id uuid.UUID) (*responses.VenueResponse, error)
 	UpdateVenue(ctx context.Context, id uuid.UUID, req requests.UpdateVenueRequest) error
-	ListVenues(ctx context.Context, location string, limit, offset int) ([]responses.ListVenueResponse, error)
-	SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string) (responses.VenueResponseDTO, error)
+	ListVenues(ctx context.Context, callerID uuid.UUID, location, status string, tags []string, matchAllTags bool, limit, offset int) ([]responses.ListVenueResponse, error)
+	SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string, tags []string, matchAllTags bool) (responses.VenueResponseDTO, error)
 	AddCourt(ctx context.Context, venueID uuid.UUID, req requests.CreateCourtRequest) (*responses.CourtResponse, error)
-	UpdateCourt(ctx context.Context, venueID uuid.UUID, req requests.UpdateCourtRequest) error
+	UpdateCourt(ctx context.Context, venueID uuid.UUID, req requests.UpdateCourtRequest) (*responses.UpdateCourtResponse, error)
 	DeleteCourt(ctx context.Context, venueID uuid.UUID, courtID uuid.UUID) error
+	ReorderCourts(ctx context.Context, venueID uuid.UUID, req requests.ReorderCourtsRequest) ([]responses.CourtResponse, error)
 	AddReview(ctx context.Context, venueID uuid.UUID, userID uuid.UUID, req requests.AddReviewRequest) error
-	GetReviews(ctx context.Context, venueID uuid.UUID, limit, offset int) ([]responses.ReviewResponse, error)
+	GetReviews(ctx context.Context, venueID uuid.UUID, sort string, minRating, maxRating int, limit, offset int) ([]responses.ReviewResponse, error)
+	GetUserReview(ctx context.Context, venueID, userID uuid.UUID) (*responses.ReviewResponse, error)
+	GetRatingBreakdown(ctx context.Context, venueID uuid.UUID) (*responses.RatingBreakdownResponse, error)
+	ToggleReviewHelpful(ctx context.Context, venueID, reviewID, userID uuid.UUID) (*responses.ReviewVoteResponse, error)
+	RespondToReview(ctx context.Context, venueID, reviewID uuid.UUID, req requests.RespondToReviewRequest) (*responses.OwnerReplyResponse, error)
+	GetSchedule(ctx context.Context, venueID uuid.UUID, date string) (*responses.VenueScheduleResponse, error)
+	GetDashboard(ctx context.Context, venueID uuid.UUID) (*responses.VenueDashboardResponse, error)
 	GetFacilities(ctx context.Context, venueID uuid.UUID) (*responses.FacilityListResponse, error)
+	ClaimVenue(ctx context.Context, venueID, claimantID uuid.UUID, req requests.ClaimVenueRequest) (*responses.VenueClaimResponse, error)
+	// ListClaims and ReviewClaim are for admins checking venue claims
+	ListClaims(ctx context.Context, status string, limit, offset int) (*responses.VenueClaimListResponse, error)
+	ReviewClaim(ctx context.Context, claimID, adminID uuid.UUID, req requests.ReviewVenueClaimRequest) (*responses.VenueClaimResponse, error)
+	FavoriteVenue(ctx context.Context, venueID, userID uuid.UUID) error
+	UnfavoriteVenue(ctx context.Context, venueID, userID uuid.UUID) error
+	ListFavoriteVenues(ctx context.Context, userID uuid.UUID, limit, offset int) (responses.VenueResponseDTO, error)
+	IsFavorited(ctx context.Context, venueID, userID uuid.UUID) (bool, error)
+	// MarkFavorites sets IsFavorited on each of the venues for the signed in user
+	MarkFavorites(ctx context.Context, userID uuid.UUID, venues []responses.VenueResponse) error
 	IsOwner(ctx context.Context, venueID uuid.UUID, ownerID uuid.UUID) (bool, error)
+	RestoreVenue(ctx context.Context, venueID uuid.UUID, userID uuid.UUID) (*responses.VenueResponse, error)
+	ListFeaturedVenues(ctx context.Context, limit, offset int) ([]responses.VenueResponse, error)
+	SetFeatured(ctx context.Context, venueID uuid.UUID, req requests.SetFeaturedVenueRequest) (*responses.VenueResponse, error)
+	GetTags(ctx context.Context, venueID uuid.UUID) ([]string, error)
+	SetTags(ctx context.Context, venueID uuid.UUID, req requests.SetVenueTagsRequest) ([]string, error)
+	RemoveTag(ctx context.Context, venueID uuid.UUID, tag string) error
 }
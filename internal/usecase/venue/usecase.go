@@ -2,38 +2,116 @@ package venue
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"badbuddy/internal/delivery/dto/requests"
 	"badbuddy/internal/delivery/dto/responses"
 	"badbuddy/internal/domain/models"
+	"badbuddy/internal/infrastructure/media"
+	"badbuddy/internal/infrastructure/metrics"
+	"badbuddy/internal/infrastructure/phone"
 	"badbuddy/internal/repositories/interfaces"
+	"badbuddy/internal/usecase/notification"
+	"badbuddy/internal/usecase/webhook"
 
 	"github.com/google/uuid"
 )
 
+const (
+	maxVenueTags      = 20
+	maxVenueTagLength = 50
+
+	scheduleSlotLength = 30 * time.Minute
+
+	// reviewCooldown is the minimum gap between two reviews by the same user
+	reviewCooldown = time.Minute
+
+	// maxReviewResponseLength caps the owner's reply to a review in characters
+	maxReviewResponseLength = 1000
+
+	// maxClaimEvidenceLength caps the evidence sent with a venue claim in characters
+	maxClaimEvidenceLength = 1000
+
+	defaultClaimLimit = 20
+	maxClaimLimit     = 100
+)
+
 type useCase struct {
-	venueRepo interfaces.VenueRepository
-	userRepo  interfaces.UserRepository
+	venueRepo           interfaces.VenueRepository
+	userRepo            interfaces.UserRepository
+	bookingRepo         interfaces.BookingRepository
+	sessionRepo         interfaces.SessionRepository
+	chatRepo            interfaces.ChatRepository
+	notificationUseCase notification.UseCase
+	webhooks            webhook.UseCase
+	imagePolicy         media.Policy
+	phoneRegion         string
 }
 
-func NewVenueUseCase(venueRepo interfaces.VenueRepository, userRepo interfaces.UserRepository) UseCase {
+func NewVenueUseCase(
+	venueRepo interfaces.VenueRepository,
+	userRepo interfaces.UserRepository,
+	bookingRepo interfaces.BookingRepository,
+	sessionRepo interfaces.SessionRepository,
+	chatRepo interfaces.ChatRepository,
+	notificationUseCase notification.UseCase,
+	webhooks webhook.UseCase,
+	imagePolicy media.Policy,
+	phoneRegion string,
+) UseCase {
 	return &useCase{
-		venueRepo: venueRepo,
-		userRepo:  userRepo,
+		venueRepo:           venueRepo,
+		userRepo:            userRepo,
+		bookingRepo:         bookingRepo,
+		sessionRepo:         sessionRepo,
+		chatRepo:            chatRepo,
+		notificationUseCase: notificationUseCase,
+		webhooks:            webhooks,
+		imagePolicy:         imagePolicy,
+		phoneRegion:         phoneRegion,
 	}
 }
 
 func (uc *useCase) CreateVenue(ctx context.Context, ownerID uuid.UUID, req requests.CreateVenueRequest) (*responses.VenueResponse, error) {
+	owner, err := uc.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+	if !owner.EmailVerified {
+		return nil, fmt.Errorf("%w: verify your email address before listing a venue", ErrEmailNotVerified)
+	}
+
+	timezone, err := validateTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateOpenRange(req.OpenRange); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateImageURLs(req.ImageURLs); err != nil {
+		return nil, err
+	}
+
+	normalizedPhone, err := uc.normalizePhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
 
 	venue := &models.Venue{
 		Name:        req.Name,
 		Description: req.Description,
 		Address:     req.Address,
 		Location:    req.Location,
-		Phone:       req.Phone,
+		Phone:       normalizedPhone,
 		Email:       req.Email,
 		OpenRange:   models.NullRawMessage{RawMessage: mustMarshalJSON(req.OpenRange)},
 		Rules:       models.NullRawMessage{RawMessage: mustMarshalJSON(req.Rules)},
@@ -44,6 +122,7 @@ func (uc *useCase) CreateVenue(ctx context.Context, ownerID uuid.UUID, req reque
 		UpdatedAt:   time.Now(),
 		Latitude:    req.Latitude,
 		Longitude:   req.Longitude,
+		Timezone:    timezone,
 	}
 
 	if err := uc.venueRepo.Create(ctx, venue); err != nil {
@@ -63,7 +142,7 @@ func (uc *useCase) CreateVenue(ctx context.Context, ownerID uuid.UUID, req reque
 		return nil, fmt.Errorf("failed to add facilities: %w", err)
 	}
 
-	return &responses.VenueResponse{
+	response := &responses.VenueResponse{
 		ID:           venue.ID.String(),
 		Name:         venue.Name,
 		Description:  venue.Description,
@@ -79,9 +158,14 @@ func (uc *useCase) CreateVenue(ctx context.Context, ownerID uuid.UUID, req reque
 		Facilities:   convertToFacilityResponse(convertToModelFacilities(req.Facilities)),
 		Rules:        convertToRuleResponse(req.Rules),
 		Courts:       []responses.CourtResponse{},
+		Tags:         []string{},
 		Latitude:     venue.Latitude,
 		Longitude:    venue.Longitude,
-	}, nil
+		Timezone:     venue.Timezone,
+	}
+	setOpenStatus(response, time.Now().In(venue.TimeLocation()))
+
+	return response, nil
 }
 
 func (uc *useCase) GetVenue(ctx context.Context, id uuid.UUID) (*responses.VenueResponse, error) {
@@ -97,6 +181,7 @@ func (uc *useCase) GetVenue(ctx context.Context, id uuid.UUID) (*responses.Venue
 			Name:         court.Name,
 			Description:  court.Description,
 			PricePerHour: court.PricePerHour,
+			MaxPlayers:   court.MaxPlayers,
 			Status:       string(court.Status),
 		}
 	}
@@ -110,7 +195,7 @@ func (uc *useCase) GetVenue(ctx context.Context, id uuid.UUID) (*responses.Venue
 		return nil, fmt.Errorf("error decoding enroll response: %v", err)
 	}
 
-	return &responses.VenueResponse{
+	response := &responses.VenueResponse{
 		ID:           venueWithCourts.ID.String(),
 		Name:         venueWithCourts.Name,
 		Description:  venueWithCourts.Description,
@@ -126,9 +211,24 @@ func (uc *useCase) GetVenue(ctx context.Context, id uuid.UUID) (*responses.Venue
 		Courts:       courts,
 		Facilities:   convertToFacilityResponse(venueWithCourts.Facilities),
 		Rules:        rules,
+		Tags:         venueWithCourts.Tags,
 		Latitude:     venueWithCourts.Latitude,
 		Longitude:    venueWithCourts.Longitude,
-	}, nil
+		Timezone:     venueWithCourts.Timezone,
+	}
+	setFeaturedStatus(response, &venueWithCourts.Venue, time.Now())
+	setOpenStatus(response, time.Now().In(venueWithCourts.TimeLocation()))
+
+	// A venue whose owner can't be loaded is still shown, just without the credit
+	if owner, err := uc.userRepo.GetByID(ctx, venueWithCourts.OwnerID); err == nil {
+		response.Owner = &responses.VenueOwnerResponse{
+			ID:        owner.ID.String(),
+			Name:      strings.TrimSpace(owner.FirstName + " " + owner.LastName),
+			AvatarURL: owner.AvatarURL,
+		}
+	}
+
+	return response, nil
 }
 
 func (uc *useCase) UpdateVenue(ctx context.Context, id uuid.UUID, req requests.UpdateVenueRequest) error {
@@ -138,34 +238,59 @@ func (uc *useCase) UpdateVenue(ctx context.Context, id uuid.UUID, req requests.U
 	}
 
 	// Update fields if provided
-	if req.Name != "" {
-		venue.Name = req.Name
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return fmt.Errorf("%w: name cannot be empty", ErrValidation)
+		}
+		venue.Name = *req.Name
 	}
-	if req.Description != "" {
-		venue.Description = req.Description
+	if req.Description != nil {
+		venue.Description = *req.Description
 	}
-	if req.Address != "" {
-		venue.Address = req.Address
+	if req.Address != nil {
+		if strings.TrimSpace(*req.Address) == "" {
+			return fmt.Errorf("%w: address cannot be empty", ErrValidation)
+		}
+		venue.Address = *req.Address
 	}
-
-	if req.Phone != "" {
-		venue.Phone = req.Phone
+	if req.Location != nil {
+		if strings.TrimSpace(*req.Location) == "" {
+			return fmt.Errorf("%w: location cannot be empty", ErrValidation)
+		}
+		venue.Location = *req.Location
+	}
+	if req.Phone != nil {
+		normalizedPhone, err := uc.normalizePhone(*req.Phone)
+		if err != nil {
+			return err
+		}
+		venue.Phone = normalizedPhone
 	}
-	if req.Email != "" {
-		venue.Email = req.Email
+	if req.Email != nil {
+		venue.Email = *req.Email
 	}
 	if req.OpenRange != nil {
+		if err := validateOpenRange(req.OpenRange); err != nil {
+			return err
+		}
 		openRangeJSON, err := json.Marshal(req.OpenRange)
 		if err != nil {
 			return fmt.Errorf("failed to marshal open range: %w", err)
 		}
 		venue.OpenRange.RawMessage = openRangeJSON
 	}
-	if req.ImageURLs != "" {
-		venue.ImageURLs = req.ImageURLs
+	if req.ImageURLs != nil {
+		if err := uc.validateImageURLs(*req.ImageURLs); err != nil {
+			return err
+		}
+		venue.ImageURLs = *req.ImageURLs
 	}
-	if req.Status != "" {
-		venue.Status = models.VenueStatus(req.Status)
+	// Setting maintenance again re-runs the cancellations, so a cascade that
+	// failed part way can be finished by retrying the request
+	maintenance := false
+	if req.Status != nil {
+		venue.Status = models.VenueStatus(*req.Status)
+		maintenance = venue.Status == models.VenueStatusMaintenance
 	}
 	if req.Rules != nil {
 		rulesJSON, err := json.Marshal(req.Rules)
@@ -174,20 +299,34 @@ func (uc *useCase) UpdateVenue(ctx context.Context, id uuid.UUID, req requests.U
 		}
 		venue.Rules.RawMessage = rulesJSON
 	}
-	venue.Latitude = req.Latitude
-	venue.Longitude = req.Longitude
-
-	facilityUUIDs := make([]uuid.UUID, len(req.Facilities))
-	for i, facility := range req.Facilities {
-		facilityUUID, err := uuid.Parse(facility.ID)
+	if req.Latitude != nil {
+		venue.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		venue.Longitude = *req.Longitude
+	}
+	if req.Timezone != nil {
+		timezone, err := validateTimezone(*req.Timezone)
 		if err != nil {
-			return fmt.Errorf("invalid facility ID: %w", err)
+			return err
+		}
+		venue.Timezone = timezone
+	}
+
+	// Facilities are only replaced when the list is sent; an empty list clears them
+	if req.Facilities != nil {
+		facilityUUIDs := make([]uuid.UUID, len(req.Facilities))
+		for i, facility := range req.Facilities {
+			facilityUUID, err := uuid.Parse(facility.ID)
+			if err != nil {
+				return fmt.Errorf("%w: invalid facility ID: %v", ErrValidation, err)
+			}
+			facilityUUIDs[i] = facilityUUID
 		}
-		facilityUUIDs[i] = facilityUUID
-	}
 
-	if err := uc.venueRepo.UpdateFacilities(ctx, venue.ID, facilityUUIDs); err != nil {
-		return fmt.Errorf("failed to update facilities: %w", err)
+		if err := uc.venueRepo.UpdateFacilities(ctx, venue.ID, facilityUUIDs); err != nil {
+			return fmt.Errorf("failed to update facilities: %w", err)
+		}
 	}
 
 	venue.UpdatedAt = time.Now()
@@ -195,11 +334,183 @@ func (uc *useCase) UpdateVenue(ctx context.Context, id uuid.UUID, req requests.U
 		return fmt.Errorf("failed to update venue: %w", err)
 	}
 
+	if maintenance {
+		if err := uc.cancelUpcomingActivity(ctx, &venue.Venue); err != nil {
+			return fmt.Errorf("failed to cancel upcoming activity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// cancelUpcomingActivity cancels the bookings and sessions that have not started yet
+// at a venue going into maintenance, refunding paid bookings and notifying everyone affected.
+// Sessions are cleaned up the way a host cancellation does, session chat included.
+// A booking or session is only marked cancelled once the rest of its cleanup is done,
+// so running this again after a failure picks up whatever was left.
+func (uc *useCase) cancelUpcomingActivity(ctx context.Context, venue *models.Venue) error {
+	loc := venue.TimeLocation()
+	now := time.Now().In(loc)
+	startOf := func(date, start time.Time) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+	}
+
+	bookings, err := uc.bookingRepo.GetUpcomingVenueBookings(ctx, venue.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	for _, booking := range bookings {
+		if !startOf(booking.Date, booking.StartTime).After(now) {
+			continue
+		}
+
+		if booking.Payment != nil && booking.Payment.Status == models.PaymentStatusCompleted {
+			booking.Payment.Status = models.PaymentStatusRefunded
+			booking.Payment.UpdatedAt = time.Now()
+			if err := uc.bookingRepo.UpdatePayment(ctx, booking.Payment); err != nil {
+				return fmt.Errorf("failed to refund booking %s: %w", booking.ID, err)
+			}
+			uc.publishBookingEvent(ctx, venue.ID, booking.ID, models.WebhookEventPaymentUpdated)
+		}
+		// Also true when a previous run refunded the booking but failed to cancel it
+		refunded := booking.Payment != nil && booking.Payment.Status == models.PaymentStatusRefunded
+
+		if err := uc.bookingRepo.CancelBooking(ctx, booking.ID); err != nil {
+			return fmt.Errorf("failed to cancel booking %s: %w", booking.ID, err)
+		}
+		uc.publishBookingEvent(ctx, venue.ID, booking.ID, models.WebhookEventBookingCancelled)
+
+		body := fmt.Sprintf("%s is closed for maintenance, so your booking of %s on %s at %s was cancelled.",
+			venue.Name, booking.CourtName, booking.Date.Format("2006-01-02"), booking.StartTime.Format("15:04"))
+		if refunded {
+			body += " Your payment has been refunded."
+		}
+		uc.notify(ctx, booking.UserID, models.NotificationTypeBooking, "Booking cancelled", body, map[string]interface{}{
+			"booking_id": booking.ID,
+			"venue_id":   venue.ID,
+			"refunded":   refunded,
+		})
+	}
+
+	sessions, err := uc.sessionRepo.GetUpcomingVenueSessions(ctx, venue.ID, now)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		if !startOf(session.SessionDate, session.StartTime).After(now) {
+			continue
+		}
+
+		participants, err := uc.sessionRepo.GetParticipants(ctx, session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get participants: %w", err)
+		}
+
+		// Not every session has a chat, and a previous run may have deleted it
+		hasChat := true
+		chatID, err := uc.chatRepo.GetChatIDBySessionID(ctx, session.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			hasChat = false
+		} else if err != nil {
+			return fmt.Errorf("failed to get chat ID: %w", err)
+		}
+
+		body := fmt.Sprintf("%s is closed for maintenance, so \"%s\" on %s at %s was cancelled.",
+			venue.Name, session.Title, session.SessionDate.Format("2006-01-02"), session.StartTime.Format("15:04"))
+		data := map[string]interface{}{
+			"session_id": session.ID,
+			"venue_id":   venue.ID,
+		}
+
+		notifiedHost := false
+		for _, p := range participants {
+			if p.Status == models.ParticipantStatusCancelled {
+				continue
+			}
+			if err := uc.sessionRepo.UpdateParticipantStatus(ctx, session.ID, p.UserID, models.ParticipantStatusCancelled); err != nil {
+				return fmt.Errorf("failed to update participant status: %w", err)
+			}
+			if hasChat {
+				if err := uc.chatRepo.RemoveUserFromChat(ctx, p.UserID, chatID); err != nil {
+					return fmt.Errorf("failed to remove user from chat: %w", err)
+				}
+			}
+			uc.notify(ctx, p.UserID, models.NotificationTypeSessionCancelled, "Session cancelled", body, data)
+			notifiedHost = notifiedHost || p.UserID == session.HostID
+		}
+
+		if !notifiedHost {
+			uc.notify(ctx, session.HostID, models.NotificationTypeSessionCancelled, "Session cancelled", body, data)
+		}
+
+		// The session chat goes with the session
+		if hasChat {
+			if err := uc.chatRepo.DeleteChat(ctx, chatID); err != nil {
+				return fmt.Errorf("failed to delete session chat: %w", err)
+			}
+		}
+
+		session.Status = models.SessionStatusCancelled
+		session.UpdatedAt = time.Now()
+		if err := uc.sessionRepo.Update(ctx, &session); err != nil {
+			return fmt.Errorf("failed to cancel session %s: %w", session.ID, err)
+		}
+		metrics.SessionsCancelled.WithLabelValues("venue").Inc()
+	}
+
 	return nil
 }
 
-func (uc *useCase) ListVenues(ctx context.Context, location string, limit, offset int) ([]responses.ListVenueResponse, error) {
-	venues, err := uc.venueRepo.List(ctx, location, limit, offset)
+// publishBookingEvent sends the booking's current state to the venue's webhooks.
+// A failed lookup is logged and must not undo the cancellation.
+func (uc *useCase) publishBookingEvent(ctx context.Context, venueID, bookingID uuid.UUID, event models.WebhookEvent) {
+	booking, err := uc.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		log.Printf("failed to load booking %s for %s webhook: %v", bookingID, event, err)
+		return
+	}
+
+	uc.webhooks.Dispatch(venueID, event, booking.ToResponse())
+}
+
+// notify sends a best-effort notification; a failure must not undo the cancellation
+func (uc *useCase) notify(ctx context.Context, userID uuid.UUID, notificationType models.NotificationType, title, body string, data interface{}) {
+	if err := uc.notificationUseCase.Notify(ctx, userID, notificationType, title, body, data); err != nil {
+		log.Printf("failed to notify user %s: %v", userID, err)
+	}
+}
+
+// ListVenues lists active venues. Other statuses are only listed to signed in
+// callers: owners see their own venues with that status, admins every venue.
+func (uc *useCase) ListVenues(ctx context.Context, callerID uuid.UUID, location, status string, tags []string, matchAllTags bool, limit, offset int) ([]responses.ListVenueResponse, error) {
+	filters := interfaces.VenueListFilters{
+		Location: location,
+		Status:   models.VenueStatusActive,
+		Tags:     interfaces.VenueTagFilter{Tags: normalizeTags(tags), MatchAll: matchAllTags},
+	}
+
+	switch models.VenueStatus(status) {
+	case "", models.VenueStatusActive:
+	case models.VenueStatusInactive, models.VenueStatusMaintenance:
+		if callerID == uuid.Nil {
+			return nil, fmt.Errorf("%w: sign in to list %s venues", ErrUnauthorized, status)
+		}
+		filters.Status = models.VenueStatus(status)
+
+		caller, err := uc.userRepo.GetByID(ctx, callerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if caller.Role != string(models.UserRoleAdmin) {
+			filters.OwnerID = callerID
+		}
+	default:
+		return nil, fmt.Errorf("%w: status must be active, inactive or maintenance", ErrValidation)
+	}
+
+	venues, err := uc.venueRepo.List(ctx, filters, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list venues: %w", err)
 	}
@@ -208,52 +519,41 @@ func (uc *useCase) ListVenues(ctx context.Context, location string, limit, offse
 
 	for _, venue := range venues {
 		venueResponses = append(venueResponses, responses.ListVenueResponse{
-			ID:   venue.ID.String(),
-			Name: venue.Name,
+			ID:       venue.ID.String(),
+			Name:     venue.Name,
+			Featured: venue.Featured,
 		})
 	}
+
+	if callerID != uuid.Nil {
+		venueIDs := make([]uuid.UUID, len(venues))
+		for i, venue := range venues {
+			venueIDs[i] = venue.ID
+		}
+		favorited, err := uc.venueRepo.GetFavoritedVenueIDs(ctx, callerID, venueIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get favorite venues: %w", err)
+		}
+		for i, venue := range venues {
+			isFavorited := favorited[venue.ID]
+			venueResponses[i].IsFavorited = &isFavorited
+		}
+	}
+
 	return venueResponses, nil
 }
 
-func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string) (responses.VenueResponseDTO, error) {
-	venues, err := uc.venueRepo.Search(ctx, query, limit, offset, minPrice, maxPrice, location, facilities)
+func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset int, minPrice int, maxPrice int, location string, facilities []string, tags []string, matchAllTags bool) (responses.VenueResponseDTO, error) {
+	tagFilter := interfaces.VenueTagFilter{Tags: normalizeTags(tags), MatchAll: matchAllTags}
+
+	venues, err := uc.venueRepo.Search(ctx, query, limit, offset, minPrice, maxPrice, location, facilities, tagFilter)
 	if err != nil {
 		return responses.VenueResponseDTO{}, fmt.Errorf("failed to search venues: %w", err)
 	}
 
 	venueResponses := make([]responses.VenueResponse, len(venues))
 	for i, venue := range venues {
-		venueResponses[i] = responses.VenueResponse{
-			ID:          venue.ID.String(),
-			Name:        venue.Name,
-			Description: venue.Description,
-			Address:     venue.Address,
-			Location:    venue.Location,
-			Phone:       venue.Phone,
-			Email:       venue.Email,
-			OpenRange: func() []responses.OpenRangeResponse {
-				var openRange []responses.OpenRangeResponse
-				if err := unMarshalJSON(venue.OpenRange.RawMessage, &openRange); err != nil {
-					return nil
-				}
-				return openRange
-			}(),
-			ImageURLs:    venue.ImageURLs,
-			Status:       string(venue.Status),
-			Rating:       venue.Rating,
-			TotalReviews: venue.TotalReviews,
-			Facilities:   convertToFacilityResponse(venue.Facilities),
-			Rules: func() []responses.RuleResponse {
-				var rules []responses.RuleResponse
-				if err := unMarshalJSON(venue.Rules.RawMessage, &rules); err != nil {
-					return nil
-				}
-				return rules
-			}(),
-			Courts:    convertToCourtResponse(venue.Courts),
-			Latitude:  venue.Latitude,
-			Longitude: venue.Longitude,
-		}
+		venueResponses[i] = toVenueResponse(venue)
 	}
 
 	// total, err := uc.venueRepo.CountVenues(ctx)
@@ -261,7 +561,7 @@ func (uc *useCase) SearchVenues(ctx context.Context, query string, limit, offset
 	// 	return responses.VenueResponseDTO{}, fmt.Errorf("failed to count venues: %w", err)
 	// }
 
-	total, err := uc.venueRepo.CountSearch(ctx, query, minPrice, maxPrice, location, facilities)
+	total, err := uc.venueRepo.CountSearch(ctx, query, minPrice, maxPrice, location, facilities, tagFilter)
 	if err != nil {
 		return responses.VenueResponseDTO{}, fmt.Errorf("failed to count venues: %w", err)
 	}
@@ -285,12 +585,21 @@ func (uc *useCase) AddCourt(ctx context.Context, venueID uuid.UUID, req requests
 		}
 	}
 
+	maxPlayers := models.DefaultCourtMaxPlayers
+	if req.MaxPlayers != 0 {
+		if req.MaxPlayers < 1 {
+			return nil, fmt.Errorf("%w: max players must be at least 1", ErrValidation)
+		}
+		maxPlayers = req.MaxPlayers
+	}
+
 	court := &models.Court{
 		ID:           uuid.New(),
 		VenueID:      venueID,
 		Name:         req.Name,
 		Description:  req.Description,
 		PricePerHour: req.PricePerHour,
+		MaxPlayers:   maxPlayers,
 		Status:       models.CourtStatusAvailable,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
@@ -305,19 +614,20 @@ func (uc *useCase) AddCourt(ctx context.Context, venueID uuid.UUID, req requests
 		Name:         court.Name,
 		Description:  court.Description,
 		PricePerHour: court.PricePerHour,
+		MaxPlayers:   court.MaxPlayers,
 		Status:       string(court.Status),
 	}, nil
 }
 
-func (uc *useCase) UpdateCourt(ctx context.Context, venueID uuid.UUID, req requests.UpdateCourtRequest) error {
+func (uc *useCase) UpdateCourt(ctx context.Context, venueID uuid.UUID, req requests.UpdateCourtRequest) (*responses.UpdateCourtResponse, error) {
 
 	courts, err := uc.venueRepo.GetCourts(ctx, venueID)
 	if err != nil {
-		return fmt.Errorf("failed to get court: %w", err)
+		return nil, fmt.Errorf("failed to get court: %w", err)
 	}
 	courtUUID, err := uuid.Parse(req.CourtID)
 	if err != nil {
-		return fmt.Errorf("invalid court ID: %w", err)
+		return nil, fmt.Errorf("invalid court ID: %w", err)
 	}
 
 	var court *models.Court
@@ -330,29 +640,161 @@ func (uc *useCase) UpdateCourt(ctx context.Context, venueID uuid.UUID, req reque
 	}
 
 	if court == nil {
-		return fmt.Errorf("court not found")
+		return nil, fmt.Errorf("court not found")
 	}
 
-	if req.Name != "" {
-		court.Name = req.Name
+	if req.Name != nil {
+		if strings.TrimSpace(*req.Name) == "" {
+			return nil, fmt.Errorf("%w: court name cannot be empty", ErrValidation)
+		}
+		court.Name = *req.Name
+	}
+	if req.Description != nil {
+		court.Description = *req.Description
 	}
-	if req.Description != "" {
-		court.Description = req.Description
+	if req.PricePerHour != nil {
+		if *req.PricePerHour <= 0 {
+			return nil, fmt.Errorf("%w: price per hour must be greater than 0", ErrValidation)
+		}
+		court.PricePerHour = *req.PricePerHour
 	}
-	if req.PricePerHour > 0 {
-		court.PricePerHour = req.PricePerHour
+	if req.MaxPlayers != nil {
+		if *req.MaxPlayers < 1 {
+			return nil, fmt.Errorf("%w: max players must be at least 1", ErrValidation)
+		}
+		court.MaxPlayers = *req.MaxPlayers
 	}
-	if req.Status != "" {
-		court.Status = models.CourtStatus(req.Status)
+	enteringMaintenance := false
+	if req.Status != nil {
+		status := models.CourtStatus(*req.Status)
+		enteringMaintenance = status == models.CourtStatusMaintenance && court.Status != models.CourtStatusMaintenance
+		court.Status = status
 	}
 
 	court.UpdatedAt = time.Now()
 
 	if err := uc.venueRepo.UpdateCourt(ctx, court); err != nil {
-		return fmt.Errorf("failed to update court: %w", err)
+		return nil, fmt.Errorf("failed to update court: %w", err)
 	}
 
-	return nil
+	response := &responses.UpdateCourtResponse{AffectedSessions: []responses.AffectedSessionResponse{}}
+	if enteringMaintenance {
+		affected, err := uc.moveSessionsOffCourt(ctx, venueID, court, courts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to move sessions off court: %w", err)
+		}
+		response.AffectedSessions = affected
+	}
+
+	return response, nil
+}
+
+// moveSessionsOffCourt handles the sessions that have not started yet and still hold a court
+// going into maintenance. Each one is moved to another available court at the venue that is
+// free for its whole time; when none is, the host is asked to pick a new court.
+func (uc *useCase) moveSessionsOffCourt(ctx context.Context, venueID uuid.UUID, court *models.Court, courts []models.Court) ([]responses.AffectedSessionResponse, error) {
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	loc := venue.TimeLocation()
+	now := time.Now().In(loc)
+
+	sessions, err := uc.sessionRepo.GetUpcomingCourtSessions(ctx, court.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	affected := []responses.AffectedSessionResponse{}
+	for _, session := range sessions {
+		start := time.Date(session.SessionDate.Year(), session.SessionDate.Month(), session.SessionDate.Day(),
+			session.StartTime.Hour(), session.StartTime.Minute(), 0, 0, loc)
+		if !start.After(now) {
+			continue
+		}
+
+		replacement, err := uc.findFreeCourt(ctx, &session, court.ID, courts)
+		if err != nil {
+			return nil, err
+		}
+
+		result := responses.AffectedSessionResponse{
+			SessionID:   session.ID.String(),
+			Title:       session.Title,
+			SessionDate: session.SessionDate.Format("2006-01-02"),
+			StartTime:   session.StartTime.Format("15:04"),
+			EndTime:     session.EndTime.Format("15:04"),
+		}
+		data := map[string]interface{}{
+			"session_id": session.ID,
+			"venue_id":   venueID,
+			"court_id":   court.ID,
+		}
+
+		if replacement == nil {
+			body := fmt.Sprintf("%s at %s is under maintenance. Please pick another court for \"%s\" on %s at %s.",
+				court.Name, venue.Name, session.Title, result.SessionDate, result.StartTime)
+			uc.notify(ctx, session.HostID, models.NotificationTypeSessionUpdated, "Court unavailable", body, data)
+			affected = append(affected, result)
+			continue
+		}
+
+		if err := uc.sessionRepo.ReplaceSessionCourt(ctx, session.ID, court.ID, replacement.ID); err != nil {
+			return nil, fmt.Errorf("failed to reassign session %s: %w", session.ID, err)
+		}
+		result.ReassignedCourtID = replacement.ID.String()
+		result.ReassignedCourtName = replacement.Name
+		data["new_court_id"] = replacement.ID
+
+		body := fmt.Sprintf("%s at %s is under maintenance, so \"%s\" on %s at %s was moved to %s.",
+			court.Name, venue.Name, session.Title, result.SessionDate, result.StartTime, replacement.Name)
+		uc.notify(ctx, session.HostID, models.NotificationTypeSessionUpdated, "Court changed", body, data)
+		affected = append(affected, result)
+	}
+
+	return affected, nil
+}
+
+// findFreeCourt returns the first court other than excludeID, in display order,
+// that isn't under maintenance and that neither another session nor a booking
+// holds during the session. The live occupied status only describes the current
+// hour, so it doesn't count. It returns nil when every court is taken or already
+// held by the session.
+func (uc *useCase) findFreeCourt(ctx context.Context, session *models.Session, excludeID uuid.UUID, courts []models.Court) (*models.Court, error) {
+	detail, err := uc.sessionRepo.GetByID(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	own := make(map[uuid.UUID]bool, len(detail.Courts))
+	for _, c := range detail.Courts {
+		own[c.ID] = true
+	}
+
+	for i := range courts {
+		candidate := &courts[i]
+		if candidate.ID == excludeID || own[candidate.ID] || candidate.Status == models.CourtStatusMaintenance {
+			continue
+		}
+
+		conflicts, err := uc.sessionRepo.GetConflictingSessionCourts(ctx, []uuid.UUID{candidate.ID}, session.SessionDate, session.StartTime, session.EndTime, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check session courts: %w", err)
+		}
+		if len(conflicts) > 0 {
+			continue
+		}
+
+		available, err := uc.bookingRepo.CheckCourtAvailability(ctx, candidate.ID, session.SessionDate, session.StartTime, session.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check court availability: %w", err)
+		}
+		if available {
+			return candidate, nil
+		}
+	}
+
+	return nil, nil
 }
 
 func (uc *useCase) DeleteCourt(ctx context.Context, venueID uuid.UUID, courtID uuid.UUID) error {
@@ -382,7 +824,67 @@ func (uc *useCase) DeleteCourt(ctx context.Context, venueID uuid.UUID, courtID u
 
 }
 
+// ReorderCourts sets the order the venue's courts are listed in. The list must
+// name each of the venue's courts exactly once.
+func (uc *useCase) ReorderCourts(ctx context.Context, venueID uuid.UUID, req requests.ReorderCourtsRequest) ([]responses.CourtResponse, error) {
+	courts, err := uc.venueRepo.GetCourts(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courts: %w", err)
+	}
+
+	if len(req.CourtIDs) != len(courts) {
+		return nil, fmt.Errorf("%w: court_ids must list all %d courts of the venue", ErrValidation, len(courts))
+	}
+
+	remaining := make(map[uuid.UUID]bool, len(courts))
+	for _, court := range courts {
+		remaining[court.ID] = true
+	}
+
+	courtIDs := make([]uuid.UUID, len(req.CourtIDs))
+	for i, value := range req.CourtIDs {
+		courtID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid court ID %q", ErrValidation, value)
+		}
+		if !remaining[courtID] {
+			return nil, fmt.Errorf("%w: court %s is not a court of this venue or is listed twice", ErrValidation, courtID)
+		}
+		delete(remaining, courtID)
+		courtIDs[i] = courtID
+	}
+
+	if err := uc.venueRepo.ReorderCourts(ctx, venueID, courtIDs); err != nil {
+		return nil, fmt.Errorf("failed to reorder courts: %w", err)
+	}
+
+	courts, err = uc.venueRepo.GetCourts(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courts: %w", err)
+	}
+
+	return convertToCourtResponse(courts), nil
+}
+
 func (uc *useCase) AddReview(ctx context.Context, venueID uuid.UUID, userID uuid.UUID, req requests.AddReviewRequest) error {
+	reviewed, err := uc.venueRepo.HasUserReviewed(ctx, venueID, userID)
+	if err != nil {
+		return err
+	}
+	if reviewed {
+		return ErrAlreadyReviewed
+	}
+
+	lastReviewAt, err := uc.venueRepo.GetLastReviewAt(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if lastReviewAt != nil {
+		if wait := reviewCooldown - time.Since(*lastReviewAt); wait > 0 {
+			return fmt.Errorf("%w (%ds)", ErrReviewCooldown, int(wait.Seconds())+1)
+		}
+	}
+
 	review := &models.VenueReview{
 		ID:        uuid.New(),
 		VenueID:   venueID,
@@ -392,17 +894,24 @@ func (uc *useCase) AddReview(ctx context.Context, venueID uuid.UUID, userID uuid
 		CreatedAt: time.Now(),
 	}
 
-	fmt.Println("review added before")
-
-	if err := uc.venueRepo.AddReview(ctx, review); err != nil {
+	// The checks above give the usual answers; the repository repeats them under
+	// lock for concurrent submissions that both got past them
+	if err := uc.venueRepo.AddReview(ctx, review, reviewCooldown); err != nil {
+		switch {
+		case errors.Is(err, interfaces.ErrDuplicateReview):
+			return ErrAlreadyReviewed
+		case errors.Is(err, interfaces.ErrReviewTooSoon):
+			return ErrReviewCooldown
+		}
 		return fmt.Errorf("failed to add review: %w", err)
 	}
 
-	fmt.Println("review added")
-
 	return nil
 }
-func (uc *useCase) GetReviews(ctx context.Context, venueID uuid.UUID, limit, offset int) ([]responses.ReviewResponse, error) {
+
+// GetReviews lists a venue's reviews rated between minRating and maxRating, where
+// zero leaves a bound open. sort is newest (the default) or helpful.
+func (uc *useCase) GetReviews(ctx context.Context, venueID uuid.UUID, sort string, minRating, maxRating int, limit, offset int) ([]responses.ReviewResponse, error) {
 	// Input validation
 	if venueID == uuid.Nil {
 		return nil, fmt.Errorf("invalid venue ID")
@@ -412,8 +921,26 @@ func (uc *useCase) GetReviews(ctx context.Context, venueID uuid.UUID, limit, off
 		return nil, fmt.Errorf("invalid pagination parameters")
 	}
 
+	for _, rating := range []int{minRating, maxRating} {
+		if rating < 0 || rating > 5 {
+			return nil, fmt.Errorf("%w: ratings go from 1 to 5", ErrValidation)
+		}
+	}
+	if minRating > 0 && maxRating > 0 && minRating > maxRating {
+		return nil, fmt.Errorf("%w: min_rating cannot be above max_rating", ErrValidation)
+	}
+
+	filters := interfaces.ReviewListFilters{MinRating: minRating, MaxRating: maxRating}
+	switch sort {
+	case "", "newest":
+	case "helpful":
+		filters.SortByHelpful = true
+	default:
+		return nil, fmt.Errorf("%w: sort must be newest or helpful", ErrValidation)
+	}
+
 	// Get reviews
-	reviews, err := uc.venueRepo.GetReviews(ctx, venueID, limit, offset)
+	reviews, err := uc.venueRepo.GetReviews(ctx, venueID, filters, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviews: %w", err)
 	}
@@ -455,61 +982,908 @@ func (uc *useCase) GetReviews(ctx context.Context, venueID uuid.UUID, limit, off
 			return nil, fmt.Errorf("user not found for review %s", review.ID)
 		}
 
-		reviewResponses[i] = responses.ReviewResponse{
-			ID:        review.ID.String(),
-			Rating:    review.Rating,
-			Comment:   review.Comment,
-			CreatedAt: review.CreatedAt.Format(time.RFC3339),
-			Reviewer: responses.ReviewerResponse{
-				FirstName: user.FirstName,
-				LastName:  user.LastName,
-				AvatarURL: user.AvatarURL,
-			},
-		}
+		reviewResponses[i] = toReviewResponse(&review, &user)
 	}
 
 	return reviewResponses, nil
 }
 
-func (uc *useCase) GetFacilities(ctx context.Context, venueID uuid.UUID) (*responses.FacilityListResponse, error) {
-	facilities, err := uc.venueRepo.GetFacilities(ctx, venueID)
+// GetUserReview returns the user's own review of the venue, or nil when they haven't reviewed it
+func (uc *useCase) GetUserReview(ctx context.Context, venueID, userID uuid.UUID) (*responses.ReviewResponse, error) {
+	review, err := uc.venueRepo.GetUserVenueReview(ctx, venueID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get facilities: %w", err)
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil {
+		return nil, nil
 	}
 
-	facilityResponses := make([]responses.FacilityResponse, len(facilities))
-	for i := range facilities {
-		facilityResponses[i] = responses.FacilityResponse{
-			ID:   facilities[i].ID.String(),
-			Name: facilities[i].Name,
-		}
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer: %w", err)
 	}
 
-	return &responses.FacilityListResponse{
-		Facilities: facilityResponses,
-	}, nil
+	response := toReviewResponse(review, user)
+	return &response, nil
 }
 
-func (uc *useCase) IsOwner(ctx context.Context, venueID uuid.UUID, ownerID uuid.UUID) (bool, error) {
+func toReviewResponse(review *models.VenueReview, user *models.User) responses.ReviewResponse {
+	response := responses.ReviewResponse{
+		ID:           review.ID.String(),
+		Rating:       review.Rating,
+		Comment:      review.Comment,
+		HelpfulCount: review.HelpfulCount,
+		CreatedAt:    review.CreatedAt.Format(time.RFC3339),
+		Reviewer: responses.ReviewerResponse{
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			AvatarURL: user.AvatarURL,
+		},
+	}
+	if review.OwnerResponse != nil && review.OwnerRespondedAt != nil {
+		response.OwnerResponse = &responses.OwnerReplyResponse{
+			Response:    *review.OwnerResponse,
+			RespondedAt: review.OwnerRespondedAt.Format(time.RFC3339),
+		}
+	}
+	return response
+}
+
+// GetRatingBreakdown counts the venue's reviews per star rating, listing every rating
+// from 5 down to 1 so clients can draw the bars without filling gaps
+func (uc *useCase) GetRatingBreakdown(ctx context.Context, venueID uuid.UUID) (*responses.RatingBreakdownResponse, error) {
 	venue, err := uc.venueRepo.GetByID(ctx, venueID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get venue: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
 	}
 
-	return venue.OwnerID == ownerID, nil
-}
+	counts, err := uc.venueRepo.GetRatingCounts(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating breakdown: %w", err)
+	}
 
-func convertToOpenRangeResponse(openRanges []requests.OpenRange) []responses.OpenRangeResponse {
-	var openRangeResponses []responses.OpenRangeResponse
-	for _, openRange := range openRanges {
-		openRangeResponses = append(openRangeResponses, responses.OpenRangeResponse{
-			Day:       openRange.Day,
-			IsOpen:    openRange.IsOpen,
-			OpenTime:  openRange.OpenTime,
-			CloseTime: openRange.CloseTime,
+	breakdown := &responses.RatingBreakdownResponse{
+		VenueID:      venue.ID.String(),
+		Rating:       venue.Rating,
+		TotalReviews: venue.TotalReviews,
+		Counts:       make([]responses.RatingCountResponse, 0, 5),
+	}
+	for stars := 5; stars >= 1; stars-- {
+		breakdown.Counts = append(breakdown.Counts, responses.RatingCountResponse{
+			Stars: stars,
+			Count: counts[stars],
 		})
 	}
-	return openRangeResponses
+
+	return breakdown, nil
+}
+
+// RespondToReview sets the venue owner's public reply to one of the venue's reviews.
+// A review has at most one reply; responding again replaces it.
+func (uc *useCase) RespondToReview(ctx context.Context, venueID, reviewID uuid.UUID, req requests.RespondToReviewRequest) (*responses.OwnerReplyResponse, error) {
+	response := strings.TrimSpace(req.Response)
+	if response == "" {
+		return nil, fmt.Errorf("%w: response cannot be empty", ErrValidation)
+	}
+	if utf8.RuneCountInString(response) > maxReviewResponseLength {
+		return nil, fmt.Errorf("%w: response must be at most %d characters", ErrValidation, maxReviewResponseLength)
+	}
+
+	review, err := uc.venueRepo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReviewNotFound, err)
+	}
+	if review.VenueID != venueID {
+		return nil, ErrReviewNotFound
+	}
+
+	respondedAt := time.Now()
+	if err := uc.venueRepo.SetReviewResponse(ctx, reviewID, response, respondedAt); err != nil {
+		return nil, fmt.Errorf("failed to respond to review: %w", err)
+	}
+
+	return &responses.OwnerReplyResponse{
+		Response:    response,
+		RespondedAt: respondedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// ToggleReviewHelpful adds the user's helpful vote on a review of the venue, or takes
+// it back when they already voted. Reviewers can't vote on their own review.
+func (uc *useCase) ToggleReviewHelpful(ctx context.Context, venueID, reviewID, userID uuid.UUID) (*responses.ReviewVoteResponse, error) {
+	review, err := uc.venueRepo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReviewNotFound, err)
+	}
+	if review.VenueID != venueID {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID == userID {
+		return nil, fmt.Errorf("%w: you can't vote on your own review", ErrValidation)
+	}
+
+	helpful, count, err := uc.venueRepo.ToggleReviewVote(ctx, reviewID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to vote on review: %w", err)
+	}
+
+	return &responses.ReviewVoteResponse{
+		ReviewID:     reviewID.String(),
+		Helpful:      helpful,
+		HelpfulCount: count,
+	}, nil
+}
+
+// GetSchedule builds the booking grid for a venue: every usable court's 30 minute
+// slots for the day, marked free, booked or held by a session. date defaults to
+// today in the venue's timezone.
+func (uc *useCase) GetSchedule(ctx context.Context, venueID uuid.UUID, date string) (*responses.VenueScheduleResponse, error) {
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	loc := venue.TimeLocation()
+	day := time.Now().In(loc)
+	if date != "" {
+		day, err = time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrValidation)
+		}
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+
+	// at places a time of day on the schedule date
+	at := func(clock time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+	// span handles ranges that run past midnight
+	span := func(start, end time.Time) (time.Time, time.Time) {
+		from, to := at(start), at(end)
+		if !to.After(from) {
+			to = to.AddDate(0, 0, 1)
+		}
+		return from, to
+	}
+
+	response := &responses.VenueScheduleResponse{
+		VenueID: venue.ID.String(),
+		Date:    day.Format("2006-01-02"),
+		Courts:  []responses.CourtScheduleResponse{},
+	}
+
+	openRanges := []responses.OpenRangeResponse{}
+	if err := unMarshalJSON(venue.OpenRange.RawMessage, &openRanges); err != nil {
+		return nil, fmt.Errorf("failed to decode open range: %w", err)
+	}
+
+	var daySchedule *responses.OpenRangeResponse
+	for i := range openRanges {
+		if strings.EqualFold(openRanges[i].Day, day.Weekday().String()) {
+			daySchedule = &openRanges[i]
+			break
+		}
+	}
+	if daySchedule == nil || !daySchedule.IsOpen {
+		return response, nil
+	}
+
+	open, close := span(daySchedule.OpenTime, daySchedule.CloseTime)
+	response.IsOpen = true
+	response.OpenTime = open.Format("15:04")
+	response.CloseTime = close.Format("15:04")
+
+	bookings, err := uc.bookingRepo.GetVenueBookings(ctx, venueID, day, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	sessionCourts, err := uc.sessionRepo.GetVenueSessionCourts(ctx, venueID, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	for _, court := range venue.Courts {
+		if court.Status == models.CourtStatusMaintenance {
+			continue
+		}
+
+		slots := []responses.ScheduleSlot{}
+		for start := open; !start.Add(scheduleSlotLength).After(close); start = start.Add(scheduleSlotLength) {
+			end := start.Add(scheduleSlotLength)
+			slot := responses.ScheduleSlot{
+				StartTime: start.Format("15:04"),
+				EndTime:   end.Format("15:04"),
+				Status:    "free",
+			}
+
+			for _, booking := range bookings {
+				if booking.CourtID != court.ID || booking.Status == models.BookingStatusCancelled {
+					continue
+				}
+				from, to := span(booking.StartTime, booking.EndTime)
+				if from.Before(end) && to.After(start) {
+					slot.Status = "booked"
+					slot.BookingID = booking.ID.String()
+					break
+				}
+			}
+
+			if slot.Status == "free" {
+				for _, sessionCourt := range sessionCourts {
+					if sessionCourt.CourtID != court.ID {
+						continue
+					}
+					from, to := span(sessionCourt.StartTime, sessionCourt.EndTime)
+					if from.Before(end) && to.After(start) {
+						slot.Status = "session"
+						slot.SessionID = sessionCourt.SessionID.String()
+						break
+					}
+				}
+			}
+
+			slots = append(slots, slot)
+		}
+
+		response.Courts = append(response.Courts, responses.CourtScheduleResponse{
+			CourtID:      court.ID.String(),
+			CourtName:    court.Name,
+			PricePerHour: court.PricePerHour,
+			Slots:        slots,
+		})
+	}
+
+	return response, nil
+}
+
+// GetDashboard gathers today's bookings, upcoming sessions, rating, courts under
+// maintenance and this week's revenue for the owner's home screen
+func (uc *useCase) GetDashboard(ctx context.Context, venueID uuid.UUID) (*responses.VenueDashboardResponse, error) {
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	loc := venue.TimeLocation()
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	// Weeks start on Monday
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	dashboard := &responses.VenueDashboardResponse{
+		VenueID:      venue.ID.String(),
+		Date:         today.Format("2006-01-02"),
+		Rating:       venue.Rating,
+		TotalReviews: venue.TotalReviews,
+		WeekStart:    weekStart.Format("2006-01-02"),
+		WeekEnd:      weekEnd.Format("2006-01-02"),
+	}
+
+	bookings, err := uc.bookingRepo.GetVenueBookings(ctx, venue.ID, weekStart, weekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+	for _, booking := range bookings {
+		if booking.Status == models.BookingStatusCancelled {
+			continue
+		}
+		if booking.Date.Format("2006-01-02") == dashboard.Date {
+			dashboard.TodayBookings++
+		}
+		if booking.Payment != nil && booking.Payment.Status == models.PaymentStatusCompleted {
+			dashboard.WeekRevenue += booking.Payment.Amount
+		}
+	}
+
+	sessions, err := uc.sessionRepo.GetUpcomingVenueSessions(ctx, venue.ID, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	dashboard.UpcomingSessions = len(sessions)
+
+	maintenance := []models.Court{}
+	for _, court := range venue.Courts {
+		if court.Status == models.CourtStatusMaintenance {
+			maintenance = append(maintenance, court)
+		}
+	}
+	dashboard.MaintenanceCourts = convertToCourtResponse(maintenance)
+
+	return dashboard, nil
+}
+
+func (uc *useCase) GetFacilities(ctx context.Context, venueID uuid.UUID) (*responses.FacilityListResponse, error) {
+	facilities, err := uc.venueRepo.GetFacilities(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get facilities: %w", err)
+	}
+
+	facilityResponses := make([]responses.FacilityResponse, len(facilities))
+	for i := range facilities {
+		facilityResponses[i] = responses.FacilityResponse{
+			ID:   facilities[i].ID.String(),
+			Name: facilities[i].Name,
+		}
+	}
+
+	return &responses.FacilityListResponse{
+		Facilities: facilityResponses,
+	}, nil
+}
+
+// ClaimVenue files a claim on a venue that has no owner yet, such as one imported
+// before its operator signed up. The claimant becomes the owner once an admin approves it.
+func (uc *useCase) ClaimVenue(ctx context.Context, venueID, claimantID uuid.UUID, req requests.ClaimVenueRequest) (*responses.VenueClaimResponse, error) {
+	evidence := strings.TrimSpace(req.Evidence)
+	if evidence == "" {
+		return nil, fmt.Errorf("%w: evidence is required", ErrValidation)
+	}
+	if utf8.RuneCountInString(evidence) > maxClaimEvidenceLength {
+		return nil, fmt.Errorf("%w: evidence must be at most %d characters", ErrValidation, maxClaimEvidenceLength)
+	}
+	if strings.TrimSpace(req.Phone) == "" {
+		return nil, fmt.Errorf("%w: a phone number is required", ErrValidation)
+	}
+	phoneNumber, err := uc.normalizePhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+	if venue.OwnerID != uuid.Nil {
+		return nil, ErrVenueAlreadyOwned
+	}
+
+	pending, err := uc.venueRepo.HasPendingClaim(ctx, venueID, claimantID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrAlreadyClaimed
+	}
+
+	claim := &models.VenueClaim{
+		ID:         uuid.New(),
+		VenueID:    venueID,
+		ClaimantID: claimantID,
+		Phone:      phoneNumber,
+		Evidence:   evidence,
+		Status:     models.VenueClaimStatusPending,
+		CreatedAt:  time.Now(),
+	}
+	if err := uc.venueRepo.CreateClaim(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	response := toVenueClaimResponse(claim)
+	return &response, nil
+}
+
+// ListClaims lists pending claims unless another status is asked for, oldest first
+func (uc *useCase) ListClaims(ctx context.Context, status string, limit, offset int) (*responses.VenueClaimListResponse, error) {
+	claimStatus := models.VenueClaimStatusPending
+	switch models.VenueClaimStatus(status) {
+	case "":
+	case models.VenueClaimStatusPending, models.VenueClaimStatusApproved, models.VenueClaimStatusRejected:
+		claimStatus = models.VenueClaimStatus(status)
+	default:
+		return nil, fmt.Errorf("%w: status must be pending, approved or rejected", ErrValidation)
+	}
+
+	if limit <= 0 {
+		limit = defaultClaimLimit
+	}
+	if limit > maxClaimLimit {
+		limit = maxClaimLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	claims, err := uc.venueRepo.ListClaims(ctx, claimStatus, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.venueRepo.CountClaims(ctx, claimStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.VenueClaimListResponse{
+		Claims: make([]responses.VenueClaimResponse, len(claims)),
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+	for i := range claims {
+		response.Claims[i] = toVenueClaimResponse(&claims[i])
+	}
+
+	return response, nil
+}
+
+// ReviewClaim approves or rejects a pending claim and tells the claimant. Approving
+// makes the claimant the venue's owner and rejects the other claims on it.
+func (uc *useCase) ReviewClaim(ctx context.Context, claimID, adminID uuid.UUID, req requests.ReviewVenueClaimRequest) (*responses.VenueClaimResponse, error) {
+	status := models.VenueClaimStatus(req.Status)
+	if status != models.VenueClaimStatusApproved && status != models.VenueClaimStatusRejected {
+		return nil, fmt.Errorf("%w: status must be approved or rejected", ErrValidation)
+	}
+
+	claim, err := uc.venueRepo.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrClaimNotFound, err)
+	}
+	if claim.Status != models.VenueClaimStatusPending {
+		return nil, fmt.Errorf("%w: the claim was already %s", ErrValidation, claim.Status)
+	}
+
+	venue, err := uc.venueRepo.GetByID(ctx, claim.VenueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+	if status == models.VenueClaimStatusApproved && venue.OwnerID != uuid.Nil {
+		return nil, ErrVenueAlreadyOwned
+	}
+
+	if err := uc.venueRepo.ReviewClaim(ctx, claimID, status, adminID); err != nil {
+		return nil, fmt.Errorf("failed to review claim: %w", err)
+	}
+
+	claim, err = uc.venueRepo.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrClaimNotFound, err)
+	}
+
+	body := fmt.Sprintf("Your claim on %s was rejected.", venue.Name)
+	if status == models.VenueClaimStatusApproved {
+		body = fmt.Sprintf("Your claim on %s was approved. You can now manage the venue.", venue.Name)
+	}
+	uc.notify(ctx, claim.ClaimantID, models.NotificationTypeSystem, "Venue claim "+string(status), body, map[string]interface{}{
+		"claim_id": claim.ID,
+		"venue_id": venue.ID,
+	})
+
+	response := toVenueClaimResponse(claim)
+	return &response, nil
+}
+
+func toVenueClaimResponse(claim *models.VenueClaim) responses.VenueClaimResponse {
+	response := responses.VenueClaimResponse{
+		ID:         claim.ID.String(),
+		VenueID:    claim.VenueID.String(),
+		ClaimantID: claim.ClaimantID.String(),
+		Phone:      claim.Phone,
+		Evidence:   claim.Evidence,
+		Status:     string(claim.Status),
+		CreatedAt:  claim.CreatedAt.Format(time.RFC3339),
+	}
+
+	if claim.ReviewedBy != nil {
+		reviewedBy := claim.ReviewedBy.String()
+		response.ReviewedBy = &reviewedBy
+	}
+	if claim.ReviewedAt != nil {
+		reviewedAt := claim.ReviewedAt.Format(time.RFC3339)
+		response.ReviewedAt = &reviewedAt
+	}
+
+	return response
+}
+
+// FavoriteVenue bookmarks the venue for the user; favoriting it again changes nothing
+func (uc *useCase) FavoriteVenue(ctx context.Context, venueID, userID uuid.UUID) error {
+	if _, err := uc.venueRepo.GetByID(ctx, venueID); err != nil {
+		return fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	if err := uc.venueRepo.AddFavorite(ctx, userID, venueID); err != nil {
+		return fmt.Errorf("failed to favorite venue: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) UnfavoriteVenue(ctx context.Context, venueID, userID uuid.UUID) error {
+	if err := uc.venueRepo.RemoveFavorite(ctx, userID, venueID); err != nil {
+		return fmt.Errorf("failed to unfavorite venue: %w", err)
+	}
+
+	return nil
+}
+
+// ListFavoriteVenues lists the user's favorite venues, most recently favorited first
+func (uc *useCase) ListFavoriteVenues(ctx context.Context, userID uuid.UUID, limit, offset int) (responses.VenueResponseDTO, error) {
+	venues, err := uc.venueRepo.ListFavorites(ctx, userID, limit, offset)
+	if err != nil {
+		return responses.VenueResponseDTO{}, fmt.Errorf("failed to list favorite venues: %w", err)
+	}
+
+	total, err := uc.venueRepo.CountFavorites(ctx, userID)
+	if err != nil {
+		return responses.VenueResponseDTO{}, fmt.Errorf("failed to count favorite venues: %w", err)
+	}
+
+	venueResponses := make([]responses.VenueResponse, len(venues))
+	for i, venue := range venues {
+		venueResponses[i] = toVenueResponse(venue)
+		isFavorited := true
+		venueResponses[i].IsFavorited = &isFavorited
+	}
+
+	return responses.VenueResponseDTO{
+		Venues: venueResponses,
+		Total:  total,
+	}, nil
+}
+
+func (uc *useCase) IsFavorited(ctx context.Context, venueID, userID uuid.UUID) (bool, error) {
+	favorited, err := uc.venueRepo.GetFavoritedVenueIDs(ctx, userID, []uuid.UUID{venueID})
+	if err != nil {
+		return false, fmt.Errorf("failed to get favorite venues: %w", err)
+	}
+
+	return favorited[venueID], nil
+}
+
+func (uc *useCase) MarkFavorites(ctx context.Context, userID uuid.UUID, venues []responses.VenueResponse) error {
+	venueIDs := make([]uuid.UUID, 0, len(venues))
+	for _, venue := range venues {
+		id, err := uuid.Parse(venue.ID)
+		if err != nil {
+			return fmt.Errorf("invalid venue ID %q: %w", venue.ID, err)
+		}
+		venueIDs = append(venueIDs, id)
+	}
+
+	favorited, err := uc.venueRepo.GetFavoritedVenueIDs(ctx, userID, venueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get favorite venues: %w", err)
+	}
+
+	for i := range venues {
+		isFavorited := favorited[venueIDs[i]]
+		venues[i].IsFavorited = &isFavorited
+	}
+
+	return nil
+}
+
+func (uc *useCase) IsOwner(ctx context.Context, venueID uuid.UUID, ownerID uuid.UUID) (bool, error) {
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get venue: %w", err)
+	}
+
+	return venue.OwnerID == ownerID, nil
+}
+
+// RestoreVenue undoes a soft delete. Only the venue owner or an admin may restore it.
+func (uc *useCase) RestoreVenue(ctx context.Context, venueID uuid.UUID, userID uuid.UUID) (*responses.VenueResponse, error) {
+	venue, err := uc.venueRepo.GetByIDIncludingDeleted(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	if venue.OwnerID != userID {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Role != string(models.UserRoleAdmin) {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	if venue.DeletedAt == nil {
+		return nil, fmt.Errorf("%w: venue is not deleted", ErrValidation)
+	}
+
+	if err := uc.venueRepo.Restore(ctx, venueID); err != nil {
+		return nil, fmt.Errorf("failed to restore venue: %w", err)
+	}
+
+	return uc.GetVenue(ctx, venueID)
+}
+
+func (uc *useCase) ListFeaturedVenues(ctx context.Context, limit, offset int) ([]responses.VenueResponse, error) {
+	venues, err := uc.venueRepo.ListFeatured(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list featured venues: %w", err)
+	}
+
+	venueResponses := make([]responses.VenueResponse, len(venues))
+	for i, venue := range venues {
+		venueResponses[i] = toVenueResponse(venue)
+	}
+
+	return venueResponses, nil
+}
+
+// SetFeatured toggles a venue's promotion. Callers must check the user is an admin.
+func (uc *useCase) SetFeatured(ctx context.Context, venueID uuid.UUID, req requests.SetFeaturedVenueRequest) (*responses.VenueResponse, error) {
+	if req.Featured && req.FeaturedUntil != nil && !req.FeaturedUntil.After(time.Now()) {
+		return nil, fmt.Errorf("%w: featured_until must be in the future", ErrValidation)
+	}
+
+	if err := uc.venueRepo.SetFeatured(ctx, venueID, req.Featured, req.FeaturedUntil); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	return uc.GetVenue(ctx, venueID)
+}
+
+func (uc *useCase) GetTags(ctx context.Context, venueID uuid.UUID) ([]string, error) {
+	tags, err := uc.venueRepo.GetTags(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (uc *useCase) SetTags(ctx context.Context, venueID uuid.UUID, req requests.SetVenueTagsRequest) ([]string, error) {
+	tags := normalizeTags(req.Tags)
+	if len(tags) > maxVenueTags {
+		return nil, fmt.Errorf("%w: a venue can have at most %d tags", ErrValidation, maxVenueTags)
+	}
+
+	for _, tag := range tags {
+		if len(tag) > maxVenueTagLength {
+			return nil, fmt.Errorf("%w: tag %q exceeds %d characters", ErrValidation, tag, maxVenueTagLength)
+		}
+	}
+
+	if err := uc.venueRepo.SetTags(ctx, venueID, tags); err != nil {
+		return nil, fmt.Errorf("failed to set tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (uc *useCase) RemoveTag(ctx context.Context, venueID uuid.UUID, tag string) error {
+	if err := uc.venueRepo.RemoveTag(ctx, venueID, strings.ToLower(strings.TrimSpace(tag))); err != nil {
+		if errors.Is(err, interfaces.ErrTagNotFound) {
+			return ErrTagNotFound
+		}
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeTags lowercases and trims tags, dropping empties and duplicates
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func convertToOpenRangeResponse(openRanges []requests.OpenRange) []responses.OpenRangeResponse {
+	var openRangeResponses []responses.OpenRangeResponse
+	for _, openRange := range openRanges {
+		openRangeResponses = append(openRangeResponses, responses.OpenRangeResponse{
+			Day:       openRange.Day,
+			IsOpen:    openRange.IsOpen,
+			OpenTime:  openRange.OpenTime,
+			CloseTime: openRange.CloseTime,
+		})
+	}
+	return openRangeResponses
+}
+
+// validateOpenRange checks each entry names a weekday, appears once, and opens
+// before it closes on open days. Only the time of day is compared, as booking
+// validation does.
+func validateOpenRange(openRanges []requests.OpenRange) error {
+	seen := make(map[string]bool, len(openRanges))
+	for _, openRange := range openRanges {
+		day := strings.ToLower(strings.TrimSpace(openRange.Day))
+
+		valid := false
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if day == strings.ToLower(weekday.String()) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("%w: open_range day %q is not a weekday name", ErrValidation, openRange.Day)
+		}
+
+		if seen[day] {
+			return fmt.Errorf("%w: open_range has more than one entry for %s", ErrValidation, openRange.Day)
+		}
+		seen[day] = true
+
+		if !openRange.IsOpen {
+			continue
+		}
+		open := openRange.OpenTime.Hour()*60 + openRange.OpenTime.Minute()
+		close := openRange.CloseTime.Hour()*60 + openRange.CloseTime.Minute()
+		if open >= close {
+			return fmt.Errorf("%w: open_range %s must open before it closes", ErrValidation, openRange.Day)
+		}
+	}
+
+	return nil
+}
+
+// validateTimezone checks name is a known IANA timezone; empty means the default
+func validateTimezone(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.DefaultVenueTimezone, nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("%w: unknown timezone %q", ErrValidation, name)
+	}
+	return name, nil
+}
+
+// validateImageURLs checks each URL of a comma separated image_urls value
+// against the image policy
+func (uc *useCase) validateImageURLs(imageURLs string) error {
+	for _, imageURL := range strings.Split(imageURLs, ",") {
+		if imageURL = strings.TrimSpace(imageURL); imageURL == "" {
+			continue
+		}
+		if err := uc.imagePolicy.CheckURL(imageURL); err != nil {
+			return fmt.Errorf("%w: %v", ErrValidation, err)
+		}
+	}
+	return nil
+}
+
+// normalizePhone stores venue phone numbers in E.164 form
+func (uc *useCase) normalizePhone(raw string) (string, error) {
+	normalized, err := phone.Normalize(raw, uc.phoneRegion)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	return normalized, nil
+}
+
+// setOpenStatus fills IsOpenNow, NextOpenAt and NextCloseAt from the weekly
+// OpenRange as seen at now. Ranges closing at or before their opening time run
+// past midnight, and back-to-back ranges are treated as one opening.
+func setOpenStatus(response *responses.VenueResponse, now time.Time) {
+	type interval struct{ open, close time.Time }
+
+	schedule := make(map[string]responses.OpenRangeResponse, len(response.OpenRange))
+	for _, openRange := range response.OpenRange {
+		schedule[strings.ToLower(openRange.Day)] = openRange
+	}
+
+	// Yesterday is included for ranges that run past midnight into today
+	var intervals []interval
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for offset := -1; offset <= 7; offset++ {
+		day := today.AddDate(0, 0, offset)
+		openRange, ok := schedule[strings.ToLower(day.Weekday().String())]
+		if !ok || !openRange.IsOpen {
+			continue
+		}
+
+		open := time.Date(day.Year(), day.Month(), day.Day(),
+			openRange.OpenTime.Hour(), openRange.OpenTime.Minute(), 0, 0, now.Location())
+		close := time.Date(day.Year(), day.Month(), day.Day(),
+			openRange.CloseTime.Hour(), openRange.CloseTime.Minute(), 0, 0, now.Location())
+		if !close.After(open) {
+			close = close.AddDate(0, 0, 1)
+		}
+
+		if n := len(intervals); n > 0 && !open.After(intervals[n-1].close) {
+			if close.After(intervals[n-1].close) {
+				intervals[n-1].close = close
+			}
+			continue
+		}
+		intervals = append(intervals, interval{open: open, close: close})
+	}
+
+	response.IsOpenNow = false
+	response.NextOpenAt = nil
+	response.NextCloseAt = nil
+
+	for i, current := range intervals {
+		if current.close.After(now) && !current.open.After(now) {
+			response.IsOpenNow = true
+			closeAt := current.close
+			response.NextCloseAt = &closeAt
+			if i+1 < len(intervals) {
+				openAt := intervals[i+1].open
+				response.NextOpenAt = &openAt
+			}
+			return
+		}
+
+		if current.open.After(now) {
+			openAt, closeAt := current.open, current.close
+			response.NextOpenAt = &openAt
+			response.NextCloseAt = &closeAt
+			return
+		}
+	}
+}
+
+// toVenueResponse maps a venue row, including its aggregated courts and
+// facilities, to the API response
+func toVenueResponse(venue models.Venue) responses.VenueResponse {
+	now := time.Now().In(venue.TimeLocation())
+	response := responses.VenueResponse{
+		ID:          venue.ID.String(),
+		Name:        venue.Name,
+		Description: venue.Description,
+		Address:     venue.Address,
+		Location:    venue.Location,
+		Phone:       venue.Phone,
+		Email:       venue.Email,
+		OpenRange: func() []responses.OpenRangeResponse {
+			var openRange []responses.OpenRangeResponse
+			if err := unMarshalJSON(venue.OpenRange.RawMessage, &openRange); err != nil {
+				return nil
+			}
+			return openRange
+		}(),
+		ImageURLs:    venue.ImageURLs,
+		Status:       string(venue.Status),
+		Rating:       venue.Rating,
+		TotalReviews: venue.TotalReviews,
+		Facilities:   convertToFacilityResponse(venue.Facilities),
+		Rules: func() []responses.RuleResponse {
+			var rules []responses.RuleResponse
+			if err := unMarshalJSON(venue.Rules.RawMessage, &rules); err != nil {
+				return nil
+			}
+			return rules
+		}(),
+		Courts:    convertToCourtResponse(venue.Courts),
+		Tags:      venue.Tags,
+		Latitude:  venue.Latitude,
+		Longitude: venue.Longitude,
+		Timezone:  venue.Timezone,
+		Relevance: venue.Relevance,
+		UpdatedAt: venueUpdatedAt(venue).Format(time.RFC3339Nano),
+	}
+	setFeaturedStatus(&response, &venue, now)
+	setOpenStatus(&response, now)
+
+	return response
+}
+
+// venueUpdatedAt is the latest change to the venue or its courts, which
+// courts don't propagate to the venue row
+func venueUpdatedAt(venue models.Venue) time.Time {
+	updatedAt := venue.UpdatedAt
+	for _, court := range venue.Courts {
+		if court.UpdatedAt.After(updatedAt) {
+			updatedAt = court.UpdatedAt
+		}
+	}
+	return updatedAt
+}
+
+// setFeaturedStatus only exposes the featured flag while the promotion is active
+func setFeaturedStatus(response *responses.VenueResponse, venue *models.Venue, now time.Time) {
+	response.Featured = venue.IsFeatured(now)
+	if response.Featured {
+		response.FeaturedUntil = venue.FeaturedUntil
+	}
 }
 
 func convertToRuleResponse(rules []requests.Rule) []responses.RuleResponse {
@@ -552,6 +1926,7 @@ func convertToCourtResponse(courts []models.Court) []responses.CourtResponse {
 			Name:         court.Name,
 			Description:  court.Description,
 			PricePerHour: court.PricePerHour,
+			MaxPlayers:   court.MaxPlayers,
 			Status:       string(court.Status),
 		}
 	}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	ErrVenueNotFound = errors.New("venue not found")
+
+	ErrForbidden = errors.New("forbidden")
+
+	ErrValidation = errors.New("validation error")
+)
+
+type UseCase interface {
+	RegisterWebhook(ctx context.Context, venueID, ownerID uuid.UUID, req requests.RegisterWebhookRequest) (*responses.WebhookResponse, error)
+	ListWebhooks(ctx context.Context, venueID, ownerID uuid.UUID) ([]responses.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, venueID, webhookID, ownerID uuid.UUID) error
+	// Dispatch delivers an event to every webhook of the venue in the background.
+	// Deliveries are fire-and-forget: they live only in goroutines that sleep
+	// between retries, so a restart or shutdown drops any still pending, and
+	// receivers should not rely on getting every event.
+	Dispatch(venueID uuid.UUID, event models.WebhookEvent, data interface{})
+}
@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"badbuddy/internal/delivery/dto/requests"
+	"badbuddy/internal/delivery/dto/responses"
+	"badbuddy/internal/domain/models"
+	"badbuddy/internal/repositories/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const (
+	minSecretLength = 16
+	maxSecretLength = 128
+
+	// A delivery is attempted up to deliveryAttempts times, waiting
+	// initialBackoff, then twice as long, and so on between attempts
+	deliveryAttempts = 5
+	initialBackoff   = 2 * time.Second
+	deliveryTimeout  = 10 * time.Second
+)
+
+// errBlockedAddress stops deliveries to addresses inside our own network, so a
+// webhook can't be used to reach internal services
+var errBlockedAddress = errors.New("webhook address is not public")
+
+type useCase struct {
+	webhookRepo interfaces.WebhookRepository
+	venueRepo   interfaces.VenueRepository
+	client      *http.Client
+}
+
+func NewWebhookUseCase(webhookRepo interfaces.WebhookRepository, venueRepo interfaces.VenueRepository) UseCase {
+	return &useCase{
+		webhookRepo: webhookRepo,
+		venueRepo:   venueRepo,
+		client:      newDeliveryClient(),
+	}
+}
+
+// newDeliveryClient checks every address it connects to, which also covers
+// hosts whose DNS changed after registration, and doesn't follow redirects
+func newDeliveryClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: deliveryTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: deliveryTimeout,
+		Transport: &http.Transport{
+			// No proxy: the dialer has to see the receiver's own address
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: deliveryTimeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicIP rejects loopback, private, link-local, multicast and unspecified addresses
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// checkPublicHost resolves host and fails unless every address it has is public
+func checkPublicHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: url host %q could not be resolved", ErrValidation, host)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return fmt.Errorf("%w: url must point to a public address", ErrValidation)
+		}
+	}
+	return nil
+}
+
+func (uc *useCase) RegisterWebhook(ctx context.Context, venueID, ownerID uuid.UUID, req requests.RegisterWebhookRequest) (*responses.WebhookResponse, error) {
+	if err := uc.checkOwner(ctx, venueID, ownerID); err != nil {
+		return nil, err
+	}
+
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) URL", ErrValidation)
+	}
+	if err := checkPublicHost(ctx, target.Hostname()); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	if len(secret) < minSecretLength || len(secret) > maxSecretLength {
+		return nil, fmt.Errorf("%w: secret must be %d-%d characters", ErrValidation, minSecretLength, maxSecretLength)
+	}
+
+	webhook := &models.VenueWebhook{
+		ID:        uuid.New(),
+		VenueID:   venueID,
+		URL:       target.String(),
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookResponse(webhook)
+	response.Secret = secret
+	return &response, nil
+}
+
+func (uc *useCase) ListWebhooks(ctx context.Context, venueID, ownerID uuid.UUID) ([]responses.WebhookResponse, error) {
+	if err := uc.checkOwner(ctx, venueID, ownerID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := uc.webhookRepo.ListByVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookResponses := make([]responses.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		webhookResponses[i] = toWebhookResponse(&webhooks[i])
+	}
+
+	return webhookResponses, nil
+}
+
+func (uc *useCase) DeleteWebhook(ctx context.Context, venueID, webhookID, ownerID uuid.UUID) error {
+	if err := uc.checkOwner(ctx, venueID, ownerID); err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.Delete(ctx, webhookID, venueID); err != nil {
+		return fmt.Errorf("%w: %v", ErrWebhookNotFound, err)
+	}
+
+	return nil
+}
+
+func (uc *useCase) Dispatch(venueID uuid.UUID, event models.WebhookEvent, data interface{}) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		webhooks, err := uc.webhookRepo.ListByVenue(ctx, venueID)
+		cancel()
+		if err != nil {
+			log.Printf("webhook: failed to list webhooks for venue %s: %v", venueID, err)
+			return
+		}
+		if len(webhooks) == 0 {
+			return
+		}
+
+		deliveryID := uuid.New()
+		body, err := json.Marshal(map[string]interface{}{
+			"id":         deliveryID,
+			"event":      event,
+			"venue_id":   venueID,
+			"created_at": time.Now().Format(time.RFC3339),
+			"data":       data,
+		})
+		if err != nil {
+			log.Printf("webhook: failed to encode %s event: %v", event, err)
+			return
+		}
+
+		for _, webhook := range webhooks {
+			go uc.deliver(webhook, deliveryID, event, body)
+		}
+	}()
+}
+
+// deliver posts the event, retrying with exponential backoff on network errors,
+// 5xx responses and 429s. Nothing is persisted, so a delivery still waiting
+// to retry is lost if the process stops.
+func (uc *useCase) deliver(webhook models.VenueWebhook, deliveryID uuid.UUID, event models.WebhookEvent, body []byte) {
+	backoff := initialBackoff
+	for attempt := 1; attempt <= deliveryAttempts; attempt++ {
+		retry, err := uc.post(webhook, deliveryID, event, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt == deliveryAttempts {
+			log.Printf("webhook: giving up on %s delivery %s to %s after %d attempt(s): %v", event, deliveryID, webhook.URL, attempt, err)
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (uc *useCase) post(webhook models.VenueWebhook, deliveryID uuid.UUID, event models.WebhookEvent, body []byte) (bool, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-BadBuddy-Event", string(event))
+	req.Header.Set("X-BadBuddy-Delivery", deliveryID.String())
+	req.Header.Set("X-BadBuddy-Timestamp", timestamp)
+	req.Header.Set("X-BadBuddy-Signature", "sha256="+sign(webhook.Secret, timestamp, body))
+
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, errBlockedAddress), err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// sign computes the hex HMAC-SHA256 of "<timestamp>.<body>". Receivers recompute it
+// with their secret and should reject stale timestamps to prevent replays.
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (uc *useCase) checkOwner(ctx context.Context, venueID, ownerID uuid.UUID) error {
+	venue, err := uc.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVenueNotFound, err)
+	}
+
+	if venue.OwnerID != ownerID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func toWebhookResponse(webhook *models.VenueWebhook) responses.WebhookResponse {
+	return responses.WebhookResponse{
+		ID:        webhook.ID.String(),
+		VenueID:   webhook.VenueID.String(),
+		URL:       webhook.URL,
+		CreatedAt: webhook.CreatedAt.Format(time.RFC3339),
+	}
+}